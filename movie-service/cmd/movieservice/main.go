@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"log/slog"
@@ -18,10 +19,16 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
-	httpAPI "movie-service/internal/api"      // HTTP API
+	httpAPI "movie-service/internal/api" // HTTP API
+	"movie-service/internal/clients"
 	"movie-service/internal/genproto/moviepb" // Сгенерированный gRPC код
-	grpcServer "movie-service/internal/grpc"  // Наш gRPC сервер
+	"movie-service/internal/genproto/userpb"
+	grpcServer "movie-service/internal/grpc" // Наш gRPC сервер
 	"movie-service/internal/store"
+	"movie-service/internal/worker"
+	"shared/auth"
+	"shared/cursor"
+	"shared/userevents"
 )
 
 // getDBConnectionString возвращает строку подключения к БД для MovieService.
@@ -57,6 +64,38 @@ func connectToDB(dbURL string, logger *slog.Logger) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// getEnvDuration читает длительность (например, "5m") из переменной окружения или возвращает значение по умолчанию.
+func getEnvDuration(logger *slog.Logger, name string, def time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		logger.Warn("Invalid duration in environment variable, using default", slog.String("name", name), slog.String("value", raw), slog.Duration("default", def))
+		return def
+	}
+	return value
+}
+
+// newCursorCodec создает подпись курсоров пагинации с ключом из PAGINATION_CURSOR_SECRET.
+// Без переменной сервис не запускается. Для локальной разработки PAGINATION_CURSOR_INSECURE_DEV=true
+// разрешает случайный ключ: курсоры не переживают перезапуск и не подходят другим репликам сервиса.
+func newCursorCodec(logger *slog.Logger) (*cursor.Codec, error) {
+	if secret := os.Getenv("PAGINATION_CURSOR_SECRET"); secret != "" {
+		return cursor.NewCodec([]byte(secret)), nil
+	}
+	if os.Getenv("PAGINATION_CURSOR_INSECURE_DEV") != "true" {
+		return nil, errors.New("PAGINATION_CURSOR_SECRET environment variable not set (set PAGINATION_CURSOR_INSECURE_DEV=true to use a random key in development)")
+	}
+	key, err := cursor.RandomKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cursor signing key: %w", err)
+	}
+	logger.Warn("PAGINATION_CURSOR_SECRET environment variable not set and PAGINATION_CURSOR_INSECURE_DEV is true, using a random key. Pagination cursors will not survive restarts or work across replicas.")
+	return cursor.NewCodec(key), nil
+}
+
 // extractPassword - вспомогательная функция для логирования URL без пароля (упрощенная)
 func extractPassword(dbURL string) string {
 	parts := strings.Split(dbURL, ":")
@@ -75,6 +114,29 @@ func main() {
 
 	httpPort := "8081"
 	grpcPort := "9092"
+	userServiceGRPCAddr := "localhost:9091"
+	reviewServiceGRPCAddr := os.Getenv("REVIEW_SERVICE_GRPC_ADDR")
+	if reviewServiceGRPCAddr == "" {
+		reviewServiceGRPCAddr = "localhost:9093"
+	}
+
+	// --- Проверка JWT по публичным ключам UserService ---
+	jwksURL := os.Getenv("USER_SERVICE_JWKS_URL")
+	if jwksURL == "" {
+		jwksURL = "http://localhost:8080/.well-known/jwks.json"
+		logger.Warn("USER_SERVICE_JWKS_URL environment variable not set, using default.", slog.String("url", jwksURL))
+	}
+	tokenValidator, err := auth.NewTokenValidator(jwksURL, logger)
+	if err != nil {
+		logger.Error("Failed to create token validator", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Info("Token validator initialized.")
+
+	// Политика подтверждения email: по умолчанию пользователи с неподтвержденным email
+	// могут входить, но не могут выполнять действия, публикующие контент
+	requireVerifiedEmail := os.Getenv("REQUIRE_VERIFIED_EMAIL") != "false"
+	logger.Info("Email verification policy configured.", slog.Bool("require_verified_email", requireVerifiedEmail))
 
 	// --- Инициализация хранилища PostgreSQL для MovieService ---
 	dbURL := getDBConnectionString()
@@ -97,6 +159,18 @@ func main() {
 	}
 	logger.Info("PostgreSQL MovieStore initialized for MovieService.")
 
+	// --- Отвязка фильмов удаленных пользователей по событиям UserService ---
+	userSvcClient, err := clients.NewUserServiceGRPCClient(userServiceGRPCAddr, logger)
+	if err != nil {
+		logger.Error("Failed to create UserService gRPC client", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer userSvcClient.Close()
+	consumerCtx, stopConsumers := context.WithCancel(context.Background())
+	defer stopConsumers()
+	userEventConsumer := userevents.NewConsumer[*userpb.UserEvent](userSvcClient, movieStorage, movieStorage.DetachSubmitter, logger, 30*time.Second)
+	go userEventConsumer.Run(consumerCtx)
+
 	// --- Настройка и запуск gRPC сервера ---
 	grpcServiceImplementation := grpcServer.NewServer(movieStorage, logger) // Передаем PostgresMovieStore
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
@@ -115,8 +189,23 @@ func main() {
 		}
 	}()
 
+	// --- Оценки фильмов из ReviewService (копия для фильтра min_rating и сортировки по оценкам) ---
+	reviewSvcClient, err := clients.NewReviewServiceGRPCClient(reviewServiceGRPCAddr, logger)
+	if err != nil {
+		logger.Error("Failed to create ReviewService gRPC client", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer reviewSvcClient.Close()
+	ratingSyncer := worker.NewRatingSyncer(reviewSvcClient, movieStorage, logger, getEnvDuration(logger, "RATING_SYNC_INTERVAL", 5*time.Minute))
+	go ratingSyncer.Run(consumerCtx)
+
 	// --- Настройка и запуск HTTP сервера ---
-	movieAPIHandler := httpAPI.NewMovieHandler(movieStorage, logger, validate) // Передаем PostgresMovieStore
+	cursorCodec, err := newCursorCodec(logger)
+	if err != nil {
+		logger.Error("Failed to set up pagination cursors", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	movieAPIHandler := httpAPI.NewMovieHandler(movieStorage, logger, validate, tokenValidator, cursorCodec, requireVerifiedEmail) // Передаем PostgresMovieStore
 	httpRouter := httpAPI.NewRouter(movieAPIHandler)
 	httpSrv := &http.Server{
 		Addr:         ":" + httpPort,
@@ -138,6 +227,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("MovieService shutting down...")
+	stopConsumers()
 
 	ctxHttp, cancelHttp := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelHttp()
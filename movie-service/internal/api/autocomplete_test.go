@@ -0,0 +1,99 @@
+// movie-service/internal/api/autocomplete_test.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"movie-service/internal/domain"
+	"movie-service/internal/store"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// suggestRecorder запоминает, с каким limit вызывался SuggestTitles.
+type suggestRecorder struct {
+	store.MovieStore
+	calls int
+	limit int
+}
+
+func (s *suggestRecorder) SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.MovieSuggestion, error) {
+	s.calls++
+	s.limit = limit
+	return s.MovieStore.SuggestTitles(ctx, query, limit)
+}
+
+func TestGetMovieAutocomplete(t *testing.T) {
+	movieStore := store.NewMockMovieStore()
+	for _, movie := range []*domain.Movie{
+		{ID: "interstellar", Title: "Interstellar", ReleaseYear: 2014, Status: domain.StatusApproved},
+		{ID: "interstellar-cut", Title: "Interstellar Director's Cut", Status: domain.StatusPendingApproval},
+	} {
+		if err := movieStore.Create(context.Background(), movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		q         string
+		limit     string
+		wantCalls int
+		wantLimit int
+		wantIDs   []string
+	}{
+		{name: "typo", q: "Interstelar", wantCalls: 1, wantLimit: defaultAutocompleteLimit, wantIDs: []string{"interstellar"}},
+		{name: "approved only", q: "interstellar", wantCalls: 1, wantLimit: defaultAutocompleteLimit, wantIDs: []string{"interstellar"}},
+		{name: "limit above the cap", q: "interstellar", limit: "1000", wantCalls: 1, wantLimit: maxAutocompleteLimit, wantIDs: []string{"interstellar"}},
+		{name: "explicit limit", q: "interstellar", limit: "3", wantCalls: 1, wantLimit: 3, wantIDs: []string{"interstellar"}},
+		{name: "two characters", q: "in", wantCalls: 1, wantLimit: defaultAutocompleteLimit, wantIDs: []string{"interstellar"}},
+		{name: "empty query", q: ""},
+		{name: "one character", q: "i"},
+		{name: "one character with spaces", q: "  i  "},
+		{name: "one multibyte character", q: "ж"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &suggestRecorder{MovieStore: movieStore}
+			handler := NewMovieHandler(recorder, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), nil, nil, false)
+
+			query := url.Values{"q": {tt.q}}
+			if tt.limit != "" {
+				query.Set("limit", tt.limit)
+			}
+			rec := httptest.NewRecorder()
+			handler.GetMovieAutocomplete(rec, httptest.NewRequest(http.MethodGet, "/api/movies/autocomplete?"+query.Encode(), nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("code = %d, body %s", rec.Code, rec.Body.String())
+			}
+			var resp struct {
+				Suggestions []domain.MovieSuggestion `json:"suggestions"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Suggestions == nil {
+				t.Fatalf("suggestions is null: %s", rec.Body.String())
+			}
+
+			if recorder.calls != tt.wantCalls || recorder.limit != tt.wantLimit {
+				t.Errorf("store called %d times with limit %d, want %d times with limit %d", recorder.calls, recorder.limit, tt.wantCalls, tt.wantLimit)
+			}
+			var gotIDs []string
+			for _, suggestion := range resp.Suggestions {
+				gotIDs = append(gotIDs, suggestion.ID)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("suggestions = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -6,11 +6,19 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv" // <--- РАСКОММЕНТИРОВАН для GetMovies
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"movie-service/internal/domain"
 	"movie-service/internal/store"
+	"shared/auth"
+	"shared/authz"
+	"shared/cursor"
+	"shared/sortspec"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -23,15 +31,21 @@ type MovieHandler struct {
 	store     store.MovieStore
 	logger    *slog.Logger
 	validator *validator.Validate
+	authn     *auth.Middleware // Проверка токенов UserService и политики подтвержденного email
+	cursors   *cursor.Codec    // Подпись курсоров пагинации списка фильмов
 }
 
 // NewMovieHandler создает новый экземпляр MovieHandler.
-func NewMovieHandler(s store.MovieStore, l *slog.Logger, v *validator.Validate) *MovieHandler {
-	return &MovieHandler{
+// requireVerifiedEmail запрещает предлагать фильмы пользователям с неподтвержденным email.
+func NewMovieHandler(s store.MovieStore, l *slog.Logger, v *validator.Validate, tv auth.TokenValidator, cursors *cursor.Codec, requireVerifiedEmail bool) *MovieHandler {
+	h := &MovieHandler{
 		store:     s,
 		logger:    l,
 		validator: v,
+		cursors:   cursors,
 	}
+	h.authn = auth.NewMiddleware(tv, h.respondError, l, requireVerifiedEmail)
+	return h
 }
 
 // --- Вспомогательные функции ---
@@ -49,15 +63,32 @@ func (h *MovieHandler) respondError(w http.ResponseWriter, r *http.Request, stat
 	h.respondJSON(w, r, status, map[string]string{"error": message})
 }
 
+// respondSortError отвечает 400 на неверную сортировку и перечисляет разрешенные поля.
+func (h *MovieHandler) respondSortError(w http.ResponseWriter, r *http.Request, err error) {
+	var sortErr *sortspec.Error
+	if !errors.As(err, &sortErr) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid sort: "+err.Error())
+		return
+	}
+	h.respondJSON(w, r, http.StatusBadRequest, map[string]interface{}{
+		"error":               "Invalid sort: " + sortErr.Reason,
+		"allowed_sort_fields": sortErr.Allowed,
+	})
+}
+
 // --- Обработчики ---
 
 // CreateMovie обрабатывает запрос на создание нового фильма.
 func (h *MovieHandler) CreateMovie(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	var submittedUserIDStr string
-	submittedUserIDStr = uuid.Nil.String() // Используем nil UUID ("00000000-0000-0000-0000-000000000000")
+	submittedUserIDStr, ok := ctx.Value(UserIDKey).(string)
+	if !ok || submittedUserIDStr == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
 
-	h.logger.InfoContext(ctx, "HTTP CreateMovie request received", slog.String("path", r.URL.Path))
+	h.logger.InfoContext(ctx, "HTTP CreateMovie request received", slog.String("path", r.URL.Path), slog.String("userID", submittedUserIDStr))
 
 	var req domain.CreateMovieRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -81,7 +112,7 @@ func (h *MovieHandler) CreateMovie(w http.ResponseWriter, r *http.Request) {
 		Description:       req.Description,
 		ReleaseYear:       req.ReleaseYear,
 		Director:          req.Director,
-		Genres:            pq.StringArray(req.Genres),
+		Genres:            pq.StringArray(domain.NormalizeGenres(req.Genres)),
 		Cast:              pq.StringArray(req.Cast),
 		PosterURL:         req.PosterURL,
 		TrailerURL:        req.TrailerURL,
@@ -127,44 +158,143 @@ func (h *MovieHandler) GetMovies(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Параметры фильтрации и сортировки
-	params := store.MovieListParams{
-		Page:        page,
-		PageSize:    pageSize,
-		Genre:       queryParams.Get("genre"),
-		SearchQuery: queryParams.Get("search"),
-		SortBy:      queryParams.Get("sort_by"),
-		Status:      domain.StatusApproved, // Для публичного списка всегда только одобренные фильмы
-	}
-	if yearStr := queryParams.Get("year"); yearStr != "" {
-		if yearVal, err := strconv.Atoi(yearStr); err == nil {
-			params.Year = yearVal
-		}
+	params, err := parseMovieFilters(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
+	}
+	params.Page = page
+	params.PageSize = pageSize
+	params.SearchQuery = queryParams.Get("search")
+	if params.Sort, err = sortspec.FromQuery(queryParams, store.MovieSortFields); err != nil {
+		h.respondSortError(w, r, err)
+		return
 	}
+	if params.Sort.Has(store.MovieSortRelevance) && strings.TrimSpace(params.SearchQuery) == "" {
+		h.respondSortError(w, r, &sortspec.Error{Reason: "relevance sort requires a search query", Allowed: store.MovieSortFields})
+		return
+	}
+	params.Status = domain.StatusApproved // Для публичного списка всегда только одобренные фильмы
 
-	movies, totalCount, err := h.store.List(ctx, params)
+	// Keyset-пагинация: cursor из next_cursor/prev_cursor предыдущего ответа заменяет page
+	if params.Cursor, err = h.cursors.FromQuery(queryParams); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	includeTotal, err := cursor.IncludeTotal(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	params.SkipCount = !includeTotal
+	withFacets, err := facetsRequested(queryParams, params.Cursor == nil && page == 1)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	moviePage, err := h.store.List(ctx, params)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor: it was issued for a different sort or filters")
+		return
+	}
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Failed to list movies from store", slog.String("error", err.Error()))
 		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve movies")
 		return
 	}
+	movies, totalCount := moviePage.Movies, moviePage.TotalCount
+
+	// Фасеты не зависят от страницы, поэтому по умолчанию считаются только для первой
+	var facets *domain.MovieFacets
+	if withFacets {
+		facets = &domain.MovieFacets{Genres: []domain.FacetCount{}, Decades: []domain.FacetCount{}, Directors: []domain.FacetCount{}}
+		if totalCount != 0 { // 0 - фильмов нет; при -1 количество не считалось, и фильмы могут быть
+			if facets, err = h.store.Facets(ctx, params); err != nil {
+				h.logger.ErrorContext(ctx, "Failed to count movie facets", slog.String("error", err.Error()))
+				h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve movies")
+				return
+			}
+		}
+	}
 
 	// Формируем ответ с пагинацией
 	response := struct {
-		Movies     []*domain.Movie `json:"movies"`
-		TotalCount int             `json:"total_count"`
-		Page       int             `json:"page"`
-		PageSize   int             `json:"page_size"`
+		Movies     []*domain.Movie     `json:"movies"`
+		TotalCount *int                `json:"total_count,omitempty"` // Нет при include_total=false
+		Page       int                 `json:"page,omitempty"`        // Только при пагинации по номеру страницы
+		PageSize   int                 `json:"page_size"`
+		NextCursor string              `json:"next_cursor,omitempty"`
+		PrevCursor string              `json:"prev_cursor,omitempty"`
+		Facets     *domain.MovieFacets `json:"facets,omitempty"` // Только если запрошены (см. facetsRequested)
 	}{
 		Movies:     movies,
-		TotalCount: totalCount,
-		Page:       params.Page,
 		PageSize:   params.PageSize,
+		NextCursor: h.cursors.Encode(moviePage.Next),
+		PrevCursor: h.cursors.Encode(moviePage.Prev),
+		Facets:     facets,
+	}
+	if includeTotal {
+		response.TotalCount = &totalCount
+	}
+	if params.Cursor == nil {
+		response.Page = params.Page
 	}
 
 	h.logger.InfoContext(ctx, "Movies list retrieved successfully", slog.Int("count_returned", len(movies)), slog.Int("total_available", totalCount))
 	h.respondJSON(w, r, http.StatusOK, response)
 }
 
+// facetsRequested читает параметр запроса facets. Без него фасеты считаются только для первой страницы
+// (firstPage): при листании они не меняются, а запрос по всем подходящим фильмам дорогой.
+func facetsRequested(query url.Values, firstPage bool) (bool, error) {
+	value := query.Get("facets")
+	if value == "" {
+		return firstPage, nil
+	}
+	requested, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("facets must be true or false")
+	}
+	return requested, nil
+}
+
+// Параметры автодополнения названий
+const (
+	minAutocompleteQueryLength = 2
+	defaultAutocompleteLimit   = 10
+	maxAutocompleteLimit       = 20
+)
+
+// GetMovieAutocomplete подсказывает названия одобренных фильмов по началу или с опечатками
+// (GET /api/movies/autocomplete?q=). Параметры: q, limit.
+// Вызывается на каждое нажатие клавиши, поэтому слишком короткие запросы не доходят до хранилища.
+func (h *MovieHandler) GetMovieAutocomplete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryParams := r.URL.Query()
+	query := strings.TrimSpace(queryParams.Get("q"))
+	limit, _ := strconv.Atoi(queryParams.Get("limit"))
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	} else if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	suggestions := []*domain.MovieSuggestion{}
+	if utf8.RuneCountInString(query) >= minAutocompleteQueryLength {
+		var err error
+		if suggestions, err = h.store.SuggestTitles(ctx, query, limit); err != nil {
+			h.logger.ErrorContext(ctx, "Failed to suggest movie titles", slog.String("query", query), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve suggestions")
+			return
+		}
+	}
+
+	// Одинаковые запросы повторяются при наборе, подсказки можно недолго кешировать
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	h.respondJSON(w, r, http.StatusOK, map[string]interface{}{"suggestions": suggestions})
+}
+
 // GetMovieByID получает фильм по ID (теперь должен работать с PostgreSQL)
 func (h *MovieHandler) GetMovieByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -193,15 +323,216 @@ func (h *MovieHandler) GetMovieByID(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, r, http.StatusOK, movie)
 }
 
-// GetPendingMovies - ЗАГЛУШКА (но можно реализовать аналогично GetMovies)
+// UpdateMovie частично обновляет фильм (PATCH). Разрешено автору заявки и обладателям movie:edit_any.
+// Статус меняется только с разрешением movie:approve (без него поле status отклоняется с 403);
+// если пользователь без этого разрешения действительно меняет одобренный фильм, фильм возвращается
+// на модерацию. Запрос без изменений (пустой или с текущими значениями) ничего не записывает.
+func (h *MovieHandler) UpdateMovie(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	movieID := mux.Vars(r)["movieId"]
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	canEditAny := authz.Has(ctx, authz.PermMovieEditAny)
+	canModerate := authz.Has(ctx, authz.PermMovieApprove)
+	h.logger.InfoContext(ctx, "UpdateMovie endpoint hit", slog.String("movieID", movieID), slog.String("userID", userID))
+
+	var req domain.UpdateMovieRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode movie update request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.logger.ErrorContext(ctx, "Movie update request validation failed", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+	if req.Status != nil && !canModerate {
+		h.logger.WarnContext(ctx, "User without moderation permission attempted to change movie status", slog.String("movieID", movieID), slog.String("userID", userID))
+		h.respondError(w, r, http.StatusForbidden, "Permission required: "+string(authz.PermMovieApprove))
+		return
+	}
+
+	movie, err := h.store.GetByID(ctx, movieID)
+	if err != nil {
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Error finding movie for update", slog.String("movieID", movieID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to update movie")
+		}
+		return
+	}
+
+	if movie.SubmittedByUserID != userID && !canEditAny {
+		h.logger.WarnContext(ctx, "User attempted to edit a movie submitted by someone else", slog.String("movieID", movieID), slog.String("userID", userID))
+		h.respondError(w, r, http.StatusForbidden, "You can only edit movies you submitted")
+		return
+	}
+
+	// Обновляем только переданные поля и запоминаем, изменилось ли что-нибудь
+	if !applyMovieUpdate(movie, &req) {
+		h.logger.InfoContext(ctx, "Movie update request changes nothing, skipping write", slog.String("movieID", movieID))
+		h.respondJSON(w, r, http.StatusOK, movie)
+		return
+	}
+	if req.Status == nil && !canModerate && movie.Status == domain.StatusApproved {
+		movie.Status = domain.StatusPendingApproval // Правки пользователя снова проходят модерацию
+	}
+
+	if err := h.store.Update(ctx, movie); err != nil {
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found")
+		} else if errors.Is(err, store.ErrMovieAlreadyExists) {
+			h.respondError(w, r, http.StatusConflict, "Movie with this title might already exist.")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to update movie in store", slog.String("movieID", movieID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to update movie")
+		}
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Movie updated successfully", slog.String("movieID", movieID), slog.String("status", string(movie.Status)))
+	h.respondJSON(w, r, http.StatusOK, movie)
+}
+
+// applyMovieUpdate переносит в movie переданные поля req и сообщает, изменилось ли хотя бы одно значение.
+func applyMovieUpdate(movie *domain.Movie, req *domain.UpdateMovieRequest) bool {
+	changed := updateField(&movie.Title, req.Title)
+	changed = updateField(&movie.Description, req.Description) || changed
+	changed = updateField(&movie.ReleaseYear, req.ReleaseYear) || changed
+	changed = updateField(&movie.Director, req.Director) || changed
+	changed = updateListField(&movie.Genres, domain.NormalizeGenres(req.Genres)) || changed
+	changed = updateListField(&movie.Cast, req.Cast) || changed
+	changed = updateField(&movie.PosterURL, req.PosterURL) || changed
+	changed = updateField(&movie.TrailerURL, req.TrailerURL) || changed
+	if req.Status != nil {
+		status := domain.MovieStatus(*req.Status)
+		changed = updateField(&movie.Status, &status) || changed
+	}
+	return changed
+}
+
+// updateField присваивает *field = *value, если value передано и отличается; возвращает true при изменении.
+func updateField[T comparable](field *T, value *T) bool {
+	if value == nil || *field == *value {
+		return false
+	}
+	*field = *value
+	return true
+}
+
+// updateListField - updateField для списков (жанры, актеры); порядок элементов учитывается.
+func updateListField(field *pq.StringArray, value []string) bool {
+	if value == nil || slices.Equal(*field, value) {
+		return false
+	}
+	*field = pq.StringArray(value)
+	return true
+}
+
+// DeleteMovie мягко удаляет фильм. Разрешено автору заявки и обладателям movie:delete_any.
+func (h *MovieHandler) DeleteMovie(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	movieID := mux.Vars(r)["movieId"]
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "DeleteMovie endpoint hit", slog.String("movieID", movieID), slog.String("userID", userID))
+
+	movie, err := h.store.GetByID(ctx, movieID)
+	if err != nil {
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Error finding movie for delete", slog.String("movieID", movieID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete movie")
+		}
+		return
+	}
+
+	if movie.SubmittedByUserID != userID && !authz.Has(ctx, authz.PermMovieDeleteAny) {
+		h.logger.WarnContext(ctx, "User attempted to delete a movie submitted by someone else", slog.String("movieID", movieID), slog.String("userID", userID))
+		h.respondError(w, r, http.StatusForbidden, "You can only delete movies you submitted")
+		return
+	}
+
+	if err := h.store.Delete(ctx, movieID); err != nil {
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to delete movie in store", slog.String("movieID", movieID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete movie")
+		}
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Movie deleted successfully", slog.String("movieID", movieID), slog.String("deletedBy", userID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPendingMovies возвращает фильмы, ожидающие модерации. Фильтры и сортировка - как у GetMovies,
+// пагинация только по номеру страницы.
 func (h *MovieHandler) GetPendingMovies(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.InfoContext(ctx, "GetPendingMovies endpoint hit (STUB)")
-	// TODO: Реализовать получение списка фильмов со статусом pending_approval
-	// params := store.MovieListParams{ Status: domain.StatusPendingApproval, ... }
-	// movies, _, err := h.store.List(ctx, params)
-	// if err != nil { ... }
-	h.respondJSON(w, r, http.StatusOK, []domain.Movie{})
+	queryParams := r.URL.Query()
+	h.logger.InfoContext(ctx, "GetPendingMovies endpoint hit", slog.String("query", queryParams.Encode()))
+
+	page, _ := strconv.Atoi(queryParams.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(queryParams.Get("limit"))
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+
+	params, err := parseMovieFilters(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
+	}
+	params.Page = page
+	params.PageSize = pageSize
+	if params.Sort, err = sortspec.FromQuery(queryParams, store.MovieSortFields); err != nil {
+		h.respondSortError(w, r, err)
+		return
+	}
+	if params.Sort.Has(store.MovieSortRelevance) {
+		h.respondSortError(w, r, &sortspec.Error{Reason: "relevance sort requires a search query", Allowed: store.MovieSortFields})
+		return
+	}
+	params.Status = domain.StatusPendingApproval
+
+	moviePage, err := h.store.List(ctx, params)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list pending movies from store", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve pending movies")
+		return
+	}
+
+	h.respondJSON(w, r, http.StatusOK, struct {
+		Movies     []*domain.Movie `json:"movies"`
+		TotalCount int             `json:"total_count"`
+		Page       int             `json:"page"`
+		PageSize   int             `json:"page_size"`
+	}{
+		Movies:     moviePage.Movies,
+		TotalCount: moviePage.TotalCount,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+	})
 }
 
 // ApproveMovie использует h.store.UpdateStatus
@@ -236,12 +567,34 @@ func (h *MovieHandler) ApproveMovie(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, r, http.StatusOK, map[string]string{"message": "Movie approved successfully"})
 }
 
-// RejectMovie - ЗАГЛУШКА (но можно сделать аналогично ApproveMovie)
+// RejectMovie использует h.store.UpdateStatus
 func (h *MovieHandler) RejectMovie(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	movieID := vars["movieId"]
 	ctx := r.Context()
 	h.logger.InfoContext(ctx, "RejectMovie endpoint hit", slog.String("movieID", movieID))
-	// TODO: Реализовать вызов h.store.UpdateStatus(ctx, movieID, domain.StatusRejected)
-	h.respondJSON(w, r, http.StatusOK, map[string]string{"message": "Movie rejected successfully (stub response)"})
+
+	_, err := h.store.GetByID(ctx, movieID)
+	if err != nil {
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found, cannot reject")
+		} else {
+			h.logger.ErrorContext(ctx, "Error finding movie for rejection", slog.String("movieID", movieID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Error finding movie before rejection")
+		}
+		return
+	}
+
+	if err := h.store.UpdateStatus(ctx, movieID, domain.StatusRejected); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to update movie status for rejection", slog.String("movieID", movieID), slog.String("error", err.Error()))
+		if errors.Is(err, store.ErrMovieNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Movie not found, cannot reject (update status failed)")
+		} else {
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to reject movie")
+		}
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Movie rejected successfully", slog.String("movieID", movieID))
+	h.respondJSON(w, r, http.StatusOK, map[string]string{"message": "Movie rejected successfully"})
 }
@@ -0,0 +1,30 @@
+// movie-service/internal/api/middleware.go
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"shared/auth"
+	"shared/authz"
+)
+
+// Ключи контекста, которые заполняет auth.Middleware.
+const (
+	UserIDKey   = auth.UserIDKey
+	UserRoleKey = auth.UserRoleKey
+)
+
+// PermissionMiddleware пропускает дальше только пользователей, чей токен несет разрешение perm.
+// Должен применяться после auth.Middleware.Authenticate.
+func (h *MovieHandler) PermissionMiddleware(perm authz.Permission) func(http.Handler) http.Handler {
+	return authz.Require(perm, h.respondPermissionDenied)
+}
+
+// respondPermissionDenied отвечает 403 на запрос без нужного разрешения.
+func (h *MovieHandler) respondPermissionDenied(w http.ResponseWriter, r *http.Request, perm authz.Permission) {
+	userID, _ := r.Context().Value(UserIDKey).(string)
+	role, _ := r.Context().Value(UserRoleKey).(string)
+	h.logger.WarnContext(r.Context(), "User without required permission attempted a restricted action", slog.String("userID", userID), slog.String("role", role), slog.String("permission", string(perm)), slog.String("path", r.URL.Path))
+	h.respondError(w, r, http.StatusForbidden, "Permission required: "+string(perm))
+}
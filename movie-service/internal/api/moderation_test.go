@@ -0,0 +1,84 @@
+// movie-service/internal/api/moderation_test.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"movie-service/internal/domain"
+	"movie-service/internal/store"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func TestGetPendingMovies(t *testing.T) {
+	movieStore := store.NewMockMovieStore()
+	handler := NewMovieHandler(movieStore, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), nil, nil, false)
+
+	rec := httptest.NewRecorder()
+	handler.GetPendingMovies(rec, httptest.NewRequest(http.MethodGet, "/api/admin/movies/pending", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Movies     []domain.Movie `json:"movies"`
+		TotalCount int            `json:"total_count"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.TotalCount != 1 || len(resp.Movies) != 1 || resp.Movies[0].ID != "pending-movie-id" {
+		t.Errorf("pending movies = %+v (total %d), want only pending-movie-id", resp.Movies, resp.TotalCount)
+	}
+}
+
+func TestModerateMovie(t *testing.T) {
+	tests := []struct {
+		name       string
+		movieID    string
+		reject     bool
+		wantCode   int
+		wantStatus domain.MovieStatus
+	}{
+		{name: "approve pending movie", movieID: "pending-movie-id", wantCode: http.StatusOK, wantStatus: domain.StatusApproved},
+		{name: "reject pending movie", movieID: "pending-movie-id", reject: true, wantCode: http.StatusOK, wantStatus: domain.StatusRejected},
+		{name: "reject approved movie", movieID: "existing-approved-id", reject: true, wantCode: http.StatusOK, wantStatus: domain.StatusRejected},
+		{name: "reject unknown movie", movieID: "no-such-movie", reject: true, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieStore := store.NewMockMovieStore()
+			handler := NewMovieHandler(movieStore, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), nil, nil, false)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/movies/"+tt.movieID+"/approve", nil)
+			moderate := handler.ApproveMovie
+			if tt.reject {
+				req = httptest.NewRequest(http.MethodPost, "/api/admin/movies/"+tt.movieID+"/reject", nil)
+				moderate = handler.RejectMovie
+			}
+			req = mux.SetURLVars(req, map[string]string{"movieId": tt.movieID})
+			rec := httptest.NewRecorder()
+			moderate(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantStatus == "" {
+				return
+			}
+			movie, err := movieStore.GetByID(context.Background(), tt.movieID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if movie.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", movie.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// movie-service/internal/api/movie_filters.go
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"movie-service/internal/store"
+)
+
+// Фильтры списка фильмов (GET /api/movies):
+//   - genre - жанр; можно повторять (?genre=drama&genre=comedy) или перечислять через запятую;
+//   - genre_mode - any (по умолчанию, хотя бы один из жанров) или all (все жанры);
+//   - year - точный год, либо year_from / year_to - диапазон (границы включаются);
+//   - director, cast - режиссер и актер (точное совпадение без учета регистра);
+//   - min_rating - минимальная средняя оценка по отзывам (шкала 1-10; копия оценок ReviewService в movies.average_rating).
+
+const (
+	maxGenreFilters = 20
+	maxMovieRating  = 10
+)
+
+// parseMovieFilters разбирает параметры фильтрации.
+func parseMovieFilters(query url.Values) (store.MovieListParams, error) {
+	var params store.MovieListParams
+
+	for _, value := range query["genre"] {
+		for _, genre := range strings.Split(value, ",") {
+			if genre = strings.TrimSpace(genre); genre != "" {
+				params.Genres = append(params.Genres, genre)
+			}
+		}
+	}
+	if len(params.Genres) > maxGenreFilters {
+		return params, fmt.Errorf("at most %d genres can be requested", maxGenreFilters)
+	}
+	switch query.Get("genre_mode") {
+	case "", "any":
+	case "all":
+		params.GenreMatchAll = true
+	default:
+		return params, errors.New("genre_mode must be 'any' or 'all'")
+	}
+
+	year, err := parseYearParam(query, "year")
+	if err != nil {
+		return params, err
+	}
+	if params.YearFrom, err = parseYearParam(query, "year_from"); err != nil {
+		return params, err
+	}
+	if params.YearTo, err = parseYearParam(query, "year_to"); err != nil {
+		return params, err
+	}
+	if year != 0 {
+		if params.YearFrom != 0 || params.YearTo != 0 {
+			return params, errors.New("year cannot be combined with year_from or year_to")
+		}
+		params.YearFrom, params.YearTo = year, year
+	}
+	if params.YearFrom != 0 && params.YearTo != 0 && params.YearFrom > params.YearTo {
+		return params, errors.New("year_from must not be greater than year_to")
+	}
+
+	params.Director = strings.TrimSpace(query.Get("director"))
+	params.CastMember = strings.TrimSpace(query.Get("cast"))
+
+	if value := query.Get("min_rating"); value != "" {
+		params.MinRating, err = strconv.ParseFloat(value, 64)
+		if err != nil || !(params.MinRating > 0 && params.MinRating <= maxMovieRating) {
+			return params, fmt.Errorf("min_rating must be a number greater than 0 and at most %d", maxMovieRating)
+		}
+	}
+	return params, nil
+}
+
+// parseYearParam возвращает год из параметра name (0, если параметр не задан).
+func parseYearParam(query url.Values, name string) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil || year <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return year, nil
+}
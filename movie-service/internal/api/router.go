@@ -4,6 +4,8 @@ package api
 import (
 	"github.com/gorilla/mux"
 	"net/http"
+
+	"shared/authz"
 )
 
 func NewRouter(handler *MovieHandler) *mux.Router {
@@ -15,14 +17,18 @@ func NewRouter(handler *MovieHandler) *mux.Router {
 
 	// Эндпоинты для фильмов
 	moviesRouter := apiRouter.PathPrefix("/movies").Subrouter()
-	moviesRouter.HandleFunc("", handler.CreateMovie).Methods(http.MethodPost)
+	moviesRouter.Handle("", handler.authn.RequireVerifiedAuth(handler.CreateMovie)).Methods(http.MethodPost) // Требует JWT и подтвержденный email, автор берется из токена
 	moviesRouter.HandleFunc("", handler.GetMovies).Methods(http.MethodGet)
+	moviesRouter.HandleFunc("/autocomplete", handler.GetMovieAutocomplete).Methods(http.MethodGet) // Регистрируется до /{movieId}
 	moviesRouter.HandleFunc("/{movieId}", handler.GetMovieByID).Methods(http.MethodGet)
-	// ... другие маршруты для фильмов ...
+	moviesRouter.Handle("/{movieId}", handler.authn.RequireAuth(handler.UpdateMovie)).Methods(http.MethodPatch)  // Автор заявки или movie:edit_any
+	moviesRouter.Handle("/{movieId}", handler.authn.RequireAuth(handler.DeleteMovie)).Methods(http.MethodDelete) // Мягкое удаление, автор заявки или movie:delete_any
 
 	// Эндпоинты для администрирования/модерации фильмов
 	// Путь будет /api/movies/admin/...
+	// Доступ только с разрешением movie:approve (роли moderator и admin), иначе 401/403
 	adminMoviesRouter := moviesRouter.PathPrefix("/admin").Subrouter()
+	adminMoviesRouter.Use(handler.authn.Authenticate, handler.PermissionMiddleware(authz.PermMovieApprove))
 	adminMoviesRouter.HandleFunc("/pending", handler.GetPendingMovies).Methods(http.MethodGet)
 	adminMoviesRouter.HandleFunc("/{movieId}/approve", handler.ApproveMovie).Methods(http.MethodPost) // Маршрут для одобрения
 	adminMoviesRouter.HandleFunc("/{movieId}/reject", handler.RejectMovie).Methods(http.MethodPost)
@@ -0,0 +1,63 @@
+// movie-service/internal/api/update_movie_test.go
+package api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"movie-service/internal/domain"
+	"movie-service/internal/store"
+	"shared/authz"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func TestUpdateMovie(t *testing.T) {
+	const movieID = "existing-approved-id" // Одобренный фильм пользователя user1 из NewMockMovieStore
+	tests := []struct {
+		name        string
+		body        string
+		permissions []string
+		wantCode    int
+		wantStatus  domain.MovieStatus
+		wantWrite   bool
+	}{
+		{name: "empty body changes nothing", body: `{}`, wantCode: http.StatusOK, wantStatus: domain.StatusApproved},
+		{name: "same values in another case change nothing", body: `{"title":"Одобренный тестовый фильм 1","genres":["Sci-Fi","Action"]}`, wantCode: http.StatusOK, wantStatus: domain.StatusApproved},
+		{name: "real change re-queues for moderation", body: `{"title":"Новое название"}`, wantCode: http.StatusOK, wantStatus: domain.StatusPendingApproval, wantWrite: true},
+		{name: "submitter cannot set status", body: `{"status":"approved"}`, wantCode: http.StatusForbidden, wantStatus: domain.StatusApproved},
+		{name: "moderator change keeps approval", body: `{"title":"Новое название"}`, permissions: []string{string(authz.PermMovieApprove)}, wantCode: http.StatusOK, wantStatus: domain.StatusApproved, wantWrite: true},
+		{name: "moderator can set status", body: `{"status":"rejected"}`, permissions: []string{string(authz.PermMovieApprove)}, wantCode: http.StatusOK, wantStatus: domain.StatusRejected, wantWrite: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieStore := store.NewMockMovieStore()
+			before, _ := movieStore.GetByID(context.Background(), movieID)
+			handler := NewMovieHandler(movieStore, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), nil, nil, false)
+
+			ctx := context.WithValue(context.Background(), UserIDKey, "user1")
+			ctx = authz.WithPermissions(ctx, tt.permissions)
+			req := httptest.NewRequest(http.MethodPatch, "/api/movies/"+movieID, strings.NewReader(tt.body)).WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"movieId": movieID})
+			rec := httptest.NewRecorder()
+			handler.UpdateMovie(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			after, _ := movieStore.GetByID(context.Background(), movieID)
+			if after.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", after.Status, tt.wantStatus)
+			}
+			if written := !after.UpdatedAt.Equal(before.UpdatedAt); written != tt.wantWrite {
+				t.Errorf("store written = %v, want %v", written, tt.wantWrite)
+			}
+		})
+	}
+}
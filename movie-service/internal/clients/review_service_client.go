@@ -0,0 +1,64 @@
+// movie-service/internal/clients/review_service_client.go
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"movie-service/internal/genproto/reviewpb" // Копия сгенерированного кода из review-service
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ReviewServiceClient определяет методы ReviewService, которые использует MovieService.
+type ReviewServiceClient interface {
+	// ListMovieRatings возвращает до limit оценок фильмов с ID больше afterMovieID, по возрастанию ID.
+	ListMovieRatings(ctx context.Context, afterMovieID string, limit int32) ([]*reviewpb.MovieRating, error)
+	Close() error
+}
+
+// reviewServiceGRPCClient реализует ReviewServiceClient с использованием gRPC.
+type reviewServiceGRPCClient struct {
+	client reviewpb.ReviewInterServiceClient
+	logger *slog.Logger
+	conn   *grpc.ClientConn
+}
+
+// NewReviewServiceGRPCClient создает gRPC клиент для ReviewService (соединение устанавливается лениво).
+func NewReviewServiceGRPCClient(reviewServiceAddr string, logger *slog.Logger) (ReviewServiceClient, error) {
+	conn, err := grpc.NewClient(reviewServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials())) // Для разработки; в продакшене используйте TLS
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review service client for %s: %w", reviewServiceAddr, err)
+	}
+	logger.Info("ReviewService gRPC client created", slog.String("address", reviewServiceAddr))
+	return &reviewServiceGRPCClient{
+		client: reviewpb.NewReviewInterServiceClient(conn),
+		logger: logger,
+		conn:   conn,
+	}, nil
+}
+
+// ListMovieRatings вызывает gRPC метод ListMovieRatings на ReviewService.
+func (c *reviewServiceGRPCClient) ListMovieRatings(ctx context.Context, afterMovieID string, limit int32) ([]*reviewpb.MovieRating, error) {
+	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := c.client.ListMovieRatings(callCtx, &reviewpb.ListMovieRatingsRequest{AfterMovieId: afterMovieID, Limit: limit})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "ReviewService.ListMovieRatings gRPC call failed", slog.String("after_movie_id", afterMovieID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("grpc ListMovieRatings failed after movie %q: %w", afterMovieID, err)
+	}
+	return res.GetRatings(), nil
+}
+
+// Close закрывает gRPC соединение.
+func (c *reviewServiceGRPCClient) Close() error {
+	if c.conn != nil {
+		c.logger.Info("Closing gRPC connection to ReviewService")
+		return c.conn.Close()
+	}
+	return nil
+}
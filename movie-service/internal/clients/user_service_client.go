@@ -0,0 +1,66 @@
+// movie-service/internal/clients/user_service_client.go
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"movie-service/internal/genproto/userpb" // Копия сгенерированного кода из user-service
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// UserServiceClient определяет методы UserService, которые использует MovieService.
+type UserServiceClient interface {
+	// ListUserEvents возвращает события пользователей (удаления аккаунтов) после afterID.
+	ListUserEvents(ctx context.Context, afterID int64, limit int32) ([]*userpb.UserEvent, error)
+	Close() error
+}
+
+// userServiceGRPCClient реализует UserServiceClient с использованием gRPC.
+type userServiceGRPCClient struct {
+	client userpb.UserServiceClient
+	logger *slog.Logger
+	conn   *grpc.ClientConn
+}
+
+// NewUserServiceGRPCClient создает gRPC клиент для UserService.
+// Соединение устанавливается лениво: MovieService запускается, даже если UserService пока недоступен,
+// а вызовы начнут проходить, когда он поднимется.
+func NewUserServiceGRPCClient(userServiceAddr string, logger *slog.Logger) (UserServiceClient, error) {
+	conn, err := grpc.NewClient(userServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials())) // Для разработки; в продакшене используйте TLS
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user service client for %s: %w", userServiceAddr, err)
+	}
+	logger.Info("UserService gRPC client created", slog.String("address", userServiceAddr))
+	return &userServiceGRPCClient{
+		client: userpb.NewUserServiceClient(conn),
+		logger: logger,
+		conn:   conn,
+	}, nil
+}
+
+// ListUserEvents вызывает gRPC метод ListUserEvents на UserService.
+func (c *userServiceGRPCClient) ListUserEvents(ctx context.Context, afterID int64, limit int32) ([]*userpb.UserEvent, error) {
+	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := c.client.ListUserEvents(callCtx, &userpb.ListUserEventsRequest{AfterId: afterID, Limit: limit})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "UserService.ListUserEvents gRPC call failed", slog.Int64("after_id", afterID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("grpc ListUserEvents failed after event %d: %w", afterID, err)
+	}
+	return res.GetEvents(), nil
+}
+
+// Close закрывает gRPC соединение.
+func (c *userServiceGRPCClient) Close() error {
+	if c.conn != nil {
+		c.logger.Info("Closing gRPC connection to UserService")
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package domain
 
 import (
 	"github.com/lib/pq" // <--- ДОБАВЬТЕ ЭТОТ ИМПОРТ
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -26,10 +28,55 @@ type Movie struct {
 	Cast              pq.StringArray `json:"cast" db:"cast_members"` // <--- ИЗМЕНЕН ТИП НА pq.StringArray (поле в Go: Cast, колонка в БД: cast_members)
 	PosterURL         string         `json:"poster_url,omitempty" db:"poster_url"`
 	TrailerURL        string         `json:"trailer_url,omitempty" db:"trailer_url"`
-	SubmittedByUserID string         `json:"submitted_by_user_id" db:"submitted_by_user_id"`
+	SubmittedByUserID string         `json:"submitted_by_user_id" db:"submitted_by_user_id"` // Пусто, если автор удалил аккаунт
 	Status            MovieStatus    `json:"status" db:"status"`
 	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt         *time.Time     `json:"-" db:"deleted_at"` // Мягкое удаление: удаленные фильмы скрыты из всех выборок
+
+	// Копия оценок из ReviewService (обновляется периодически, см. worker.RatingSyncer)
+	AverageRating float64 `json:"average_rating" db:"average_rating"`
+	ReviewCount   int64   `json:"review_count" db:"review_count"`
+
+	// Результат полнотекстового поиска (заполняется только в списке фильмов с параметром search)
+	SearchRank float64          `json:"search_rank,omitempty" db:"search_rank"`
+	Highlights *MovieHighlights `json:"highlights,omitempty" db:"-"`
+}
+
+// MovieHighlights - фрагменты с подсвеченными совпадениями поискового запроса.
+// Текст экранирован для HTML, совпадения обернуты в <mark></mark>.
+type MovieHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// MovieSuggestion - вариант автодополнения названия (GET /api/movies/autocomplete)
+type MovieSuggestion struct {
+	ID          string `json:"id" db:"id"`
+	Title       string `json:"title" db:"title"`
+	ReleaseYear int    `json:"release_year" db:"release_year"`
+	PosterURL   string `json:"poster_url,omitempty" db:"poster_url"`
+}
+
+// MovieRating - средняя оценка и число отзывов фильма по данным ReviewService.
+type MovieRating struct {
+	MovieID       string
+	AverageRating float64
+	ReviewCount   int64
+}
+
+// FacetCount - значение фасета и количество фильмов с ним.
+type FacetCount struct {
+	Value string `json:"value" db:"value"`
+	Count int    `json:"count" db:"count"`
+}
+
+// MovieFacets - распределение фильмов, подходящих под текущие фильтры списка (GET /api/movies),
+// по жанрам, десятилетиям (значение - первый год десятилетия, например "1990") и режиссерам.
+type MovieFacets struct {
+	Genres    []FacetCount `json:"genres"`
+	Decades   []FacetCount `json:"decades"`
+	Directors []FacetCount `json:"directors"`
 }
 
 // CreateMovieRequest определяет тело запроса для создания нового фильма
@@ -44,7 +91,8 @@ type CreateMovieRequest struct {
 	TrailerURL  string   `json:"trailer_url,omitempty" validate:"omitempty,url"`
 }
 
-// UpdateMovieRequest (если вы его используете, также проверьте теги)
+// UpdateMovieRequest определяет тело запроса для частичного обновления фильма (PATCH).
+// Передаются только изменяемые поля. Status может менять только администратор.
 type UpdateMovieRequest struct {
 	Title       *string  `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
 	Description *string  `json:"description,omitempty" validate:"omitempty,min=10"`
@@ -56,3 +104,19 @@ type UpdateMovieRequest struct {
 	TrailerURL  *string  `json:"trailer_url,omitempty" validate:"omitempty,url"`
 	Status      *string  `json:"status,omitempty" validate:"omitempty,oneof=pending_approval approved rejected"`
 }
+
+// NormalizeGenres приводит жанры к нижнему регистру и убирает повторы (порядок сохраняется).
+// Жанры хранятся нормализованными, чтобы фильтр genre сравнивал массивы напрямую и мог
+// использовать GIN индекс. nil остается nil (в PATCH это "жанры не переданы").
+func NormalizeGenres(genres []string) []string {
+	if genres == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(genres))
+	for _, genre := range genres {
+		if genre = strings.ToLower(genre); !slices.Contains(normalized, genre) {
+			normalized = append(normalized, genre)
+		}
+	}
+	return normalized
+}
@@ -9,6 +9,7 @@ package moviepb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -180,6 +181,96 @@ func (x *GetMovieInfoResponse) GetMovieInfo() *MovieInfo {
 	return nil
 }
 
+// Запрос краткой информации о нескольких фильмах (например, для страницы отзывов)
+type BatchGetMovieInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovieIds      []string               `protobuf:"bytes,1,rep,name=movie_ids,json=movieIds,proto3" json:"movie_ids,omitempty"` // Максимум 500; повторы допустимы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMovieInfoRequest) Reset() {
+	*x = BatchGetMovieInfoRequest{}
+	mi := &file_proto_moviepb_movie_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMovieInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMovieInfoRequest) ProtoMessage() {}
+
+func (x *BatchGetMovieInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moviepb_movie_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMovieInfoRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetMovieInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetMovieInfoRequest) GetMovieIds() []string {
+	if x != nil {
+		return x.MovieIds
+	}
+	return nil
+}
+
+// Ответ только с найденными фильмами (удаленные и несуществующие пропускаются), порядок не гарантируется
+type BatchGetMovieInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movies        []*MovieInfo           `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMovieInfoResponse) Reset() {
+	*x = BatchGetMovieInfoResponse{}
+	mi := &file_proto_moviepb_movie_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMovieInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMovieInfoResponse) ProtoMessage() {}
+
+func (x *BatchGetMovieInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moviepb_movie_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMovieInfoResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetMovieInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetMovieInfoResponse) GetMovies() []*MovieInfo {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
 // Запрос на проверку существования фильма
 type CheckMovieExistsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -190,7 +281,7 @@ type CheckMovieExistsRequest struct {
 
 func (x *CheckMovieExistsRequest) Reset() {
 	*x = CheckMovieExistsRequest{}
-	mi := &file_proto_moviepb_movie_proto_msgTypes[3]
+	mi := &file_proto_moviepb_movie_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -202,7 +293,7 @@ func (x *CheckMovieExistsRequest) String() string {
 func (*CheckMovieExistsRequest) ProtoMessage() {}
 
 func (x *CheckMovieExistsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_moviepb_movie_proto_msgTypes[3]
+	mi := &file_proto_moviepb_movie_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -215,7 +306,7 @@ func (x *CheckMovieExistsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckMovieExistsRequest.ProtoReflect.Descriptor instead.
 func (*CheckMovieExistsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{3}
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CheckMovieExistsRequest) GetMovieId() string {
@@ -235,7 +326,7 @@ type CheckMovieExistsResponse struct {
 
 func (x *CheckMovieExistsResponse) Reset() {
 	*x = CheckMovieExistsResponse{}
-	mi := &file_proto_moviepb_movie_proto_msgTypes[4]
+	mi := &file_proto_moviepb_movie_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -247,7 +338,7 @@ func (x *CheckMovieExistsResponse) String() string {
 func (*CheckMovieExistsResponse) ProtoMessage() {}
 
 func (x *CheckMovieExistsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_moviepb_movie_proto_msgTypes[4]
+	mi := &file_proto_moviepb_movie_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,7 +351,7 @@ func (x *CheckMovieExistsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckMovieExistsResponse.ProtoReflect.Descriptor instead.
 func (*CheckMovieExistsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{4}
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CheckMovieExistsResponse) GetExists() bool {
@@ -270,11 +361,265 @@ func (x *CheckMovieExistsResponse) GetExists() bool {
 	return false
 }
 
+// Фильм, предложенный пользователем (для выгрузки персональных данных)
+type SubmittedMovie struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ReleaseYear   int32                  `protobuf:"varint,4,opt,name=release_year,json=releaseYear,proto3" json:"release_year,omitempty"`
+	Director      string                 `protobuf:"bytes,5,opt,name=director,proto3" json:"director,omitempty"`
+	Genres        []string               `protobuf:"bytes,6,rep,name=genres,proto3" json:"genres,omitempty"`
+	Cast          []string               `protobuf:"bytes,7,rep,name=cast,proto3" json:"cast,omitempty"`
+	PosterUrl     string                 `protobuf:"bytes,8,opt,name=poster_url,json=posterUrl,proto3" json:"poster_url,omitempty"`
+	TrailerUrl    string                 `protobuf:"bytes,9,opt,name=trailer_url,json=trailerUrl,proto3" json:"trailer_url,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // Не задано, если фильм не удален
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmittedMovie) Reset() {
+	*x = SubmittedMovie{}
+	mi := &file_proto_moviepb_movie_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmittedMovie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmittedMovie) ProtoMessage() {}
+
+func (x *SubmittedMovie) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moviepb_movie_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmittedMovie.ProtoReflect.Descriptor instead.
+func (*SubmittedMovie) Descriptor() ([]byte, []int) {
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubmittedMovie) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetReleaseYear() int32 {
+	if x != nil {
+		return x.ReleaseYear
+	}
+	return 0
+}
+
+func (x *SubmittedMovie) GetDirector() string {
+	if x != nil {
+		return x.Director
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *SubmittedMovie) GetCast() []string {
+	if x != nil {
+		return x.Cast
+	}
+	return nil
+}
+
+func (x *SubmittedMovie) GetPosterUrl() string {
+	if x != nil {
+		return x.PosterUrl
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetTrailerUrl() string {
+	if x != nil {
+		return x.TrailerUrl
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmittedMovie) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SubmittedMovie) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SubmittedMovie) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// Запрос фильмов, предложенных пользователем (включая удаленные), постранично
+type ListMoviesBySubmitterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // С 1, по умолчанию 1
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // По умолчанию 100, максимум 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesBySubmitterRequest) Reset() {
+	*x = ListMoviesBySubmitterRequest{}
+	mi := &file_proto_moviepb_movie_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesBySubmitterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesBySubmitterRequest) ProtoMessage() {}
+
+func (x *ListMoviesBySubmitterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moviepb_movie_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesBySubmitterRequest.ProtoReflect.Descriptor instead.
+func (*ListMoviesBySubmitterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMoviesBySubmitterRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMoviesBySubmitterRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMoviesBySubmitterRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListMoviesBySubmitterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movies        []*SubmittedMovie      `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesBySubmitterResponse) Reset() {
+	*x = ListMoviesBySubmitterResponse{}
+	mi := &file_proto_moviepb_movie_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesBySubmitterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesBySubmitterResponse) ProtoMessage() {}
+
+func (x *ListMoviesBySubmitterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moviepb_movie_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesBySubmitterResponse.ProtoReflect.Descriptor instead.
+func (*ListMoviesBySubmitterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_moviepb_movie_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListMoviesBySubmitterResponse) GetMovies() []*SubmittedMovie {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *ListMoviesBySubmitterResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 var File_proto_moviepb_movie_proto protoreflect.FileDescriptor
 
 const file_proto_moviepb_movie_proto_rawDesc = "" +
 	"\n" +
-	"\x19proto/moviepb/movie.proto\x12\x05movie\"l\n" +
+	"\x19proto/moviepb/movie.proto\x12\x05movie\x1a\x1fgoogle/protobuf/timestamp.proto\"l\n" +
 	"\tMovieInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12!\n" +
@@ -284,14 +629,48 @@ const file_proto_moviepb_movie_proto_rawDesc = "" +
 	"\bmovie_id\x18\x01 \x01(\tR\amovieId\"G\n" +
 	"\x14GetMovieInfoResponse\x12/\n" +
 	"\n" +
-	"movie_info\x18\x01 \x01(\v2\x10.movie.MovieInfoR\tmovieInfo\"4\n" +
+	"movie_info\x18\x01 \x01(\v2\x10.movie.MovieInfoR\tmovieInfo\"7\n" +
+	"\x18BatchGetMovieInfoRequest\x12\x1b\n" +
+	"\tmovie_ids\x18\x01 \x03(\tR\bmovieIds\"E\n" +
+	"\x19BatchGetMovieInfoResponse\x12(\n" +
+	"\x06movies\x18\x01 \x03(\v2\x10.movie.MovieInfoR\x06movies\"4\n" +
 	"\x17CheckMovieExistsRequest\x12\x19\n" +
 	"\bmovie_id\x18\x01 \x01(\tR\amovieId\"2\n" +
 	"\x18CheckMovieExistsResponse\x12\x16\n" +
-	"\x06exists\x18\x01 \x01(\bR\x06exists2\xb1\x01\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\"\xcc\x03\n" +
+	"\x0eSubmittedMovie\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\frelease_year\x18\x04 \x01(\x05R\vreleaseYear\x12\x1a\n" +
+	"\bdirector\x18\x05 \x01(\tR\bdirector\x12\x16\n" +
+	"\x06genres\x18\x06 \x03(\tR\x06genres\x12\x12\n" +
+	"\x04cast\x18\a \x03(\tR\x04cast\x12\x1d\n" +
+	"\n" +
+	"poster_url\x18\b \x01(\tR\tposterUrl\x12\x1f\n" +
+	"\vtrailer_url\x18\t \x01(\tR\n" +
+	"trailerUrl\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"h\n" +
+	"\x1cListMoviesBySubmitterRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"o\n" +
+	"\x1dListMoviesBySubmitterResponse\x12-\n" +
+	"\x06movies\x18\x01 \x03(\v2\x15.movie.SubmittedMovieR\x06movies\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount2\xed\x02\n" +
 	"\x11MovieInterService\x12G\n" +
-	"\fGetMovieInfo\x12\x1a.movie.GetMovieInfoRequest\x1a\x1b.movie.GetMovieInfoResponse\x12S\n" +
-	"\x10CheckMovieExists\x12\x1e.movie.CheckMovieExistsRequest\x1a\x1f.movie.CheckMovieExistsResponseB)Z'movie-service/internal/genproto/moviepbb\x06proto3"
+	"\fGetMovieInfo\x12\x1a.movie.GetMovieInfoRequest\x1a\x1b.movie.GetMovieInfoResponse\x12V\n" +
+	"\x11BatchGetMovieInfo\x12\x1f.movie.BatchGetMovieInfoRequest\x1a .movie.BatchGetMovieInfoResponse\x12S\n" +
+	"\x10CheckMovieExists\x12\x1e.movie.CheckMovieExistsRequest\x1a\x1f.movie.CheckMovieExistsResponse\x12b\n" +
+	"\x15ListMoviesBySubmitter\x12#.movie.ListMoviesBySubmitterRequest\x1a$.movie.ListMoviesBySubmitterResponseB)Z'movie-service/internal/genproto/moviepbb\x06proto3"
 
 var (
 	file_proto_moviepb_movie_proto_rawDescOnce sync.Once
@@ -305,25 +684,40 @@ func file_proto_moviepb_movie_proto_rawDescGZIP() []byte {
 	return file_proto_moviepb_movie_proto_rawDescData
 }
 
-var file_proto_moviepb_movie_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_moviepb_movie_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_proto_moviepb_movie_proto_goTypes = []any{
-	(*MovieInfo)(nil),                // 0: movie.MovieInfo
-	(*GetMovieInfoRequest)(nil),      // 1: movie.GetMovieInfoRequest
-	(*GetMovieInfoResponse)(nil),     // 2: movie.GetMovieInfoResponse
-	(*CheckMovieExistsRequest)(nil),  // 3: movie.CheckMovieExistsRequest
-	(*CheckMovieExistsResponse)(nil), // 4: movie.CheckMovieExistsResponse
+	(*MovieInfo)(nil),                     // 0: movie.MovieInfo
+	(*GetMovieInfoRequest)(nil),           // 1: movie.GetMovieInfoRequest
+	(*GetMovieInfoResponse)(nil),          // 2: movie.GetMovieInfoResponse
+	(*BatchGetMovieInfoRequest)(nil),      // 3: movie.BatchGetMovieInfoRequest
+	(*BatchGetMovieInfoResponse)(nil),     // 4: movie.BatchGetMovieInfoResponse
+	(*CheckMovieExistsRequest)(nil),       // 5: movie.CheckMovieExistsRequest
+	(*CheckMovieExistsResponse)(nil),      // 6: movie.CheckMovieExistsResponse
+	(*SubmittedMovie)(nil),                // 7: movie.SubmittedMovie
+	(*ListMoviesBySubmitterRequest)(nil),  // 8: movie.ListMoviesBySubmitterRequest
+	(*ListMoviesBySubmitterResponse)(nil), // 9: movie.ListMoviesBySubmitterResponse
+	(*timestamppb.Timestamp)(nil),         // 10: google.protobuf.Timestamp
 }
 var file_proto_moviepb_movie_proto_depIdxs = []int32{
-	0, // 0: movie.GetMovieInfoResponse.movie_info:type_name -> movie.MovieInfo
-	1, // 1: movie.MovieInterService.GetMovieInfo:input_type -> movie.GetMovieInfoRequest
-	3, // 2: movie.MovieInterService.CheckMovieExists:input_type -> movie.CheckMovieExistsRequest
-	2, // 3: movie.MovieInterService.GetMovieInfo:output_type -> movie.GetMovieInfoResponse
-	4, // 4: movie.MovieInterService.CheckMovieExists:output_type -> movie.CheckMovieExistsResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: movie.GetMovieInfoResponse.movie_info:type_name -> movie.MovieInfo
+	0,  // 1: movie.BatchGetMovieInfoResponse.movies:type_name -> movie.MovieInfo
+	10, // 2: movie.SubmittedMovie.created_at:type_name -> google.protobuf.Timestamp
+	10, // 3: movie.SubmittedMovie.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 4: movie.SubmittedMovie.deleted_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: movie.ListMoviesBySubmitterResponse.movies:type_name -> movie.SubmittedMovie
+	1,  // 6: movie.MovieInterService.GetMovieInfo:input_type -> movie.GetMovieInfoRequest
+	3,  // 7: movie.MovieInterService.BatchGetMovieInfo:input_type -> movie.BatchGetMovieInfoRequest
+	5,  // 8: movie.MovieInterService.CheckMovieExists:input_type -> movie.CheckMovieExistsRequest
+	8,  // 9: movie.MovieInterService.ListMoviesBySubmitter:input_type -> movie.ListMoviesBySubmitterRequest
+	2,  // 10: movie.MovieInterService.GetMovieInfo:output_type -> movie.GetMovieInfoResponse
+	4,  // 11: movie.MovieInterService.BatchGetMovieInfo:output_type -> movie.BatchGetMovieInfoResponse
+	6,  // 12: movie.MovieInterService.CheckMovieExists:output_type -> movie.CheckMovieExistsResponse
+	9,  // 13: movie.MovieInterService.ListMoviesBySubmitter:output_type -> movie.ListMoviesBySubmitterResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_moviepb_movie_proto_init() }
@@ -337,7 +731,7 @@ func file_proto_moviepb_movie_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_moviepb_movie_proto_rawDesc), len(file_proto_moviepb_movie_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MovieInterService_GetMovieInfo_FullMethodName     = "/movie.MovieInterService/GetMovieInfo"
-	MovieInterService_CheckMovieExists_FullMethodName = "/movie.MovieInterService/CheckMovieExists"
+	MovieInterService_GetMovieInfo_FullMethodName          = "/movie.MovieInterService/GetMovieInfo"
+	MovieInterService_BatchGetMovieInfo_FullMethodName     = "/movie.MovieInterService/BatchGetMovieInfo"
+	MovieInterService_CheckMovieExists_FullMethodName      = "/movie.MovieInterService/CheckMovieExists"
+	MovieInterService_ListMoviesBySubmitter_FullMethodName = "/movie.MovieInterService/ListMoviesBySubmitter"
 )
 
 // MovieInterServiceClient is the client API for MovieInterService service.
@@ -31,8 +33,12 @@ const (
 type MovieInterServiceClient interface {
 	// Получает краткую информацию о фильме по его ID
 	GetMovieInfo(ctx context.Context, in *GetMovieInfoRequest, opts ...grpc.CallOption) (*GetMovieInfoResponse, error)
+	// Получает краткую информацию о нескольких фильмах одним вызовом
+	BatchGetMovieInfo(ctx context.Context, in *BatchGetMovieInfoRequest, opts ...grpc.CallOption) (*BatchGetMovieInfoResponse, error)
 	// Проверяет, существует ли фильм с данным ID
 	CheckMovieExists(ctx context.Context, in *CheckMovieExistsRequest, opts ...grpc.CallOption) (*CheckMovieExistsResponse, error)
+	// Возвращает фильмы, предложенные пользователем (выгрузка персональных данных)
+	ListMoviesBySubmitter(ctx context.Context, in *ListMoviesBySubmitterRequest, opts ...grpc.CallOption) (*ListMoviesBySubmitterResponse, error)
 }
 
 type movieInterServiceClient struct {
@@ -53,6 +59,16 @@ func (c *movieInterServiceClient) GetMovieInfo(ctx context.Context, in *GetMovie
 	return out, nil
 }
 
+func (c *movieInterServiceClient) BatchGetMovieInfo(ctx context.Context, in *BatchGetMovieInfoRequest, opts ...grpc.CallOption) (*BatchGetMovieInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetMovieInfoResponse)
+	err := c.cc.Invoke(ctx, MovieInterService_BatchGetMovieInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *movieInterServiceClient) CheckMovieExists(ctx context.Context, in *CheckMovieExistsRequest, opts ...grpc.CallOption) (*CheckMovieExistsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckMovieExistsResponse)
@@ -63,6 +79,16 @@ func (c *movieInterServiceClient) CheckMovieExists(ctx context.Context, in *Chec
 	return out, nil
 }
 
+func (c *movieInterServiceClient) ListMoviesBySubmitter(ctx context.Context, in *ListMoviesBySubmitterRequest, opts ...grpc.CallOption) (*ListMoviesBySubmitterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMoviesBySubmitterResponse)
+	err := c.cc.Invoke(ctx, MovieInterService_ListMoviesBySubmitter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MovieInterServiceServer is the server API for MovieInterService service.
 // All implementations must embed UnimplementedMovieInterServiceServer
 // for forward compatibility.
@@ -71,8 +97,12 @@ func (c *movieInterServiceClient) CheckMovieExists(ctx context.Context, in *Chec
 type MovieInterServiceServer interface {
 	// Получает краткую информацию о фильме по его ID
 	GetMovieInfo(context.Context, *GetMovieInfoRequest) (*GetMovieInfoResponse, error)
+	// Получает краткую информацию о нескольких фильмах одним вызовом
+	BatchGetMovieInfo(context.Context, *BatchGetMovieInfoRequest) (*BatchGetMovieInfoResponse, error)
 	// Проверяет, существует ли фильм с данным ID
 	CheckMovieExists(context.Context, *CheckMovieExistsRequest) (*CheckMovieExistsResponse, error)
+	// Возвращает фильмы, предложенные пользователем (выгрузка персональных данных)
+	ListMoviesBySubmitter(context.Context, *ListMoviesBySubmitterRequest) (*ListMoviesBySubmitterResponse, error)
 	mustEmbedUnimplementedMovieInterServiceServer()
 }
 
@@ -86,9 +116,15 @@ type UnimplementedMovieInterServiceServer struct{}
 func (UnimplementedMovieInterServiceServer) GetMovieInfo(context.Context, *GetMovieInfoRequest) (*GetMovieInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMovieInfo not implemented")
 }
+func (UnimplementedMovieInterServiceServer) BatchGetMovieInfo(context.Context, *BatchGetMovieInfoRequest) (*BatchGetMovieInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMovieInfo not implemented")
+}
 func (UnimplementedMovieInterServiceServer) CheckMovieExists(context.Context, *CheckMovieExistsRequest) (*CheckMovieExistsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckMovieExists not implemented")
 }
+func (UnimplementedMovieInterServiceServer) ListMoviesBySubmitter(context.Context, *ListMoviesBySubmitterRequest) (*ListMoviesBySubmitterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMoviesBySubmitter not implemented")
+}
 func (UnimplementedMovieInterServiceServer) mustEmbedUnimplementedMovieInterServiceServer() {}
 func (UnimplementedMovieInterServiceServer) testEmbeddedByValue()                           {}
 
@@ -128,6 +164,24 @@ func _MovieInterService_GetMovieInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieInterService_BatchGetMovieInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMovieInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieInterServiceServer).BatchGetMovieInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieInterService_BatchGetMovieInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieInterServiceServer).BatchGetMovieInfo(ctx, req.(*BatchGetMovieInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MovieInterService_CheckMovieExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckMovieExistsRequest)
 	if err := dec(in); err != nil {
@@ -146,6 +200,24 @@ func _MovieInterService_CheckMovieExists_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieInterService_ListMoviesBySubmitter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMoviesBySubmitterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieInterServiceServer).ListMoviesBySubmitter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieInterService_ListMoviesBySubmitter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieInterServiceServer).ListMoviesBySubmitter(ctx, req.(*ListMoviesBySubmitterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MovieInterService_ServiceDesc is the grpc.ServiceDesc for MovieInterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,10 +229,18 @@ var MovieInterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMovieInfo",
 			Handler:    _MovieInterService_GetMovieInfo_Handler,
 		},
+		{
+			MethodName: "BatchGetMovieInfo",
+			Handler:    _MovieInterService_BatchGetMovieInfo_Handler,
+		},
 		{
 			MethodName: "CheckMovieExists",
 			Handler:    _MovieInterService_CheckMovieExists_Handler,
 		},
+		{
+			MethodName: "ListMoviesBySubmitter",
+			Handler:    _MovieInterService_ListMoviesBySubmitter_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/moviepb/movie.proto",
@@ -12,6 +12,15 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxBatchGetMovieInfo - максимальное количество ID в одном вызове BatchGetMovieInfo.
+	maxBatchGetMovieInfo = 500
+	// defaultSubmittedPageSize и maxSubmittedPageSize ограничивают страницу ListMoviesBySubmitter.
+	defaultSubmittedPageSize = 100
+	maxSubmittedPageSize     = 500
 )
 
 // Server реализует интерфейс moviepb.MovieInterServiceServer
@@ -66,6 +75,30 @@ func (s *Server) GetMovieInfo(ctx context.Context, req *moviepb.GetMovieInfoRequ
 	return &moviepb.GetMovieInfoResponse{MovieInfo: domainMovieToProtoInfo(movie)}, nil
 }
 
+// BatchGetMovieInfo реализует gRPC метод BatchGetMovieInfo: информация о фильмах по списку ID
+// одним запросом к хранилищу. Удаленные и несуществующие фильмы просто не попадают в ответ.
+func (s *Server) BatchGetMovieInfo(ctx context.Context, req *moviepb.BatchGetMovieInfoRequest) (*moviepb.BatchGetMovieInfoResponse, error) {
+	if len(req.GetMovieIds()) > maxBatchGetMovieInfo {
+		return nil, status.Errorf(codes.InvalidArgument, "too many movie_ids: %d (max %d)", len(req.GetMovieIds()), maxBatchGetMovieInfo)
+	}
+	if len(req.GetMovieIds()) == 0 {
+		return &moviepb.BatchGetMovieInfoResponse{}, nil
+	}
+
+	movies, err := s.store.GetByIDs(ctx, req.GetMovieIds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get movies by IDs from store", slog.Int("requested", len(req.GetMovieIds())), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve movie details: %v", err)
+	}
+
+	res := &moviepb.BatchGetMovieInfoResponse{Movies: make([]*moviepb.MovieInfo, 0, len(movies))}
+	for _, movie := range movies {
+		res.Movies = append(res.Movies, domainMovieToProtoInfo(movie))
+	}
+	s.logger.DebugContext(ctx, "Movie info retrieved via gRPC batch", slog.Int("requested", len(req.GetMovieIds())), slog.Int("found", len(res.Movies)))
+	return res, nil
+}
+
 // CheckMovieExists реализует gRPC метод CheckMovieExists.
 func (s *Server) CheckMovieExists(ctx context.Context, req *moviepb.CheckMovieExistsRequest) (*moviepb.CheckMovieExistsResponse, error) {
 	s.logger.InfoContext(ctx, "gRPC CheckMovieExists called", slog.String("movie_id", req.GetMovieId()))
@@ -89,7 +122,65 @@ func (s *Server) CheckMovieExists(ctx context.Context, req *moviepb.CheckMovieEx
 	// Мы можем также проверить статус, если это важно для CheckMovieExists.
 	// Например, считать существующим только 'approved' фильм.
 	// Для простоты, пока считаем любой найденный фильм существующим.
+	// Мягко удаленные фильмы store.GetByID не возвращает, поэтому для них Exists = false.
 	s.logger.InfoContext(ctx, "Movie exists (checked via gRPC)", slog.String("movie_id", movie.ID))
 	return &moviepb.CheckMovieExistsResponse{Exists: true}, nil
 }
-   
\ No newline at end of file
+
+// domainMovieToProtoSubmitted преобразует доменную модель фильма в SubmittedMovie protobuf сообщение
+func domainMovieToProtoSubmitted(movie *domain.Movie) *moviepb.SubmittedMovie {
+	submitted := &moviepb.SubmittedMovie{
+		Id:          movie.ID,
+		Title:       movie.Title,
+		Description: movie.Description,
+		ReleaseYear: int32(movie.ReleaseYear),
+		Director:    movie.Director,
+		Genres:      movie.Genres,
+		Cast:        movie.Cast,
+		PosterUrl:   movie.PosterURL,
+		TrailerUrl:  movie.TrailerURL,
+		Status:      string(movie.Status),
+		CreatedAt:   timestamppb.New(movie.CreatedAt),
+		UpdatedAt:   timestamppb.New(movie.UpdatedAt),
+	}
+	if movie.DeletedAt != nil {
+		submitted.DeletedAt = timestamppb.New(*movie.DeletedAt)
+	}
+	return submitted
+}
+
+// ListMoviesBySubmitter реализует gRPC метод ListMoviesBySubmitter.
+func (s *Server) ListMoviesBySubmitter(ctx context.Context, req *moviepb.ListMoviesBySubmitterRequest) (*moviepb.ListMoviesBySubmitterResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC ListMoviesBySubmitter called", slog.String("user_id", req.GetUserId()), slog.Int("page", int(req.GetPage())))
+
+	if req.GetUserId() == "" {
+		s.logger.WarnContext(ctx, "gRPC ListMoviesBySubmitter called with empty user_id")
+		return nil, status.Errorf(codes.InvalidArgument, "user_id cannot be empty")
+	}
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultSubmittedPageSize
+	}
+	if pageSize > maxSubmittedPageSize {
+		pageSize = maxSubmittedPageSize
+	}
+
+	movies, total, err := s.store.ListBySubmitter(ctx, req.GetUserId(), page, pageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list movies by submitter from store", slog.String("user_id", req.GetUserId()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to list movies by submitter: %v", err)
+	}
+
+	res := &moviepb.ListMoviesBySubmitterResponse{
+		Movies:     make([]*moviepb.SubmittedMovie, 0, len(movies)),
+		TotalCount: int32(total),
+	}
+	for _, movie := range movies {
+		res.Movies = append(res.Movies, domainMovieToProtoSubmitted(movie))
+	}
+	return res, nil
+}
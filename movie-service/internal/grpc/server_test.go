@@ -0,0 +1,63 @@
+// movie-service/internal/grpc/server_test.go
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"movie-service/internal/genproto/moviepb"
+	"movie-service/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchGetMovieInfo(t *testing.T) {
+	movieStore := store.NewMockMovieStore()
+	if err := movieStore.Delete(context.Background(), "another-approved-id"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	server := NewServer(movieStore, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tooMany := make([]string, maxBatchGetMovieInfo+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("movie-%d", i)
+	}
+	tests := []struct {
+		name     string
+		ids      []string
+		wantCode codes.Code
+		wantIDs  []string
+	}{
+		{name: "empty request", wantCode: codes.OK},
+		{name: "known movies", ids: []string{"existing-approved-id", "pending-movie-id"}, wantCode: codes.OK, wantIDs: []string{"existing-approved-id", "pending-movie-id"}},
+		{name: "unknown ids are skipped", ids: []string{"no-such-movie", "existing-approved-id"}, wantCode: codes.OK, wantIDs: []string{"existing-approved-id"}},
+		{name: "deleted movies are skipped", ids: []string{"another-approved-id", "early-bird-approved"}, wantCode: codes.OK, wantIDs: []string{"early-bird-approved"}},
+		{name: "duplicates returned once", ids: []string{"early-bird-approved", "early-bird-approved"}, wantCode: codes.OK, wantIDs: []string{"early-bird-approved"}},
+		{name: "max batch size", ids: append(tooMany[:maxBatchGetMovieInfo-1:maxBatchGetMovieInfo-1], "early-bird-approved"), wantCode: codes.OK, wantIDs: []string{"early-bird-approved"}},
+		{name: "over max batch size", ids: tooMany, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.BatchGetMovieInfo(context.Background(), &moviepb.BatchGetMovieInfoRequest{MovieIds: tt.ids})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			var gotIDs []string
+			for _, movie := range resp.GetMovies() {
+				gotIDs = append(gotIDs, movie.GetId())
+			}
+			slices.Sort(gotIDs)
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("movies = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// movie-service/internal/store/event_offset_store.go
+package store
+
+import (
+	"context"
+	"log"
+)
+
+// EventOffsetStore хранит позицию потребителя в журнале событий другого сервиса
+// (ID последнего обработанного события), чтобы после перезапуска продолжить с нее.
+type EventOffsetStore interface {
+	// GetEventOffset возвращает последний обработанный ID; 0, если потребитель еще ничего не обработал.
+	GetEventOffset(ctx context.Context, consumer string) (int64, error)
+	SaveEventOffset(ctx context.Context, consumer string, offset int64) error
+}
+
+func (m *MockMovieStore) GetEventOffset(ctx context.Context, consumer string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.eventOffsets[consumer], nil
+}
+
+func (m *MockMovieStore) SaveEventOffset(ctx context.Context, consumer string, offset int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK STORE] Event offset for '%s' saved: %d\n", consumer, offset)
+	m.eventOffsets[consumer] = offset
+	return nil
+}
@@ -0,0 +1,293 @@
+// movie-service/internal/store/movie_filters.go
+package store
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"movie-service/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// Фильтры списка фильмов (MovieListParams) и фасеты по ним. List и Facets строят условия
+// одним и тем же кодом, поэтому счетчики фасетов всегда соответствуют выдаче.
+
+// maxFacetDirectors - сколько самых частых режиссеров попадает в фасет.
+const maxFacetDirectors = 20
+
+// movieFilterSQL - условия WHERE для фильтров MovieListParams и их аргументы.
+type movieFilterSQL struct {
+	conditions []string
+	args       []interface{}
+	tsQuery    string // Выражение to_tsquery(...) для ранжирования и подсветки, если задан поиск
+}
+
+// buildMovieFilterSQL строит условия для фильмов, подходящих под params (удаленные фильмы исключаются всегда).
+func buildMovieFilterSQL(params MovieListParams) *movieFilterSQL {
+	f := &movieFilterSQL{conditions: []string{"deleted_at IS NULL"}}
+	if params.Status != "" {
+		f.add("status = $%d", params.Status)
+	}
+	if len(params.Genres) > 0 {
+		genres := make([]string, 0, len(params.Genres))
+		for _, genre := range params.Genres {
+			genres = append(genres, strings.ToLower(genre))
+		}
+		// Жанры хранятся в нижнем регистре (domain.NormalizeGenres), поэтому массивы сравниваются
+		// напрямую, и условие использует GIN индекс по genres
+		operator := "&&"
+		if params.GenreMatchAll {
+			operator = "@>"
+		}
+		f.add("genres "+operator+" $%d::text[]", pq.Array(genres))
+	}
+	if params.YearFrom != 0 {
+		f.add("release_year >= $%d", params.YearFrom)
+	}
+	if params.YearTo != 0 {
+		f.add("release_year <= $%d", params.YearTo)
+	}
+	if params.Director != "" {
+		f.add("LOWER(director) = LOWER($%d)", params.Director)
+	}
+	if params.CastMember != "" {
+		f.add("EXISTS (SELECT 1 FROM unnest(cast_members) AS c WHERE lower(c) = lower($%d))", params.CastMember)
+	}
+	if params.MinRating > 0 {
+		f.add("average_rating >= $%d", params.MinRating)
+	}
+	// Полнотекстовый поиск по search_vector (название, режиссер, актеры, описание; см. movie_search.go)
+	if searchTerms := parseSearchQuery(params.SearchQuery); len(searchTerms) > 0 {
+		f.tsQuery = fmt.Sprintf("to_tsquery('simple', $%d)", f.nextArg())
+		f.conditions = append(f.conditions, "search_vector @@ "+f.tsQuery)
+		f.args = append(f.args, buildTSQuery(searchTerms))
+	}
+	return f
+}
+
+// add добавляет условие; %d в condition заменяется номером аргумента arg.
+func (f *movieFilterSQL) add(condition string, arg interface{}) {
+	f.conditions = append(f.conditions, fmt.Sprintf(condition, f.nextArg()))
+	f.args = append(f.args, arg)
+}
+
+// nextArg возвращает номер следующего аргумента запроса.
+func (f *movieFilterSQL) nextArg() int {
+	return len(f.args) + 1
+}
+
+// where возвращает условия, объединенные через AND.
+func (f *movieFilterSQL) where() string {
+	return strings.Join(f.conditions, " AND ")
+}
+
+// movieFacetRow - строка результата запроса фасетов.
+type movieFacetRow struct {
+	Facet string `db:"facet"`
+	domain.FacetCount
+}
+
+// Facets считает фасеты одним запросом по отфильтрованным фильмам. Режиссеры группируются
+// без учета регистра (показывается одно из написаний); жанры хранятся в нижнем регистре.
+func (s *PostgresMovieStore) Facets(ctx context.Context, params MovieListParams) (*domain.MovieFacets, error) {
+	filter := buildMovieFilterSQL(params)
+	query := fmt.Sprintf(`WITH filtered AS (SELECT id, genres, release_year, director FROM movies WHERE %s)
+        SELECT 'genre' AS facet, g AS value, COUNT(DISTINCT id) AS count
+            FROM filtered, unnest(genres) AS g GROUP BY g
+        UNION ALL
+        SELECT 'decade', ((release_year / 10) * 10)::text, COUNT(*)
+            FROM filtered WHERE release_year IS NOT NULL GROUP BY 2
+        UNION ALL
+        (SELECT 'director', MIN(director), COUNT(*)
+            FROM filtered WHERE COALESCE(director, '') <> '' GROUP BY LOWER(director)
+            ORDER BY 3 DESC, 2 LIMIT $%d)`, filter.where(), filter.nextArg())
+	args := append(filter.args, maxFacetDirectors)
+
+	s.logger.DebugContext(ctx, "Executing movie facets query", slog.String("query", query), slog.Any("args", args))
+	var rows []movieFacetRow
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count movie facets in DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to count movie facets: %w", err)
+	}
+
+	facets := newMovieFacets()
+	for _, row := range rows {
+		switch row.Facet {
+		case "genre":
+			facets.Genres = append(facets.Genres, row.FacetCount)
+		case "decade":
+			facets.Decades = append(facets.Decades, row.FacetCount)
+		case "director":
+			facets.Directors = append(facets.Directors, row.FacetCount)
+		}
+	}
+	sortMovieFacets(facets)
+	return facets, nil
+}
+
+// newMovieFacets создает пустые фасеты (в JSON - пустые массивы, а не null).
+func newMovieFacets() *domain.MovieFacets {
+	return &domain.MovieFacets{
+		Genres:    []domain.FacetCount{},
+		Decades:   []domain.FacetCount{},
+		Directors: []domain.FacetCount{},
+	}
+}
+
+// sortMovieFacets упорядочивает фасеты: жанры и режиссеры - самые частые первыми,
+// десятилетия - от новых к старым.
+func sortMovieFacets(facets *domain.MovieFacets) {
+	byCount := func(counts []domain.FacetCount) {
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].Count != counts[j].Count {
+				return counts[i].Count > counts[j].Count
+			}
+			return strings.ToLower(counts[i].Value) < strings.ToLower(counts[j].Value)
+		})
+	}
+	byCount(facets.Genres)
+	byCount(facets.Directors)
+	sort.Slice(facets.Decades, func(i, j int) bool {
+		decadeI, _ := strconv.Atoi(facets.Decades[i].Value)
+		decadeJ, _ := strconv.Atoi(facets.Decades[j].Value)
+		return decadeI > decadeJ
+	})
+}
+
+// --- Фильтрация в памяти для MockMovieStore ---
+
+// movieMatchesFilters проверяет фильм на все фильтры params, кроме полнотекстового поиска.
+func movieMatchesFilters(movie *domain.Movie, params MovieListParams) bool {
+	if movie.DeletedAt != nil {
+		return false
+	}
+	if params.Status != "" && movie.Status != params.Status {
+		return false
+	}
+	if len(params.Genres) > 0 {
+		matched := 0
+		for _, genre := range params.Genres {
+			if containsFold(movie.Genres, genre) {
+				matched++
+			}
+		}
+		if matched == 0 || (params.GenreMatchAll && matched < len(params.Genres)) {
+			return false
+		}
+	}
+	if params.YearFrom != 0 && movie.ReleaseYear < params.YearFrom {
+		return false
+	}
+	if params.YearTo != 0 && movie.ReleaseYear > params.YearTo {
+		return false
+	}
+	if params.Director != "" && !strings.EqualFold(movie.Director, params.Director) {
+		return false
+	}
+	if params.CastMember != "" && !containsFold(movie.Cast, params.CastMember) {
+		return false
+	}
+	if params.MinRating > 0 && movie.AverageRating < params.MinRating {
+		return false
+	}
+	return true
+}
+
+// containsFold сообщает, есть ли value в values без учета регистра.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterMoviesLocked возвращает копии фильмов, подходящих под params. При поиске у копий
+// заполнены ранг и подсветка. Вызывающий должен держать m.mu.
+func (m *MockMovieStore) filterMoviesLocked(params MovieListParams) []domain.Movie {
+	searchTerms := parseSearchQuery(params.SearchQuery)
+	var filtered []domain.Movie
+	for _, source := range []map[string]*domain.Movie{m.predefinedMovies, m.movies} {
+		for _, moviePtr := range source {
+			if !movieMatchesFilters(moviePtr, params) {
+				continue
+			}
+			movie := *moviePtr
+			// Полнотекстовый поиск (те же правила, что у PostgresMovieStore, см. movie_search.go)
+			if len(searchTerms) > 0 {
+				rank, ok := matchMovie(&movie, searchTerms)
+				if !ok {
+					continue
+				}
+				movie.SearchRank = rank
+				movie.Highlights = highlightMovie(&movie, searchTerms)
+			}
+			filtered = append(filtered, movie)
+		}
+	}
+	return filtered
+}
+
+func (m *MockMovieStore) Facets(ctx context.Context, params MovieListParams) (*domain.MovieFacets, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK STORE] Counting movie facets with params: %+v\n", params)
+
+	// Ключ - значение в нижнем регистре; показывается лексикографически меньшее написание, как MIN() в PostgreSQL
+	type facetBucket struct {
+		value string
+		count int
+	}
+	genres := make(map[string]*facetBucket)
+	decades := make(map[int]int)
+	directors := make(map[string]*facetBucket)
+	countValue := func(buckets map[string]*facetBucket, value string) {
+		key := strings.ToLower(value)
+		bucket, ok := buckets[key]
+		if !ok {
+			buckets[key] = &facetBucket{value: value, count: 1}
+			return
+		}
+		bucket.count++
+		if value < bucket.value {
+			bucket.value = value
+		}
+	}
+
+	for _, movie := range m.filterMoviesLocked(params) {
+		seenGenres := make(map[string]bool)
+		for _, genre := range movie.Genres {
+			if !seenGenres[strings.ToLower(genre)] {
+				seenGenres[strings.ToLower(genre)] = true
+				countValue(genres, genre)
+			}
+		}
+		decades[movie.ReleaseYear/10*10]++
+		if movie.Director != "" {
+			countValue(directors, movie.Director)
+		}
+	}
+
+	facets := newMovieFacets()
+	for _, bucket := range genres {
+		facets.Genres = append(facets.Genres, domain.FacetCount{Value: bucket.value, Count: bucket.count})
+	}
+	for decade, count := range decades {
+		facets.Decades = append(facets.Decades, domain.FacetCount{Value: strconv.Itoa(decade), Count: count})
+	}
+	for _, bucket := range directors {
+		facets.Directors = append(facets.Directors, domain.FacetCount{Value: bucket.value, Count: bucket.count})
+	}
+	sortMovieFacets(facets)
+	if len(facets.Directors) > maxFacetDirectors {
+		facets.Directors = facets.Directors[:maxFacetDirectors]
+	}
+	return facets, nil
+}
@@ -0,0 +1,151 @@
+// movie-service/internal/store/movie_filters_test.go
+package store
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"movie-service/internal/domain"
+)
+
+// filterGenre есть только у фильмов newFilterStore, чтобы предопределенные фильмы не попадали в выдачу.
+const filterGenre = "filtertest"
+
+// newFilterStore возвращает MockMovieStore с фильмами для проверки фильтров и фасетов.
+func newFilterStore(t *testing.T) *MockMovieStore {
+	t.Helper()
+	ctx := context.Background()
+	movieStore := NewMockMovieStore()
+	movies := []*domain.Movie{
+		{ID: "filter-1", Director: "Christopher Nolan", Cast: []string{"Christian Bale", "Michael Caine"}, ReleaseYear: 2005, Genres: []string{filterGenre, "action"}},
+		{ID: "filter-2", Director: "christopher nolan", Cast: []string{"Leonardo DiCaprio", "Michael Caine"}, ReleaseYear: 2010, Genres: []string{filterGenre, "sci-fi"}},
+		{ID: "filter-3", Director: "Ridley Scott", Cast: []string{"Sigourney Weaver"}, ReleaseYear: 1979, Genres: []string{filterGenre, "sci-fi", "horror"}},
+		{ID: "filter-4", Director: "Ridley Scott", Cast: []string{"Michael Caine Jr"}, ReleaseYear: 2000, Genres: []string{filterGenre}},
+		{ID: "filter-5", Director: "Christopher Nolan", Cast: []string{"Michael Caine"}, ReleaseYear: 2014, Genres: []string{filterGenre}, Status: domain.StatusPendingApproval},
+	}
+	for _, movie := range movies {
+		movie.Title = movie.ID
+		if movie.Status == "" {
+			movie.Status = domain.StatusApproved
+		}
+		if err := movieStore.Create(ctx, movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	// У filter-4 нет отзывов (рейтинг 0)
+	ratings := []domain.MovieRating{
+		{MovieID: "filter-1", AverageRating: 4.5, ReviewCount: 2},
+		{MovieID: "filter-2", AverageRating: 4, ReviewCount: 1},
+		{MovieID: "filter-3", AverageRating: 3.5, ReviewCount: 4},
+		{MovieID: "filter-5", AverageRating: 5, ReviewCount: 1},
+	}
+	if err := movieStore.SetRatings(ctx, ratings, time.Now()); err != nil {
+		t.Fatalf("SetRatings: %v", err)
+	}
+	return movieStore
+}
+
+func TestMockMovieFilters(t *testing.T) {
+	movieStore := newFilterStore(t)
+	tests := []struct {
+		name   string
+		params MovieListParams
+		want   []string
+	}{
+		{name: "no extra filters", want: []string{"filter-1", "filter-2", "filter-3", "filter-4"}},
+		{name: "cast member", params: MovieListParams{CastMember: "Michael Caine"}, want: []string{"filter-1", "filter-2"}},
+		{name: "cast member in another case", params: MovieListParams{CastMember: "MICHAEL CAINE"}, want: []string{"filter-1", "filter-2"}},
+		{name: "cast member needs the full name", params: MovieListParams{CastMember: "Caine"}},
+		{name: "director in any case", params: MovieListParams{Director: "CHRISTOPHER NOLAN"}, want: []string{"filter-1", "filter-2"}},
+		{name: "director needs the full name", params: MovieListParams{Director: "Nolan"}},
+		{name: "min rating", params: MovieListParams{MinRating: 4}, want: []string{"filter-1", "filter-2"}},
+		{name: "min rating excludes movies without reviews", params: MovieListParams{MinRating: 0.5}, want: []string{"filter-1", "filter-2", "filter-3"}},
+		{name: "director and min rating", params: MovieListParams{Director: "ridley scott", MinRating: 3.5}, want: []string{"filter-3"}},
+		{name: "cast and director", params: MovieListParams{CastMember: "michael caine", Director: "Ridley Scott"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.Status = domain.StatusApproved
+			params.Genres = []string{filterGenre}
+			params.Page, params.PageSize = 1, 10
+			page, err := movieStore.List(context.Background(), params)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			got := movieIDs(page.Movies)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("movies = %v, want %v", got, tt.want)
+			}
+			if page.TotalCount != len(tt.want) {
+				t.Errorf("total_count = %d, want %d", page.TotalCount, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestMockMovieFacets(t *testing.T) {
+	movieStore := newFilterStore(t)
+	tests := []struct {
+		name   string
+		params MovieListParams
+		want   domain.MovieFacets
+	}{
+		{
+			name: "all approved movies",
+			want: domain.MovieFacets{
+				Genres:    []domain.FacetCount{{Value: filterGenre, Count: 4}, {Value: "sci-fi", Count: 2}, {Value: "action", Count: 1}, {Value: "horror", Count: 1}},
+				Decades:   []domain.FacetCount{{Value: "2010", Count: 1}, {Value: "2000", Count: 2}, {Value: "1970", Count: 1}},
+				Directors: []domain.FacetCount{{Value: "Christopher Nolan", Count: 2}, {Value: "Ridley Scott", Count: 2}},
+			},
+		},
+		{
+			name:   "counts follow the filters",
+			params: MovieListParams{CastMember: "michael caine"},
+			want: domain.MovieFacets{
+				Genres:    []domain.FacetCount{{Value: filterGenre, Count: 2}, {Value: "action", Count: 1}, {Value: "sci-fi", Count: 1}},
+				Decades:   []domain.FacetCount{{Value: "2010", Count: 1}, {Value: "2000", Count: 1}},
+				Directors: []domain.FacetCount{{Value: "Christopher Nolan", Count: 2}},
+			},
+		},
+		{
+			name:   "nothing matches",
+			params: MovieListParams{MinRating: 5},
+			want:   domain.MovieFacets{Genres: []domain.FacetCount{}, Decades: []domain.FacetCount{}, Directors: []domain.FacetCount{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.Status = domain.StatusApproved
+			params.Genres = []string{filterGenre}
+			facets, err := movieStore.Facets(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Facets: %v", err)
+			}
+			if !slices.Equal(facets.Genres, tt.want.Genres) {
+				t.Errorf("genres = %v, want %v", facets.Genres, tt.want.Genres)
+			}
+			if !slices.Equal(facets.Decades, tt.want.Decades) {
+				t.Errorf("decades = %v, want %v", facets.Decades, tt.want.Decades)
+			}
+			if !slices.Equal(facets.Directors, tt.want.Directors) {
+				t.Errorf("directors = %v, want %v", facets.Directors, tt.want.Directors)
+			}
+		})
+	}
+}
+
+func TestBuildMovieFilterSQLCastMember(t *testing.T) {
+	f := buildMovieFilterSQL(MovieListParams{Status: domain.StatusApproved, CastMember: "Michael Caine"})
+	want := "deleted_at IS NULL AND status = $1 AND EXISTS (SELECT 1 FROM unnest(cast_members) AS c WHERE lower(c) = lower($2))"
+	if got := f.where(); got != want {
+		t.Errorf("where = %q\nwant    %q", got, want)
+	}
+	if len(f.args) != 2 || f.args[1] != "Michael Caine" {
+		t.Errorf("args = %v, want the cast member as $2", f.args)
+	}
+}
@@ -0,0 +1,150 @@
+// movie-service/internal/store/movie_list_test.go
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"movie-service/internal/domain"
+	"shared/sortspec"
+)
+
+// newPagingStore возвращает MockMovieStore с фильмами, у которых совпадают годы, названия (в разном регистре)
+// и рейтинги, в том числе нулевые, чтобы порядок внутри групп определял id.
+func newPagingStore(t *testing.T) *MockMovieStore {
+	t.Helper()
+	ctx := context.Background()
+	movieStore := NewMockMovieStore()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	titles := []string{"Alien", "alien", "Brazil", "Casablanca"}
+	var ratings []domain.MovieRating
+	for i := range 13 {
+		movie := &domain.Movie{
+			ID:          fmt.Sprintf("movie-%02d", i),
+			Title:       titles[i%len(titles)],
+			ReleaseYear: 2000 + i%3,
+			Genres:      []string{"noir"},
+			Status:      domain.StatusApproved,
+			CreatedAt:   createdAt.Add(time.Duration(i%5) * time.Hour), // Одинаковое время создания у нескольких фильмов
+		}
+		if err := movieStore.Create(ctx, movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if i%4 != 0 { // Каждый четвертый фильм без отзывов (рейтинг 0)
+			ratings = append(ratings, domain.MovieRating{MovieID: movie.ID, AverageRating: float64(i % 3), ReviewCount: 1})
+		}
+	}
+	if err := movieStore.SetRatings(ctx, ratings, createdAt); err != nil {
+		t.Fatalf("SetRatings: %v", err)
+	}
+	return movieStore
+}
+
+func movieIDs(movies []*domain.Movie) []string {
+	ids := make([]string, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.ID
+	}
+	return ids
+}
+
+func TestMockMovieListKeysetPaging(t *testing.T) {
+	ctx := context.Background()
+	movieStore := newPagingStore(t)
+	for _, rawSort := range []string{"", "-release_year,title", "average_rating", "-average_rating,-created_at", "title,-review_count"} {
+		t.Run("sort="+rawSort, func(t *testing.T) {
+			spec, err := sortspec.Parse(rawSort, MovieSortFields)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			params := MovieListParams{Status: domain.StatusApproved, Genres: []string{"noir"}, Sort: spec, Page: 1, PageSize: 100}
+			full, err := movieStore.List(ctx, params)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			want := movieIDs(full.Movies)
+			if len(want) != 13 || full.Next != nil || full.Prev != nil {
+				t.Fatalf("full list: %d movies, next %v, prev %v", len(want), full.Next, full.Prev)
+			}
+
+			// Вперед по next_cursor
+			params.PageSize = 4
+			var forward []string
+			var last *MoviePage
+			for page, pages := (*MoviePage)(nil), 0; page == nil || page.Next != nil; pages++ {
+				if pages > 10 {
+					t.Fatal("paging forward does not stop")
+				}
+				if page != nil {
+					params.Cursor = page.Next
+				}
+				if page, err = movieStore.List(ctx, params); err != nil {
+					t.Fatalf("List: %v", err)
+				}
+				if (params.Cursor == nil) != (page.Prev == nil) {
+					t.Errorf("page after %v: prev = %v", params.Cursor, page.Prev)
+				}
+				forward = append(forward, movieIDs(page.Movies)...)
+				last = page
+			}
+			if !slices.Equal(forward, want) {
+				t.Fatalf("forward = %v\nwant      %v", forward, want)
+			}
+
+			// Назад по prev_cursor от последней страницы: страницы идут в прямом порядке
+			backward := movieIDs(last.Movies)
+			for page := last; page.Prev != nil; {
+				params.Cursor = page.Prev
+				if page, err = movieStore.List(ctx, params); err != nil {
+					t.Fatalf("List: %v", err)
+				}
+				if page.Next == nil {
+					t.Errorf("page before %v has no next cursor", params.Cursor.Values)
+				}
+				backward = append(movieIDs(page.Movies), backward...)
+			}
+			if !slices.Equal(backward, want) {
+				t.Errorf("backward = %v\nwant       %v", backward, want)
+			}
+		})
+	}
+}
+
+func TestMockMovieListRejectsForeignCursor(t *testing.T) {
+	ctx := context.Background()
+	movieStore := newPagingStore(t)
+	params := MovieListParams{Status: domain.StatusApproved, Genres: []string{"noir"}, Sort: sortspec.Spec{{Field: MovieSortTitle}}, Page: 1, PageSize: 4}
+	first, err := movieStore.List(ctx, params)
+	if err != nil || first.Next == nil {
+		t.Fatalf("List: next %v, error %v", first, err)
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*MovieListParams)
+		wantErr bool
+	}{
+		{name: "same list", change: func(*MovieListParams) {}},
+		{name: "genres in another case and order", change: func(p *MovieListParams) { p.Genres = []string{"NOIR", "noir"} }},
+		{name: "different sort", change: func(p *MovieListParams) { p.Sort = sortspec.Spec{{Field: MovieSortTitle, Desc: true}} }, wantErr: true},
+		{name: "different genre", change: func(p *MovieListParams) { p.Genres = []string{"comedy"} }, wantErr: true},
+		{name: "added year filter", change: func(p *MovieListParams) { p.YearFrom = 2001 }, wantErr: true},
+		{name: "added min rating", change: func(p *MovieListParams) { p.MinRating = 1 }, wantErr: true},
+		{name: "added search", change: func(p *MovieListParams) { p.SearchQuery = "alien" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := params
+			tt.change(&next)
+			next.Cursor = first.Next
+			_, err := movieStore.List(ctx, next)
+			if got := errors.Is(err, ErrInvalidCursor); got != tt.wantErr {
+				t.Errorf("List error = %v, want ErrInvalidCursor %v", err, tt.wantErr)
+			}
+		})
+	}
+}
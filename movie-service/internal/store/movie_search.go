@@ -0,0 +1,237 @@
+// movie-service/internal/store/movie_search.go
+package store
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"movie-service/internal/domain"
+)
+
+// Полнотекстовый поиск фильмов (параметр search). Синтаксис запроса:
+//   - слова через пробел должны встречаться все (в любом порядке и в любом из полей);
+//   - "фраза в кавычках" - слова подряд;
+//   - слово* - поиск по префиксу.
+// Поля склеены в один search_vector (название, режиссер, актеры, описание), поэтому фраза может
+// начинаться в конце одного поля и продолжаться в начале следующего.
+// PostgresMovieStore строит из разобранного запроса tsquery, MockMovieStore ищет по тем же правилам в памяти.
+// Используется конфигурация 'simple' (без стемминга): названия и имена бывают на разных языках.
+
+// Веса полей при ранжировании (соответствуют весам A, B, C в movie_search_document и ts_rank по умолчанию).
+const (
+	searchWeightTitle       = 1.0 // A
+	searchWeightPeople      = 0.4 // B: режиссер и актеры
+	searchWeightDescription = 0.2 // C
+)
+
+// Маркеры подсвеченных совпадений до HTML-экранирования; в ответе они заменяются на <mark></mark>.
+const (
+	highlightStartMarker = "\x02"
+	highlightStopMarker  = "\x03"
+)
+
+// descriptionSnippetWords - примерная длина фрагмента описания в словах.
+const descriptionSnippetWords = 30
+
+// searchTerm - слово или фраза запроса; prefix относится к последнему слову.
+type searchTerm struct {
+	words  []string
+	prefix bool
+}
+
+// parseSearchQuery разбирает строку поиска. Все символы, кроме букв и цифр, считаются разделителями,
+// поэтому результат безопасно подставлять в to_tsquery. Пустой результат означает, что искать нечего.
+func parseSearchQuery(query string) []searchTerm {
+	var terms []searchTerm
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 { // Внутри кавычек - фраза
+			if term, ok := newSearchTerm(part); ok {
+				terms = append(terms, term)
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			if term, ok := newSearchTerm(field); ok {
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// newSearchTerm создает слово или фразу из фрагмента запроса (например, "spider-man" - фраза из двух слов).
+func newSearchTerm(text string) (searchTerm, bool) {
+	words := searchTokens(text)
+	if len(words) == 0 {
+		return searchTerm{}, false
+	}
+	return searchTerm{words: words, prefix: strings.HasSuffix(strings.TrimSpace(text), "*")}, true
+}
+
+// searchTokens разбивает текст на слова в нижнем регистре.
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// buildTSQuery преобразует разобранный запрос в текст для to_tsquery('simple', ...).
+func buildTSQuery(terms []searchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		phrase := strings.Join(term.words, " <-> ")
+		if term.prefix {
+			phrase += ":*"
+		}
+		if len(term.words) > 1 {
+			phrase = "(" + phrase + ")"
+		}
+		parts = append(parts, phrase)
+	}
+	return strings.Join(parts, " & ")
+}
+
+// renderHighlight экранирует текст для HTML и заменяет маркеры совпадений на <mark></mark>.
+func renderHighlight(marked string) string {
+	escaped := html.EscapeString(marked)
+	return strings.NewReplacer(highlightStartMarker, "<mark>", highlightStopMarker, "</mark>").Replace(escaped)
+}
+
+// --- Поиск в памяти для MockMovieStore ---
+
+// textToken - слово текста и его границы в исходной строке (в байтах).
+type textToken struct {
+	word       string
+	start, end int
+}
+
+// tokenizeText разбивает текст на слова, запоминая их позиции для подсветки.
+func tokenizeText(text string) []textToken {
+	var tokens []textToken
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			tokens = append(tokens, textToken{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, textToken{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// termMatches возвращает позиции слов tokens, с которых начинается совпадение с term.
+func termMatches(tokens []textToken, term searchTerm) []int {
+	var positions []int
+	for i := 0; i+len(term.words) <= len(tokens); i++ {
+		matched := true
+		for j, word := range term.words {
+			last := j == len(term.words)-1
+			if tokens[i+j].word != word && !(last && term.prefix && strings.HasPrefix(tokens[i+j].word, word)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// searchDocument склеивает поля фильма в одну последовательность слов, как movie_search_document,
+// и возвращает вес поля для каждого слова. Границы слов (start, end) относятся к исходному полю.
+func searchDocument(movie *domain.Movie) ([]textToken, []float64) {
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{text: movie.Title, weight: searchWeightTitle},
+		{text: movie.Director, weight: searchWeightPeople},
+		{text: strings.Join(movie.Cast, " "), weight: searchWeightPeople},
+		{text: movie.Description, weight: searchWeightDescription},
+	}
+	var tokens []textToken
+	var weights []float64
+	for _, field := range fields {
+		fieldTokens := tokenizeText(field.text)
+		tokens = append(tokens, fieldTokens...)
+		for range fieldTokens {
+			weights = append(weights, field.weight)
+		}
+	}
+	return tokens, weights
+}
+
+// matchMovie проверяет, что фильм подходит под все слова запроса, и возвращает его ранг.
+// Ранг растет с числом совпадений и весом поля, в котором совпадение начинается, как ts_rank
+// в PostgreSQL (значения не совпадают, порядок близок).
+func matchMovie(movie *domain.Movie, terms []searchTerm) (float64, bool) {
+	tokens, weights := searchDocument(movie)
+	rank := 0.0
+	for _, term := range terms {
+		positions := termMatches(tokens, term)
+		if len(positions) == 0 {
+			return 0, false
+		}
+		for _, pos := range positions {
+			rank += weights[pos]
+		}
+	}
+	return rank, true
+}
+
+// markMatches возвращает текст с маркерами вокруг совпадений и индексы совпавших слов.
+func markMatches(text string, tokens []textToken, terms []searchTerm) (string, []int) {
+	matched := make([]bool, len(tokens))
+	for _, term := range terms {
+		for _, pos := range termMatches(tokens, term) {
+			for j := range term.words {
+				matched[pos+j] = true
+			}
+		}
+	}
+	var b strings.Builder
+	var matchedIdx []int
+	prev := 0
+	for i, token := range tokens {
+		if !matched[i] {
+			continue
+		}
+		matchedIdx = append(matchedIdx, i)
+		b.WriteString(text[prev:token.start])
+		b.WriteString(highlightStartMarker + text[token.start:token.end] + highlightStopMarker)
+		prev = token.end
+	}
+	b.WriteString(text[prev:])
+	return b.String(), matchedIdx
+}
+
+// highlightMovie строит подсветку названия и фрагмента описания, аналогичную ts_headline.
+func highlightMovie(movie *domain.Movie, terms []searchTerm) *domain.MovieHighlights {
+	titleTokens := tokenizeText(movie.Title)
+	title, _ := markMatches(movie.Title, titleTokens, terms)
+
+	descriptionTokens := tokenizeText(movie.Description)
+	snippet := movie.Description
+	if len(descriptionTokens) > descriptionSnippetWords {
+		// Фрагмент вокруг первого совпадения (или начало описания, если совпадений нет)
+		first := 0
+		if _, matchedIdx := markMatches(movie.Description, descriptionTokens, terms); len(matchedIdx) > 0 {
+			first = max(matchedIdx[0]-descriptionSnippetWords/3, 0)
+		}
+		last := min(first+descriptionSnippetWords, len(descriptionTokens)) - 1
+		snippet = movie.Description[descriptionTokens[first].start:descriptionTokens[last].end]
+	}
+	description, _ := markMatches(snippet, tokenizeText(snippet), terms)
+
+	return &domain.MovieHighlights{
+		Title:       renderHighlight(title),
+		Description: renderHighlight(description),
+	}
+}
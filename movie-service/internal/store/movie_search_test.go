@@ -0,0 +1,132 @@
+// movie-service/internal/store/movie_search_test.go
+package store
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"movie-service/internal/domain"
+)
+
+// newSearchStore возвращает MockMovieStore с фильмами, на которых видна разница между словами,
+// фразами и префиксами. Предопределенные фильмы этих слов не содержат.
+func newSearchStore(t *testing.T) *MockMovieStore {
+	t.Helper()
+	movieStore := NewMockMovieStore()
+	movies := []*domain.Movie{
+		{ID: "search-1", Title: "The Dark Knight", Director: "Christopher Nolan", Cast: []string{"Christian Bale", "Heath Ledger"}, Description: "Batman faces the Joker in Gotham."},
+		{ID: "search-2", Title: "Knight and Day", Director: "James Mangold", Cast: []string{"Tom Cruise"}, Description: "A dark comedy about a spy."},
+		{ID: "search-3", Title: "Interstellar", Director: "Christopher Nolan", Cast: []string{"Matthew McConaughey"}, Description: "Explorers travel through a wormhole. Nolan at his <best>."},
+		{ID: "search-4", Title: "Memento", Director: "Christopher Nolan", Description: "Short-term memory loss."},
+		{ID: "search-5", Title: "Dark Knight Rises", Director: "Christopher Nolan", Status: domain.StatusPendingApproval},
+	}
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, movie := range movies {
+		movie.CreatedAt = createdAt.Add(time.Duration(i) * time.Hour)
+		if movie.Status == "" {
+			movie.Status = domain.StatusApproved
+		}
+		if err := movieStore.Create(context.Background(), movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	return movieStore
+}
+
+func TestMockMovieSearch(t *testing.T) {
+	movieStore := newSearchStore(t)
+	tests := []struct {
+		name  string
+		query string
+		want  []string // ID найденных фильмов, самые релевантные первыми
+	}{
+		{name: "single word ranked by field weight", query: "dark", want: []string{"search-1", "search-2"}},
+		{name: "all words in any order and field", query: "knight dark", want: []string{"search-1", "search-2"}},
+		{name: "all words must match", query: "nolan memory", want: []string{"search-4"}},
+		{name: "phrase needs adjacent words", query: `"dark knight"`, want: []string{"search-1"}},
+		{name: "phrase in the wrong order", query: `"knight dark"`},
+		{name: "phrase across title and director", query: `"knight christopher"`, want: []string{"search-1"}},
+		{name: "phrase across cast members", query: `"bale heath"`, want: []string{"search-1"}},
+		{name: "hyphenated word is a phrase", query: "short-term", want: []string{"search-4"}},
+		{name: "prefix", query: "interst*", want: []string{"search-3"}},
+		{name: "word without prefix marker", query: "interst"},
+		{name: "prefix at the end of a phrase", query: `"dark kni*"`, want: []string{"search-1"}},
+		{name: "more matches rank higher", query: "nolan", want: []string{"search-3", "search-4", "search-1"}}, // При равном ранге новые первыми
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := movieStore.List(context.Background(), MovieListParams{Status: domain.StatusApproved, SearchQuery: tt.query, Page: 1, PageSize: 10})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if got := movieIDs(page.Movies); !slices.Equal(got, tt.want) {
+				t.Errorf("movies = %v, want %v", got, tt.want)
+			}
+			if page.TotalCount != len(tt.want) {
+				t.Errorf("total_count = %d, want %d", page.TotalCount, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestMockMovieSearchHighlights(t *testing.T) {
+	movieStore := newSearchStore(t)
+	tests := []struct {
+		name            string
+		query           string
+		movieID         string
+		wantTitle       string
+		wantDescription string
+	}{
+		{name: "word in title", query: "dark", movieID: "search-1", wantTitle: "The <mark>Dark</mark> Knight", wantDescription: "Batman faces the Joker in Gotham."},
+		{name: "word in description", query: "dark", movieID: "search-2", wantTitle: "Knight and Day", wantDescription: "A <mark>dark</mark> comedy about a spy."},
+		{name: "phrase", query: `"dark knight"`, movieID: "search-1", wantTitle: "The <mark>Dark</mark> <mark>Knight</mark>", wantDescription: "Batman faces the Joker in Gotham."},
+		{name: "prefix and HTML escaping", query: "interst* nolan", movieID: "search-3", wantTitle: "<mark>Interstellar</mark>", wantDescription: "Explorers travel through a wormhole. <mark>Nolan</mark> at his &lt;best&gt;."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := movieStore.List(context.Background(), MovieListParams{Status: domain.StatusApproved, SearchQuery: tt.query, Page: 1, PageSize: 10})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			idx := slices.IndexFunc(page.Movies, func(m *domain.Movie) bool { return m.ID == tt.movieID })
+			if idx < 0 {
+				t.Fatalf("%s not found: %v", tt.movieID, movieIDs(page.Movies))
+			}
+			highlights := page.Movies[idx].Highlights
+			if highlights == nil {
+				t.Fatal("no highlights")
+			}
+			if highlights.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", highlights.Title, tt.wantTitle)
+			}
+			if highlights.Description != tt.wantDescription {
+				t.Errorf("description = %q, want %q", highlights.Description, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestBuildTSQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "dark knight", want: "dark & knight"},
+		{query: `"Dark Knight" nolan`, want: "(dark <-> knight) & nolan"},
+		{query: "interst*", want: "interst:*"},
+		{query: `"dark kni*"`, want: "(dark <-> kni:*)"},
+		{query: "spider-man", want: "(spider <-> man)"},
+		{query: `'); DROP TABLE movies; --`, want: "drop & table & movies"},
+		{query: ` "" * `, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := buildTSQuery(parseSearchQuery(tt.query)); got != tt.want {
+				t.Errorf("buildTSQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
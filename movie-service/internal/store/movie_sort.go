@@ -0,0 +1,211 @@
+// movie-service/internal/store/movie_sort.go
+package store
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-service/internal/domain"
+	"shared/cursor"
+	"shared/sortspec"
+)
+
+// Поля сортировки списка фильмов (параметр sort).
+const (
+	MovieSortTitle         = "title"
+	MovieSortReleaseYear   = "release_year"
+	MovieSortCreatedAt     = "created_at"
+	MovieSortAverageRating = "average_rating"
+	MovieSortReviewCount   = "review_count"
+	MovieSortRelevance     = "relevance" // Только вместе с SearchQuery; по возрастанию - самые релевантные первыми
+)
+
+// MovieSortFields - поля, по которым разрешено сортировать список фильмов.
+var MovieSortFields = []string{
+	MovieSortTitle,
+	MovieSortReleaseYear,
+	MovieSortCreatedAt,
+	MovieSortAverageRating,
+	MovieSortReviewCount,
+	MovieSortRelevance,
+}
+
+// movieSortID завершает каждую сортировку фильмов (см. movieKeySpec).
+const movieSortID = "id"
+
+// movieSortColumns сопоставляет полям сортировки SQL выражения. Выражение для relevance
+// зависит от запроса и добавляется в movieKeyColumns. Год без значения считается нулевым,
+// как в domain.Movie, иначе NULL выпадал бы из сравнений keyset-пагинации.
+var movieSortColumns = map[string]string{
+	MovieSortTitle:         "LOWER(title)",
+	MovieSortReleaseYear:   "COALESCE(release_year, 0)",
+	MovieSortCreatedAt:     "created_at",
+	MovieSortAverageRating: "average_rating",
+	MovieSortReviewCount:   "review_count",
+	movieSortID:            "id",
+}
+
+// MovieSort возвращает сортировку списка: заданную или по умолчанию - самые релевантные
+// при поиске, иначе новые фильмы первыми.
+// Без поиска ключ relevance отбрасывается: ранга нет.
+func MovieSort(params MovieListParams) sortspec.Spec {
+	searching := len(parseSearchQuery(params.SearchQuery)) > 0
+	spec := make(sortspec.Spec, 0, len(params.Sort))
+	for _, key := range params.Sort {
+		if key.Field != MovieSortRelevance || searching {
+			spec = append(spec, key)
+		}
+	}
+	switch {
+	case len(spec) > 0:
+		return spec
+	case searching:
+		return sortspec.Spec{{Field: MovieSortRelevance}, {Field: MovieSortCreatedAt, Desc: true}}
+	default:
+		return sortspec.Spec{{Field: MovieSortCreatedAt, Desc: true}}
+	}
+}
+
+// movieKeySpec возвращает полный порядок списка: MovieSort и id в конце.
+func movieKeySpec(params MovieListParams) sortspec.Spec {
+	return MovieSort(params).WithTiebreak(movieSortID)
+}
+
+// movieKeyColumns возвращает SQL выражения ключей сортировки. tsQuery - выражение to_tsquery(...) при поиске.
+// Релевантность упорядочивается как место в выдаче: чем выше ранг, тем "меньше" значение
+// (псевдоним search_rank в выражениях ORDER BY недоступен).
+func movieKeyColumns(tsQuery string) map[string]string {
+	columns := maps.Clone(movieSortColumns)
+	if tsQuery != "" {
+		columns[MovieSortRelevance] = "-ts_rank(search_vector, " + tsQuery + ")"
+	}
+	return columns
+}
+
+// movieCursorScope возвращает список, для которого выдаются и принимаются курсоры: сортировку и отпечаток фильтров params.
+func movieCursorScope(params MovieListParams) cursor.Scope {
+	return cursor.Scope{Sort: MovieSort(params).String(), Filter: movieFilterHash(params)}
+}
+
+// movieFilterHash - отпечаток фильтров и поискового запроса. Значения приводятся к виду, в котором их
+// сравнивает buildMovieFilterSQL, поэтому одинаковые по смыслу запросы дают один отпечаток.
+func movieFilterHash(params MovieListParams) string {
+	genres := make([]string, len(params.Genres))
+	for i, genre := range params.Genres {
+		genres[i] = strings.ToLower(genre)
+	}
+	slices.Sort(genres)
+	return cursor.FilterHash(
+		string(params.Status),
+		strings.Join(slices.Compact(genres), ","),
+		strconv.FormatBool(params.GenreMatchAll),
+		strconv.Itoa(params.YearFrom),
+		strconv.Itoa(params.YearTo),
+		strings.ToLower(params.Director),
+		strings.ToLower(params.CastMember),
+		strconv.FormatFloat(params.MinRating, 'g', -1, 64),
+		strings.TrimSpace(params.SearchQuery),
+	)
+}
+
+// checkMovieCursor проверяет, что курсор выдан для того же списка (сортировки и фильтров) и содержит
+// по значению на каждый ключ spec из movieKeySpec.
+func checkMovieCursor(cur *cursor.Cursor, params MovieListParams, spec sortspec.Spec) error {
+	if cur == nil {
+		return nil
+	}
+	if cur.Scope != movieCursorScope(params) || len(cur.Values) != len(spec) {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// --- Сортировка в памяти для MockMovieStore ---
+
+// compareMovies сравнивает фильмы в порядке spec так же, как ORDER BY в PostgreSQL.
+func compareMovies(spec sortspec.Spec, a, b *domain.Movie) int {
+	return spec.Compare(func(field string) int {
+		switch field {
+		case MovieSortTitle:
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case MovieSortReleaseYear:
+			return cmp.Compare(a.ReleaseYear, b.ReleaseYear)
+		case MovieSortCreatedAt:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		case MovieSortAverageRating:
+			return cmp.Compare(a.AverageRating, b.AverageRating)
+		case MovieSortReviewCount:
+			return cmp.Compare(a.ReviewCount, b.ReviewCount)
+		case MovieSortRelevance:
+			return cmp.Compare(b.SearchRank, a.SearchRank)
+		case movieSortID:
+			return strings.Compare(a.ID, b.ID)
+		}
+		return 0
+	})
+}
+
+// sortMovies сортирует фильмы в памяти в порядке spec.
+func sortMovies(movies []domain.Movie, spec sortspec.Spec) {
+	sort.SliceStable(movies, func(i, j int) bool {
+		return compareMovies(spec, &movies[i], &movies[j]) < 0
+	})
+}
+
+// movieSortKey возвращает значения ключей spec фильма в виде текста (для курсора).
+func movieSortKey(spec sortspec.Spec, movie *domain.Movie) []string {
+	values := make([]string, 0, len(spec))
+	for _, key := range spec {
+		switch key.Field {
+		case MovieSortTitle:
+			values = append(values, strings.ToLower(movie.Title))
+		case MovieSortReleaseYear:
+			values = append(values, strconv.Itoa(movie.ReleaseYear))
+		case MovieSortCreatedAt:
+			values = append(values, movie.CreatedAt.Format(time.RFC3339Nano))
+		case MovieSortAverageRating:
+			values = append(values, strconv.FormatFloat(movie.AverageRating, 'g', -1, 64))
+		case MovieSortReviewCount:
+			values = append(values, strconv.FormatInt(movie.ReviewCount, 10))
+		case MovieSortRelevance:
+			values = append(values, strconv.FormatFloat(movie.SearchRank, 'g', -1, 64))
+		case movieSortID:
+			values = append(values, movie.ID)
+		}
+	}
+	return values
+}
+
+// movieFromSortKey восстанавливает из значений movieSortKey фильм, с которым можно сравнивать через compareMovies.
+func movieFromSortKey(spec sortspec.Spec, values []string) (*domain.Movie, error) {
+	movie := &domain.Movie{}
+	var err error
+	for i, key := range spec {
+		value := values[i]
+		switch key.Field {
+		case MovieSortTitle:
+			movie.Title = value
+		case MovieSortReleaseYear:
+			movie.ReleaseYear, err = strconv.Atoi(value)
+		case MovieSortCreatedAt:
+			movie.CreatedAt, err = time.Parse(time.RFC3339Nano, value)
+		case MovieSortAverageRating:
+			movie.AverageRating, err = strconv.ParseFloat(value, 64)
+		case MovieSortReviewCount:
+			movie.ReviewCount, err = strconv.ParseInt(value, 10, 64)
+		case MovieSortRelevance:
+			movie.SearchRank, err = strconv.ParseFloat(value, 64)
+		case movieSortID:
+			movie.ID = value
+		}
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return movie, nil
+}
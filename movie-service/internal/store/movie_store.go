@@ -6,52 +6,98 @@ import (
 	"log" // Можно заменить на slog, если передавать его в MockMovieStore
 	"movie-service/internal/domain"
 	"sort"
-	"strings"
 	"sync" // Для защиты доступа к in-memory карте
 	"time"
+
+	"shared/cursor"
+	"shared/sortspec"
 )
 
 var (
 	ErrMovieNotFound      = errors.New("movie not found")
 	ErrMovieAlreadyExists = errors.New("movie with these identifying features already exists")
+	ErrInvalidCursor      = errors.New("cursor does not match the list sort or filters")
 )
 
 type MovieListParams struct {
-	Page        int
-	PageSize    int
-	Genre       string
-	Year        int
-	SearchQuery string
-	SortBy      string
-	Status      domain.MovieStatus
+	Page          int
+	PageSize      int
+	Genres        []string // Без учета регистра
+	GenreMatchAll bool     // true - у фильма должны быть все Genres, false - хотя бы один
+	YearFrom      int      // 0 - без ограничения
+	YearTo        int      // 0 - без ограничения
+	Director      string   // Точное совпадение без учета регистра
+	CastMember    string   // Точное совпадение с одним из актеров без учета регистра
+	MinRating     float64  // Минимальная average_rating (копия оценок ReviewService); 0 - без ограничения
+	SearchQuery   string
+	Sort          sortspec.Spec // Поля из MovieSortFields; пустая - сортировка по умолчанию (см. MovieSort)
+	Status        domain.MovieStatus
+
+	// Keyset-пагинация: страница после (или перед, если Backward) граничной строки курсора; Page не учитывается.
+	// Курсор должен быть выдан для той же сортировки и тех же фильтров, иначе List возвращает ErrInvalidCursor.
+	Cursor *cursor.Cursor
+	// SkipCount - не считать общее количество фильмов (MoviePage.TotalCount будет -1).
+	SkipCount bool
+}
+
+// MoviePage - страница списка фильмов.
+type MoviePage struct {
+	Movies     []*domain.Movie
+	TotalCount int            // -1, если количество не считалось (SkipCount)
+	Next, Prev *cursor.Cursor // Курсоры соседних страниц; nil - страницы нет
 }
 
 type MovieStore interface {
 	Create(ctx context.Context, movie *domain.Movie) error
 	GetByID(ctx context.Context, id string) (*domain.Movie, error)
-	Update(ctx context.Context, movie *domain.Movie) error // Пока не реализован в Mock
-	Delete(ctx context.Context, id string) error           // Пока не реализован в Mock
-	List(ctx context.Context, params MovieListParams) ([]*domain.Movie, int, error)
+	// SuggestTitles возвращает до limit одобренных фильмов, название которых похоже на query (с учетом опечаток).
+	SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.MovieSuggestion, error)
+	// GetByIDs возвращает найденные неудаленные фильмы из ids (порядок не гарантируется, отсутствующие пропускаются).
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Movie, error)
+	Update(ctx context.Context, movie *domain.Movie) error
+	Delete(ctx context.Context, id string) error // Мягкое удаление
+	List(ctx context.Context, params MovieListParams) (*MoviePage, error)
+	// Facets считает фасеты (жанры, десятилетия, режиссеры) по фильмам, подходящим под фильтры params.
+	// Page, PageSize и Sort не учитываются.
+	Facets(ctx context.Context, params MovieListParams) (*domain.MovieFacets, error)
 	UpdateStatus(ctx context.Context, id string, status domain.MovieStatus) error
+	// DetachSubmitter отвязывает фильмы от удаленного пользователя и возвращает их количество.
+	DetachSubmitter(ctx context.Context, userID string) (int, error)
+	// ListBySubmitter возвращает фильмы пользователя, включая удаленные (выгрузка персональных данных).
+	ListBySubmitter(ctx context.Context, userID string, page, pageSize int) ([]*domain.Movie, int, error)
+	// SetRatings сохраняет копию оценок фильмов из ReviewService и отмечает их временем синхронизации syncedAt.
+	SetRatings(ctx context.Context, ratings []domain.MovieRating, syncedAt time.Time) error
+	// ResetStaleRatings обнуляет оценки фильмов, не обновленные с syncedAt (все отзывы удалены), и возвращает их количество.
+	ResetStaleRatings(ctx context.Context, syncedAt time.Time) (int, error)
 }
 
 type MockMovieStore struct {
 	mu               sync.RWMutex
 	movies           map[string]*domain.Movie // Фильмы, созданные во время выполнения
 	predefinedMovies map[string]*domain.Movie // Предопределенные фильмы для тестов
+	eventOffsets     map[string]int64         // Ключ: имя потребителя событий
+	titles           *titleIndex              // Триграммы названий для SuggestTitles
+	ratingsSyncedAt  map[string]time.Time     // Ключ: ID фильма; время последней синхронизации оценок
 }
 
 func NewMockMovieStore() *MockMovieStore {
 	predefined := map[string]*domain.Movie{
-		"existing-approved-id": {ID: "existing-approved-id", Title: "Одобренный тестовый фильм 1", Description: "Описание одобренного фильма 1", ReleaseYear: 2022, Genres: []string{"Sci-Fi", "Action"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-72 * time.Hour), UpdatedAt: time.Now().Add(-72 * time.Hour), SubmittedByUserID: "user1"},
-		"another-approved-id":  {ID: "another-approved-id", Title: "Другой одобренный фильм 2", Description: "Описание одобренного фильма 2", ReleaseYear: 2023, Genres: []string{"Comedy"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-48 * time.Hour), UpdatedAt: time.Now().Add(-48 * time.Hour), SubmittedByUserID: "user2"},
-		"yet-another-approved": {ID: "yet-another-approved", Title: "Еще один фильм (одобрен) 3", Description: "Описание фильма 3", ReleaseYear: 2022, Genres: []string{"Drama", "Thriller"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-96 * time.Hour), UpdatedAt: time.Now().Add(-96 * time.Hour), SubmittedByUserID: "user1"},
-		"pending-movie-id":     {ID: "pending-movie-id", Title: "Тестовый фильм на модерации 4", Description: "Описание фильма 4", ReleaseYear: 2024, Genres: []string{"Drama"}, Status: domain.StatusPendingApproval, CreatedAt: time.Now().Add(-24 * time.Hour), UpdatedAt: time.Now().Add(-24 * time.Hour), SubmittedByUserID: "user3"},
-		"early-bird-approved":  {ID: "early-bird-approved", Title: "Ранняя пташка (одобрен) 5", Description: "Описание фильма 5", ReleaseYear: 2021, Genres: []string{"Adventure", "Sci-Fi"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-120 * time.Hour), UpdatedAt: time.Now().Add(-120 * time.Hour), SubmittedByUserID: "user2"},
+		"existing-approved-id": {ID: "existing-approved-id", Title: "Одобренный тестовый фильм 1", Description: "Описание одобренного фильма 1", ReleaseYear: 2022, Genres: []string{"sci-fi", "action"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-72 * time.Hour), UpdatedAt: time.Now().Add(-72 * time.Hour), SubmittedByUserID: "user1"},
+		"another-approved-id":  {ID: "another-approved-id", Title: "Другой одобренный фильм 2", Description: "Описание одобренного фильма 2", ReleaseYear: 2023, Genres: []string{"comedy"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-48 * time.Hour), UpdatedAt: time.Now().Add(-48 * time.Hour), SubmittedByUserID: "user2"},
+		"yet-another-approved": {ID: "yet-another-approved", Title: "Еще один фильм (одобрен) 3", Description: "Описание фильма 3", ReleaseYear: 2022, Genres: []string{"drama", "thriller"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-96 * time.Hour), UpdatedAt: time.Now().Add(-96 * time.Hour), SubmittedByUserID: "user1"},
+		"pending-movie-id":     {ID: "pending-movie-id", Title: "Тестовый фильм на модерации 4", Description: "Описание фильма 4", ReleaseYear: 2024, Genres: []string{"drama"}, Status: domain.StatusPendingApproval, CreatedAt: time.Now().Add(-24 * time.Hour), UpdatedAt: time.Now().Add(-24 * time.Hour), SubmittedByUserID: "user3"},
+		"early-bird-approved":  {ID: "early-bird-approved", Title: "Ранняя пташка (одобрен) 5", Description: "Описание фильма 5", ReleaseYear: 2021, Genres: []string{"adventure", "sci-fi"}, Status: domain.StatusApproved, CreatedAt: time.Now().Add(-120 * time.Hour), UpdatedAt: time.Now().Add(-120 * time.Hour), SubmittedByUserID: "user2"},
+	}
+	titles := newTitleIndex()
+	for id, movie := range predefined {
+		titles.set(id, movie.Title)
 	}
 	return &MockMovieStore{
 		movies:           make(map[string]*domain.Movie),
 		predefinedMovies: predefined,
+		eventOffsets:     make(map[string]int64),
+		titles:           titles,
+		ratingsSyncedAt:  make(map[string]time.Time),
 	}
 }
 
@@ -70,6 +116,7 @@ func (m *MockMovieStore) Create(ctx context.Context, movie *domain.Movie) error
 	// Клонируем фильм перед сохранением, чтобы избежать изменения оригинала извне через указатель
 	movieCopy := *movie
 	m.movies[movie.ID] = &movieCopy
+	m.titles.set(movie.ID, movie.Title)
 	return nil
 }
 
@@ -78,89 +125,68 @@ func (m *MockMovieStore) GetByID(ctx context.Context, id string) (*domain.Movie,
 	defer m.mu.RUnlock()
 	log.Printf("[MOCK STORE] Getting movie by ID: %s\n", id)
 
-	if movie, ok := m.movies[id]; ok {
-		movieCopy := *movie // Возвращаем копию
-		return &movieCopy, nil
-	}
-	if movie, ok := m.predefinedMovies[id]; ok {
+	if movie := m.findLocked(id); movie != nil {
 		movieCopy := *movie // Возвращаем копию
 		return &movieCopy, nil
 	}
 	return nil, ErrMovieNotFound
 }
 
-func (m *MockMovieStore) List(ctx context.Context, params MovieListParams) ([]*domain.Movie, int, error) {
+func (m *MockMovieStore) GetByIDs(ctx context.Context, ids []string) ([]*domain.Movie, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	log.Printf("[MOCK STORE] Listing movies with params: %+v\n", params)
+	log.Printf("[MOCK STORE] Getting movies by IDs: %d ID(s)\n", len(ids))
 
-	var allMoviesSource []*domain.Movie // Собираем указатели на оригиналы для фильтрации
-	for _, movie := range m.predefinedMovies {
-		allMoviesSource = append(allMoviesSource, movie)
-	}
-	for _, movie := range m.movies {
-		allMoviesSource = append(allMoviesSource, movie)
+	movies := make([]*domain.Movie, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		movie := m.findLocked(id)
+		if movie == nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		movieCopy := *movie
+		movies = append(movies, &movieCopy)
 	}
+	return movies, nil
+}
 
-	var filteredMovies []domain.Movie // Здесь будут копии отфильтрованных фильмов
+func (m *MockMovieStore) SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.MovieSuggestion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK STORE] Suggesting titles for query: %q\n", query)
 
-	for _, moviePtr := range allMoviesSource {
-		movie := *moviePtr // Работаем с копией для проверок
-		keep := true
-		// Фильтр по статусу
-		if params.Status != "" && movie.Status != params.Status {
-			keep = false
-		}
-		// Фильтр по жанру
-		if keep && params.Genre != "" {
-			foundGenre := false
-			for _, g := range movie.Genres {
-				if strings.EqualFold(g, params.Genre) {
-					foundGenre = true
-					break
-				}
-			}
-			if !foundGenre {
-				keep = false
-			}
+	titleOf := func(id string) string {
+		if movie := m.findLocked(id); movie != nil {
+			return movie.Title
 		}
-		// Фильтр по году
-		if keep && params.Year != 0 && movie.ReleaseYear != params.Year {
-			keep = false
-		}
-		// Фильтр по поисковому запросу
-		if keep && params.SearchQuery != "" && !strings.Contains(strings.ToLower(movie.Title), strings.ToLower(params.SearchQuery)) {
-			keep = false
+		return ""
+	}
+	suggestions := []*domain.MovieSuggestion{}
+	for _, match := range m.titles.search(query, titleOf) {
+		movie := m.findLocked(match.movieID)
+		if movie == nil || movie.Status != domain.StatusApproved {
+			continue
 		}
-
-		if keep {
-			filteredMovies = append(filteredMovies, movie) // Добавляем копию в результат
+		suggestions = append(suggestions, &domain.MovieSuggestion{ID: movie.ID, Title: movie.Title, ReleaseYear: movie.ReleaseYear, PosterURL: movie.PosterURL})
+		if len(suggestions) == limit {
+			break
 		}
 	}
+	return suggestions, nil
+}
 
-	// Сортировка
-	sort.SliceStable(filteredMovies, func(i, j int) bool {
-		switch params.SortBy {
-		case "title_asc":
-			return strings.ToLower(filteredMovies[i].Title) < strings.ToLower(filteredMovies[j].Title)
-		case "title_desc":
-			return strings.ToLower(filteredMovies[i].Title) > strings.ToLower(filteredMovies[j].Title)
-		case "release_year_asc":
-			if filteredMovies[i].ReleaseYear == filteredMovies[j].ReleaseYear {
-				return strings.ToLower(filteredMovies[i].Title) < strings.ToLower(filteredMovies[j].Title)
-			}
-			return filteredMovies[i].ReleaseYear < filteredMovies[j].ReleaseYear
-		case "release_year_desc":
-			if filteredMovies[i].ReleaseYear == filteredMovies[j].ReleaseYear {
-				return strings.ToLower(filteredMovies[i].Title) < strings.ToLower(filteredMovies[j].Title)
-			}
-			return filteredMovies[i].ReleaseYear > filteredMovies[j].ReleaseYear
-		case "created_at_asc":
-			return filteredMovies[i].CreatedAt.Before(filteredMovies[j].CreatedAt)
-		default: // "created_at_desc" или неизвестное значение
-			return filteredMovies[i].CreatedAt.After(filteredMovies[j].CreatedAt)
-		}
-	})
+func (m *MockMovieStore) List(ctx context.Context, params MovieListParams) (*MoviePage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK STORE] Listing movies with params: %+v\n", params)
+
+	spec := movieKeySpec(params)
+	if err := checkMovieCursor(params.Cursor, params, spec); err != nil {
+		return nil, err
+	}
+	filteredMovies := m.filterMoviesLocked(params) // Копии отфильтрованных фильмов
+	sortMovies(filteredMovies, spec)
 
 	totalCount := len(filteredMovies)
 	if params.Page == 0 {
@@ -169,26 +195,40 @@ func (m *MockMovieStore) List(ctx context.Context, params MovieListParams) ([]*d
 	if params.PageSize == 0 {
 		params.PageSize = 10
 	}
+	limit := params.PageSize + 1 // Лишний фильм показывает, есть ли соседняя страница
 	start := (params.Page - 1) * params.PageSize
-	end := start + params.PageSize
-	if start < 0 {
-		start = 0
-	}
-	if start >= totalCount {
-		return []*domain.Movie{}, totalCount, nil
+	if params.Cursor != nil {
+		boundary, err := movieFromSortKey(spec, params.Cursor.Values)
+		if err != nil {
+			return nil, err
+		}
+		// Первый фильм после граничного; для страницы "назад" берем фильмы перед ним
+		start = sort.Search(totalCount, func(i int) bool {
+			return compareMovies(spec, &filteredMovies[i], boundary) > 0
+		})
+		if params.Cursor.Backward {
+			end := sort.Search(totalCount, func(i int) bool {
+				return compareMovies(spec, &filteredMovies[i], boundary) >= 0
+			})
+			start = max(end-limit, 0)
+			filteredMovies = filteredMovies[:end]
+		}
 	}
-	if end > totalCount {
-		end = totalCount
+	if params.SkipCount {
+		totalCount = -1
 	}
+	start = min(max(start, 0), len(filteredMovies))
+	end := min(start+limit, len(filteredMovies))
 
-	paginatedMovies := filteredMovies[start:end]
-	resultMoviesPtrs := make([]*domain.Movie, len(paginatedMovies))
-	for i := range paginatedMovies {
+	resultMoviesPtrs := make([]*domain.Movie, 0, end-start)
+	for i := start; i < end; i++ {
 		// Создаем новую копию для каждого элемента в итоговом слайсе указателей
-		movieCopy := paginatedMovies[i]
-		resultMoviesPtrs[i] = &movieCopy
+		movieCopy := filteredMovies[i]
+		resultMoviesPtrs = append(resultMoviesPtrs, &movieCopy)
 	}
-	return resultMoviesPtrs, totalCount, nil
+	movies, next, prev := cursor.Paginate(resultMoviesPtrs, params.PageSize, params.Cursor, params.Cursor == nil && params.Page > 1,
+		movieCursorScope(params), func(movie *domain.Movie) []string { return movieSortKey(spec, movie) })
+	return &MoviePage{Movies: movies, TotalCount: totalCount, Next: next, Prev: prev}, nil
 }
 
 func (m *MockMovieStore) UpdateStatus(ctx context.Context, id string, status domain.MovieStatus) error {
@@ -196,33 +236,142 @@ func (m *MockMovieStore) UpdateStatus(ctx context.Context, id string, status dom
 	defer m.mu.Unlock()
 	log.Printf("[MOCK STORE] Updating status for movie ID %s to %s\n", id, status)
 
-	// Сначала ищем в runtime созданных фильмах
-	if movie, ok := m.movies[id]; ok {
-		movie.Status = status
-		movie.UpdatedAt = time.Now().UTC()
-		return nil
+	// Для простоты мока обновляем фильм напрямую, для реальной БД это операция UPDATE
+	movie := m.findLocked(id)
+	if movie == nil {
+		return ErrMovieNotFound
 	}
-	// Затем в предопределенных
-	if movie, ok := m.predefinedMovies[id]; ok {
-		// Обновляем копию в predefinedMovies (или сам элемент, если считаем это приемлемым для мока)
-		// Для простоты мока, обновим напрямую, но для реальной БД это была бы операция UPDATE
-		movie.Status = status
-		movie.UpdatedAt = time.Now().UTC()
-		// m.predefinedMovies[id] = movie // Если movie был бы локальной копией
-		return nil
-	}
-	return ErrMovieNotFound
+	movie.Status = status
+	movie.UpdatedAt = time.Now().UTC()
+	return nil
 }
 
-// Заглушки для нереализованных методов интерфейса
 func (m *MockMovieStore) Update(ctx context.Context, movie *domain.Movie) error {
-	log.Printf("[MOCK STORE] Update method called for movie ID %s (NOT IMPLEMENTED)\n", movie.ID)
-	// TODO: Реализовать обновление полей фильма, если потребуется для тестов
-	return errors.New("mock update not implemented")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK STORE] Updating movie ID %s\n", movie.ID)
+
+	existing := m.findLocked(movie.ID)
+	if existing == nil {
+		return ErrMovieNotFound
+	}
+	movie.UpdatedAt = time.Now().UTC()
+	movieCopy := *movie
+	movieCopy.CreatedAt = existing.CreatedAt
+	movieCopy.SubmittedByUserID = existing.SubmittedByUserID
+	*existing = movieCopy
+	m.titles.set(movie.ID, movie.Title)
+	return nil
 }
 
 func (m *MockMovieStore) Delete(ctx context.Context, id string) error {
-	log.Printf("[MOCK STORE] Delete method called for movie ID %s (NOT IMPLEMENTED)\n", id)
-	// TODO: Реализовать удаление фильма из m.movies, если потребуется для тестов
-	return errors.New("mock delete not implemented")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK STORE] Soft-deleting movie ID %s\n", id)
+
+	movie := m.findLocked(id)
+	if movie == nil {
+		return ErrMovieNotFound
+	}
+	deletedAt := time.Now().UTC()
+	movie.DeletedAt = &deletedAt
+	movie.UpdatedAt = deletedAt
+	m.titles.remove(id)
+	return nil
+}
+
+func (m *MockMovieStore) DetachSubmitter(ctx context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK STORE] Detaching submitter %s from movies\n", userID)
+
+	detached := 0
+	for _, movies := range []map[string]*domain.Movie{m.movies, m.predefinedMovies} {
+		for _, movie := range movies {
+			if movie.SubmittedByUserID == userID {
+				movie.SubmittedByUserID = ""
+				detached++
+			}
+		}
+	}
+	return detached, nil
+}
+
+func (m *MockMovieStore) ListBySubmitter(ctx context.Context, userID string, page, pageSize int) ([]*domain.Movie, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK STORE] Listing movies submitted by %s, page %d\n", userID, page)
+
+	var submitted []*domain.Movie
+	for _, movies := range []map[string]*domain.Movie{m.movies, m.predefinedMovies} {
+		for _, movie := range movies {
+			if movie.SubmittedByUserID == userID {
+				movieCopy := *movie
+				submitted = append(submitted, &movieCopy)
+			}
+		}
+	}
+	sort.Slice(submitted, func(i, j int) bool { return submitted[i].CreatedAt.Before(submitted[j].CreatedAt) })
+
+	total := len(submitted)
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []*domain.Movie{}, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return submitted[start:end], total, nil
+}
+
+// findLocked ищет неудаленный фильм среди созданных и предопределенных. Вызывающий должен держать m.mu.
+func (m *MockMovieStore) findLocked(id string) *domain.Movie {
+	movie, ok := m.movies[id]
+	if !ok {
+		movie, ok = m.predefinedMovies[id]
+	}
+	if !ok || movie.DeletedAt != nil {
+		return nil
+	}
+	return movie
+}
+
+func (m *MockMovieStore) SetRatings(ctx context.Context, ratings []domain.MovieRating, syncedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK STORE] Setting ratings for %d movies\n", len(ratings))
+
+	for _, rating := range ratings {
+		movie, ok := m.movies[rating.MovieID]
+		if !ok {
+			movie, ok = m.predefinedMovies[rating.MovieID]
+		}
+		if !ok {
+			continue // Отзывы на фильм, которого нет в MovieService
+		}
+		movie.AverageRating = rating.AverageRating
+		movie.ReviewCount = rating.ReviewCount
+		m.ratingsSyncedAt[rating.MovieID] = syncedAt
+	}
+	return nil
+}
+
+func (m *MockMovieStore) ResetStaleRatings(ctx context.Context, syncedAt time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reset := 0
+	for _, movies := range []map[string]*domain.Movie{m.movies, m.predefinedMovies} {
+		for id, movie := range movies {
+			if movie.ReviewCount > 0 && m.ratingsSyncedAt[id].Before(syncedAt) {
+				movie.AverageRating = 0
+				movie.ReviewCount = 0
+				m.ratingsSyncedAt[id] = syncedAt
+				reset++
+			}
+		}
+	}
+	log.Printf("[MOCK STORE] Reset stale ratings of %d movies\n", reset)
+	return reset, nil
 }
@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings" // Для работы со строками в Search
 	"time"
 
 	"movie-service/internal/domain"
+	"shared/cursor"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq" // Для обработки ошибок PostgreSQL и работы с массивами TEXT[]
 	// _ "github.com/lib/pq" // Драйвер PostgreSQL уже должен быть импортирован в main.go MovieService, если там есть PostgresUserStore
@@ -65,8 +68,8 @@ func (s *PostgresMovieStore) Create(ctx context.Context, movie *domain.Movie) er
 
 // GetByID находит фильм по его ID.
 func (s *PostgresMovieStore) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
-	query := `SELECT id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, submitted_by_user_id, status, created_at, updated_at
-              FROM movies WHERE id = $1`
+	query := `SELECT id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, COALESCE(submitted_by_user_id::text, '') AS submitted_by_user_id, status, created_at, updated_at, average_rating, review_count
+              FROM movies WHERE id = $1 AND deleted_at IS NULL`
 	var movie domain.Movie
 
 	s.logger.DebugContext(ctx, "Executing GetMovieByID query", slog.String("movieID", id))
@@ -83,96 +86,174 @@ func (s *PostgresMovieStore) GetByID(ctx context.Context, id string) (*domain.Mo
 	return &movie, nil
 }
 
-// List возвращает список фильмов на основе предоставленных параметров.
-func (s *PostgresMovieStore) List(ctx context.Context, params MovieListParams) ([]*domain.Movie, int, error) {
-	var movies []*domain.Movie
-	var totalCount int
-
-	// Базовый запрос для подсчета общего количества
-	countQuery := `SELECT COUNT(*) FROM movies WHERE 1=1`
-	// Базовый запрос для выборки данных
-	selectQuery := `SELECT id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, submitted_by_user_id, status, created_at, updated_at
-                    FROM movies WHERE 1=1`
-
-	var args []interface{}
-	var conditions []string
-	argId := 1
-
-	if params.Status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argId))
-		args = append(args, params.Status)
-		argId++
+// GetByIDs находит неудаленные фильмы по списку ID одним запросом.
+// Строки, не являющиеся UUID, пропускаются: такого фильма быть не может, а в запросе они вызвали бы ошибку.
+func (s *PostgresMovieStore) GetByIDs(ctx context.Context, ids []string) ([]*domain.Movie, error) {
+	validIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			validIDs = append(validIDs, id)
+		}
 	}
-	if params.Genre != "" {
-		// Поиск по жанру в массиве (регистронезависимый)
-		conditions = append(conditions, fmt.Sprintf("LOWER(genres::text)::text[] @> ARRAY[LOWER($%d::text)]", argId))
-		args = append(args, params.Genre)
-		argId++
+	movies := []*domain.Movie{}
+	if len(validIDs) == 0 {
+		return movies, nil
 	}
-	if params.Year != 0 {
-		conditions = append(conditions, fmt.Sprintf("release_year = $%d", argId))
-		args = append(args, params.Year)
-		argId++
+
+	query := `SELECT id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, COALESCE(submitted_by_user_id::text, '') AS submitted_by_user_id, status, created_at, updated_at, average_rating, review_count
+              FROM movies WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL`
+	if err := s.db.SelectContext(ctx, &movies, query, pq.Array(validIDs)); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get movies by IDs from DB", slog.Int("count", len(validIDs)), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get movies by IDs: %w", err)
 	}
-	if params.SearchQuery != "" {
-		// Простой поиск по названию (регистронезависимый)
-		conditions = append(conditions, fmt.Sprintf("LOWER(title) LIKE LOWER($%d)", argId))
-		args = append(args, "%"+params.SearchQuery+"%")
-		argId++
+	return movies, nil
+}
+
+// SuggestTitles ищет одобренные фильмы с похожим названием через pg_trgm (индекс idx_movies_title_trgm).
+// Оператор <% (word_similarity) находит и опечатки, и недописанные слова; названия, начинающиеся
+// с запроса, идут первыми.
+func (s *PostgresMovieStore) SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.MovieSuggestion, error) {
+	sqlQuery := `SELECT id, title, COALESCE(release_year, 0) AS release_year, COALESCE(poster_url, '') AS poster_url
+                 FROM movies
+                 WHERE deleted_at IS NULL AND status = $1 AND ($2 <% title OR title ILIKE $3)
+                 ORDER BY title ILIKE $3 DESC, word_similarity($2, title) DESC, title
+                 LIMIT $4`
+	prefixPattern := likeEscaper.Replace(query) + "%"
+	suggestions := []*domain.MovieSuggestion{}
+	if err := s.db.SelectContext(ctx, &suggestions, sqlQuery, domain.StatusApproved, query, prefixPattern, limit); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to suggest movie titles from DB", slog.String("query", query), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to suggest movie titles: %w", err)
 	}
+	return suggestions, nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE во вводе пользователя.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
 
-	if len(conditions) > 0 {
-		conditionStr := " AND " + strings.Join(conditions, " AND ")
-		countQuery += conditionStr
-		selectQuery += conditionStr
+// List возвращает список фильмов на основе предоставленных параметров.
+func (s *PostgresMovieStore) List(ctx context.Context, params MovieListParams) (*MoviePage, error) {
+	totalCount := -1
+
+	// Полный порядок выдачи (сортировка и id) - по нему же строится условие курсора
+	spec := movieKeySpec(params)
+	if err := checkMovieCursor(params.Cursor, params, spec); err != nil {
+		return nil, err
 	}
 
+	// Условия фильтров (общие с Facets, см. movie_filters.go)
+	filter := buildMovieFilterSQL(params)
+	args := filter.args
+	tsQuery := filter.tsQuery
+	columns := movieKeyColumns(tsQuery)
+
 	// Получаем общее количество
-	s.logger.DebugContext(ctx, "Executing List movies count query", slog.String("query", countQuery), slog.Any("args", args))
-	err := s.db.GetContext(ctx, &totalCount, countQuery, args...)
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to count movies in DB", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to count movies: %w", err)
+	if !params.SkipCount {
+		countQuery := `SELECT COUNT(*) FROM movies WHERE ` + filter.where()
+		s.logger.DebugContext(ctx, "Executing List movies count query", slog.String("query", countQuery), slog.Any("args", args))
+		if err := s.db.GetContext(ctx, &totalCount, countQuery, args...); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to count movies in DB", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("failed to count movies: %w", err)
+		}
+		if totalCount == 0 {
+			return &MoviePage{Movies: []*domain.Movie{}}, nil
+		}
 	}
 
-	if totalCount == 0 {
-		return []*domain.Movie{}, 0, nil
+	// Базовый запрос для выборки данных; sort_key - значения ключей сортировки для курсоров
+	selectColumns := `id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, COALESCE(submitted_by_user_id::text, '') AS submitted_by_user_id, status, created_at, updated_at, average_rating, review_count, ` +
+		spec.KeyArray(columns) + ` AS sort_key`
+
+	argId := len(args) + 1
+	// При поиске выбираем ранг и подсвеченные фрагменты
+	if tsQuery != "" {
+		selectColumns += fmt.Sprintf(`, ts_rank(search_vector, %[1]s) AS search_rank,
+                    ts_headline('simple', title, %[1]s, $%[2]d) AS title_highlight,
+                    ts_headline('simple', COALESCE(description, ''), %[1]s, $%[3]d) AS description_highlight`, tsQuery, argId, argId+1)
+		args = append(args, titleHeadlineOptions, descriptionHeadlineOptions)
+		argId += 2
 	}
+	where := filter.where()
 
-	// Добавляем сортировку
-	// TODO: Добавить более гибкую и безопасную сортировку
-	orderBy := "created_at DESC" // Сортировка по умолчанию
-	if params.SortBy != "" {
-		// В реальном приложении здесь нужна валидация SortBy, чтобы избежать SQL-инъекций
-		// Например, разрешать только определенные поля и направления
-		// Для примера, если params.SortBy = "title_asc", то orderBy = "title ASC"
-		// Этот мок пока не реализует сложную сортировку
-		if params.SortBy == "release_year_desc" {
-			orderBy = "release_year DESC, title ASC"
-		} else if params.SortBy == "title_asc" {
-			orderBy = "title ASC"
+	// Keyset-пагинация: строки после граничной; страница "назад" читается в обратном порядке и переворачивается
+	order := spec
+	offset := (params.Page - 1) * params.PageSize
+	backward := params.Cursor != nil && params.Cursor.Backward
+	if params.Cursor != nil {
+		if backward {
+			order = spec.Reverse()
 		}
+		keyset, keysetArgs := order.Keyset(columns, params.Cursor.Values, argId)
+		where += " AND " + keyset
+		args = append(args, keysetArgs...)
+		argId += len(keysetArgs)
+		offset = 0
 	}
-	selectQuery += " ORDER BY " + orderBy
+	selectQuery := `SELECT ` + selectColumns + ` FROM movies WHERE ` + where
 
-	// Добавляем пагинацию
+	// Сортировка только по полям из белого списка (MovieSortFields, см. movie_sort.go)
+	selectQuery += " ORDER BY " + order.OrderBy(columns)
+
+	// Добавляем пагинацию; лишний фильм показывает, есть ли соседняя страница
 	selectQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argId, argId+1)
-	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+	args = append(args, params.PageSize+1, offset)
 	argId += 2
 
 	s.logger.DebugContext(ctx, "Executing List movies select query", slog.String("query", selectQuery), slog.Any("args", args))
-	err = s.db.SelectContext(ctx, &movies, selectQuery, args...)
-	if err != nil {
+	var rows []movieRow
+	if tsQuery != "" {
+		var searchRows []movieSearchRow
+		if err := s.db.SelectContext(ctx, &searchRows, selectQuery, args...); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to search movies in DB", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("failed to list movies: %w", err)
+		}
+		rows = make([]movieRow, 0, len(searchRows))
+		for _, row := range searchRows {
+			row.Movie.Highlights = &domain.MovieHighlights{
+				Title:       renderHighlight(row.TitleHighlight),
+				Description: renderHighlight(row.DescriptionHighlight),
+			}
+			rows = append(rows, row.movieRow)
+		}
+	} else if err := s.db.SelectContext(ctx, &rows, selectQuery, args...); err != nil {
 		s.logger.ErrorContext(ctx, "Failed to list movies from DB", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to list movies: %w", err)
+		return nil, fmt.Errorf("failed to list movies: %w", err)
 	}
 
-	return movies, totalCount, nil
+	if backward {
+		slices.Reverse(rows)
+	}
+	rows, next, prev := cursor.Paginate(rows, params.PageSize, params.Cursor, params.Cursor == nil && params.Page > 1,
+		movieCursorScope(params), func(row movieRow) []string { return row.SortKey })
+	movies := make([]*domain.Movie, len(rows))
+	for i := range rows {
+		movies[i] = &rows[i].Movie
+	}
+	return &MoviePage{Movies: movies, TotalCount: totalCount, Next: next, Prev: prev}, nil
+}
+
+// movieRow - строка списка фильмов: фильм и значения ключей сортировки для курсоров.
+type movieRow struct {
+	domain.Movie
+	SortKey pq.StringArray `db:"sort_key"`
+}
+
+// Параметры ts_headline: совпадения обрамляются маркерами, которые renderHighlight превращает в <mark></mark>
+// после HTML-экранирования текста. В названии подсвечиваются все совпадения, из описания берется фрагмент.
+const (
+	titleHeadlineOptions       = "StartSel=" + highlightStartMarker + ", StopSel=" + highlightStopMarker + ", HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=" + highlightStartMarker + ", StopSel=" + highlightStopMarker + ", MaxWords=30, MinWords=15, MaxFragments=1"
+)
+
+// movieSearchRow - строка результата полнотекстового поиска: фильм, ранг и подсветка из ts_headline.
+type movieSearchRow struct {
+	movieRow
+	TitleHighlight       string `db:"title_highlight"`
+	DescriptionHighlight string `db:"description_highlight"`
 }
 
 // UpdateStatus обновляет статус фильма.
 func (s *PostgresMovieStore) UpdateStatus(ctx context.Context, id string, status domain.MovieStatus) error {
-	query := `UPDATE movies SET status = $1, updated_at = $2 WHERE id = $3`
+	query := `UPDATE movies SET status = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
 	updatedAt := time.Now().UTC()
 
 	s.logger.DebugContext(ctx, "Executing UpdateMovieStatus query", slog.String("movieID", id), slog.String("status", string(status)))
@@ -190,19 +271,149 @@ func (s *PostgresMovieStore) UpdateStatus(ctx context.Context, id string, status
 	return nil
 }
 
-// TODO: Реализовать методы Update и Delete для MovieStore
+// Update обновляет все редактируемые поля фильма.
 func (s *PostgresMovieStore) Update(ctx context.Context, movie *domain.Movie) error {
-	s.logger.WarnContext(ctx, "Update movie method not fully implemented for PostgresMovieStore")
-	// Примерный запрос:
-	// query := `UPDATE movies SET title=$1, description=$2, release_year=$3, director=$4, genres=$5, cast_members=$6, poster_url=$7, trailer_url=$8, status=$9, updated_at=$10 WHERE id=$11`
-	// _, err := s.db.ExecContext(ctx, query, movie.Title, ..., movie.ID)
-	return errors.New("update movie not implemented yet")
+	query := `UPDATE movies SET title = $1, description = $2, release_year = $3, director = $4, genres = $5, cast_members = $6,
+                  poster_url = $7, trailer_url = $8, status = $9, updated_at = $10
+              WHERE id = $11 AND deleted_at IS NULL`
+	movie.UpdatedAt = time.Now().UTC()
+
+	s.logger.DebugContext(ctx, "Executing Update movie query", slog.String("movieID", movie.ID))
+	result, err := s.db.ExecContext(ctx, query,
+		movie.Title, movie.Description, movie.ReleaseYear, movie.Director,
+		pq.Array(movie.Genres), pq.Array(movie.Cast),
+		movie.PosterURL, movie.TrailerURL, movie.Status, movie.UpdatedAt,
+		movie.ID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			s.logger.WarnContext(ctx, "Movie update violates unique constraint", slog.String("movieID", movie.ID), slog.String("constraint", pqErr.Constraint))
+			return ErrMovieAlreadyExists
+		}
+		s.logger.ErrorContext(ctx, "Failed to update movie in DB", slog.String("movieID", movie.ID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update movie: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get rows affected after movie update", slog.String("movieID", movie.ID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to check movie update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		s.logger.WarnContext(ctx, "No movie found to update in DB", slog.String("movieID", movie.ID))
+		return ErrMovieNotFound
+	}
+	s.logger.InfoContext(ctx, "Movie updated successfully in DB", slog.String("movieID", movie.ID))
+	return nil
 }
 
+// Delete выполняет мягкое удаление фильма: строка остается в БД, но скрывается из List, GetByID и gRPC.
 func (s *PostgresMovieStore) Delete(ctx context.Context, id string) error {
-	s.logger.WarnContext(ctx, "Delete movie method not fully implemented for PostgresMovieStore")
-	// Примерный запрос:
-	// query := `DELETE FROM movies WHERE id=$1`
-	// _, err := s.db.ExecContext(ctx, query, id)
-	return errors.New("delete movie not implemented yet")
+	query := `UPDATE movies SET deleted_at = $1, updated_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+	deletedAt := time.Now().UTC()
+
+	s.logger.DebugContext(ctx, "Executing soft Delete movie query", slog.String("movieID", id))
+	result, err := s.db.ExecContext(ctx, query, deletedAt, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete movie in DB", slog.String("movieID", id), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to delete movie: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get rows affected after movie delete", slog.String("movieID", id), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to check movie delete result: %w", err)
+	}
+	if rowsAffected == 0 {
+		s.logger.WarnContext(ctx, "No movie found to delete in DB", slog.String("movieID", id))
+		return ErrMovieNotFound
+	}
+	s.logger.InfoContext(ctx, "Movie soft-deleted successfully in DB", slog.String("movieID", id))
+	return nil
+}
+
+// ListBySubmitter возвращает фильмы пользователя, включая мягко удаленные, от старых к новым.
+func (s *PostgresMovieStore) ListBySubmitter(ctx context.Context, userID string, page, pageSize int) ([]*domain.Movie, int, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return []*domain.Movie{}, 0, nil // Не UUID - фильмов с таким автором быть не может
+	}
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM movies WHERE submitted_by_user_id = $1`
+	if err := s.db.GetContext(ctx, &totalCount, countQuery, userID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count movies by submitter in DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to count movies by submitter: %w", err)
+	}
+	if totalCount == 0 {
+		return []*domain.Movie{}, 0, nil
+	}
+
+	movies := []*domain.Movie{}
+	selectQuery := `SELECT id, title, description, release_year, director, genres, cast_members, poster_url, trailer_url, submitted_by_user_id::text AS submitted_by_user_id, status, created_at, updated_at, deleted_at
+                    FROM movies WHERE submitted_by_user_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`
+	if err := s.db.SelectContext(ctx, &movies, selectQuery, userID, pageSize, (page-1)*pageSize); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list movies by submitter from DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to list movies by submitter: %w", err)
+	}
+	return movies, totalCount, nil
+}
+
+// DetachSubmitter обнуляет submitted_by_user_id у всех фильмов пользователя (включая удаленные).
+// Повторный вызов безопасен.
+func (s *PostgresMovieStore) DetachSubmitter(ctx context.Context, userID string) (int, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return 0, nil
+	}
+	query := `UPDATE movies SET submitted_by_user_id = NULL WHERE submitted_by_user_id = $1`
+
+	result, err := s.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to detach submitter in DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to detach submitter: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check detach submitter result: %w", err)
+	}
+	s.logger.InfoContext(ctx, "Submitter detached from movies", slog.String("userID", userID), slog.Int64("count", rowsAffected))
+	return int(rowsAffected), nil
+}
+
+// SetRatings обновляет копию оценок одним запросом. Строки, не являющиеся UUID, пропускаются.
+func (s *PostgresMovieStore) SetRatings(ctx context.Context, ratings []domain.MovieRating, syncedAt time.Time) error {
+	ids := make([]string, 0, len(ratings))
+	averages := make([]float64, 0, len(ratings))
+	counts := make([]int64, 0, len(ratings))
+	for _, rating := range ratings {
+		if _, err := uuid.Parse(rating.MovieID); err != nil {
+			continue
+		}
+		ids = append(ids, rating.MovieID)
+		averages = append(averages, rating.AverageRating)
+		counts = append(counts, rating.ReviewCount)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE movies AS m
+              SET average_rating = r.average_rating, review_count = r.review_count, ratings_synced_at = $4
+              FROM unnest($1::uuid[], $2::double precision[], $3::bigint[]) AS r(id, average_rating, review_count)
+              WHERE m.id = r.id`
+	if _, err := s.db.ExecContext(ctx, query, pq.Array(ids), pq.Array(averages), pq.Array(counts), syncedAt); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update movie ratings in DB", slog.Int("count", len(ids)), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update movie ratings: %w", err)
+	}
+	return nil
+}
+
+// ResetStaleRatings обнуляет оценки фильмов, которые не обновлялись с syncedAt.
+func (s *PostgresMovieStore) ResetStaleRatings(ctx context.Context, syncedAt time.Time) (int, error) {
+	query := `UPDATE movies SET average_rating = 0, review_count = 0, ratings_synced_at = $1
+              WHERE review_count > 0 AND (ratings_synced_at IS NULL OR ratings_synced_at < $1)`
+	result, err := s.db.ExecContext(ctx, query, syncedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to reset stale movie ratings in DB", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to reset stale movie ratings: %w", err)
+	}
+	reset, _ := result.RowsAffected()
+	return int(reset), nil
 }
@@ -0,0 +1,141 @@
+// movie-service/internal/store/title_index.go
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+// Нечеткий поиск названий для автодополнения в MockMovieStore. Повторяет подход pg_trgm,
+// который использует PostgresMovieStore: слова разбиваются на триграммы с пробелами по краям,
+// а сходство запроса с названием - это лучшее сходство с непрерывным участком триграмм названия
+// (аналог word_similarity), поэтому и опечатки, и недописанные слова находят нужный фильм.
+
+// suggestSimilarityThreshold - минимальное сходство, как pg_trgm.word_similarity_threshold по умолчанию.
+const suggestSimilarityThreshold = 0.6
+
+// titleTrigrams возвращает триграммы текста по порядку (с повторами), как их строит pg_trgm.
+func titleTrigrams(text string) []string {
+	var trigrams []string
+	for _, word := range searchTokens(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams = append(trigrams, string(padded[i:i+3]))
+		}
+	}
+	return trigrams
+}
+
+// wordSimilarity - наибольшее сходство (по Жаккару) множества триграмм запроса
+// с непрерывным участком триграмм названия, от 0 до 1.
+func wordSimilarity(queryTrigrams map[string]bool, title []string) float64 {
+	if len(queryTrigrams) == 0 {
+		return 0
+	}
+	best := 0.0
+	for start := range title {
+		window := make(map[string]bool)
+		common := 0
+		for end := start; end < len(title); end++ {
+			if !window[title[end]] {
+				window[title[end]] = true
+				if queryTrigrams[title[end]] {
+					common++
+				}
+			}
+			if common == 0 {
+				continue
+			}
+			similarity := float64(common) / float64(len(queryTrigrams)+len(window)-common)
+			if similarity > best {
+				best = similarity
+			}
+		}
+	}
+	return best
+}
+
+// titleIndex - инвертированный индекс триграмм названий: триграмма -> ID фильмов.
+// Позволяет при каждом нажатии клавиши сравнивать запрос только с фильмами, у которых есть общие триграммы.
+type titleIndex struct {
+	byTrigram map[string]map[string]struct{}
+	trigrams  map[string][]string // ID фильма -> триграммы его названия
+}
+
+func newTitleIndex() *titleIndex {
+	return &titleIndex{
+		byTrigram: make(map[string]map[string]struct{}),
+		trigrams:  make(map[string][]string),
+	}
+}
+
+// set индексирует (или переиндексирует) название фильма.
+func (idx *titleIndex) set(movieID, title string) {
+	idx.remove(movieID)
+	trigrams := titleTrigrams(title)
+	idx.trigrams[movieID] = trigrams
+	for _, trigram := range trigrams {
+		ids, ok := idx.byTrigram[trigram]
+		if !ok {
+			ids = make(map[string]struct{})
+			idx.byTrigram[trigram] = ids
+		}
+		ids[movieID] = struct{}{}
+	}
+}
+
+// remove удаляет фильм из индекса.
+func (idx *titleIndex) remove(movieID string) {
+	for _, trigram := range idx.trigrams[movieID] {
+		delete(idx.byTrigram[trigram], movieID)
+		if len(idx.byTrigram[trigram]) == 0 {
+			delete(idx.byTrigram, trigram)
+		}
+	}
+	delete(idx.trigrams, movieID)
+}
+
+// titleMatch - кандидат автодополнения со сходством и признаком совпадения по началу названия.
+type titleMatch struct {
+	movieID    string
+	prefix     bool
+	similarity float64
+}
+
+// search возвращает кандидатов, похожих на query, лучшие первыми: сначала названия, начинающиеся
+// с запроса, затем по убыванию сходства. titleOf возвращает название фильма по ID.
+func (idx *titleIndex) search(query string, titleOf func(movieID string) string) []titleMatch {
+	queryTrigrams := make(map[string]bool)
+	for _, trigram := range titleTrigrams(query) {
+		queryTrigrams[trigram] = true
+	}
+	candidates := make(map[string]struct{})
+	for trigram := range queryTrigrams {
+		for movieID := range idx.byTrigram[trigram] {
+			candidates[movieID] = struct{}{}
+		}
+	}
+
+	normalizedQuery := strings.Join(searchTokens(query), " ")
+	var matches []titleMatch
+	for movieID := range candidates {
+		match := titleMatch{
+			movieID:    movieID,
+			prefix:     strings.HasPrefix(strings.Join(searchTokens(titleOf(movieID)), " "), normalizedQuery),
+			similarity: wordSimilarity(queryTrigrams, idx.trigrams[movieID]),
+		}
+		if match.prefix || match.similarity >= suggestSimilarityThreshold {
+			matches = append(matches, match)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix
+		}
+		if matches[i].similarity != matches[j].similarity {
+			return matches[i].similarity > matches[j].similarity
+		}
+		return titleOf(matches[i].movieID) < titleOf(matches[j].movieID)
+	})
+	return matches
+}
@@ -0,0 +1,80 @@
+// movie-service/internal/store/title_index_test.go
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"movie-service/internal/domain"
+)
+
+func TestMockSuggestTitles(t *testing.T) {
+	ctx := context.Background()
+	movieStore := NewMockMovieStore()
+	movies := []*domain.Movie{
+		{ID: "title-1", Title: "Interstellar", Status: domain.StatusApproved},
+		{ID: "title-2", Title: "International", Status: domain.StatusApproved},
+		{ID: "title-3", Title: "The Dark Knight", Status: domain.StatusApproved},
+		{ID: "title-4", Title: "Dark City", Status: domain.StatusApproved},
+		{ID: "title-5", Title: "Interstellar Director's Cut", Status: domain.StatusPendingApproval},
+		{ID: "title-6", Title: "Interstellar Extended", Status: domain.StatusApproved},
+		{ID: "title-7", Title: "Old Title", Status: domain.StatusApproved},
+	}
+	for i := range 25 {
+		movies = append(movies, &domain.Movie{ID: fmt.Sprintf("sequel-%02d", i), Title: fmt.Sprintf("Sequel %02d", i), Status: domain.StatusApproved})
+	}
+	for _, movie := range movies {
+		if err := movieStore.Create(ctx, movie); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if err := movieStore.Delete(ctx, "title-6"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	renamed := *movies[6]
+	renamed.Title = "New Name"
+	if err := movieStore.Update(ctx, &renamed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string // Названия подсказок по порядку; nil - проверяется только количество
+		count int
+	}{
+		{name: "typo", query: "Interstelar", limit: 10, want: []string{"Interstellar"}},
+		{name: "prefix of the title first", query: "inter", limit: 10, want: []string{"International", "Interstellar"}},
+		{name: "prefix before a word inside the title", query: "dark", limit: 10, want: []string{"Dark City", "The Dark Knight"}},
+		{name: "case and punctuation are ignored", query: "THE dark-kni", limit: 10, want: []string{"The Dark Knight"}},
+		{name: "pending and deleted movies are hidden", query: "interstellar", limit: 10, want: []string{"Interstellar"}},
+		{name: "renamed movie found by new title", query: "new name", limit: 10, want: []string{"New Name"}},
+		{name: "renamed movie not found by old title", query: "old title", limit: 10, want: []string{}},
+		{name: "unrelated query", query: "zzzz", limit: 10, want: []string{}},
+		{name: "limit", query: "sequel", limit: 20, count: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := movieStore.SuggestTitles(ctx, tt.query, tt.limit)
+			if err != nil {
+				t.Fatalf("SuggestTitles: %v", err)
+			}
+			titles := make([]string, 0, len(suggestions))
+			for _, suggestion := range suggestions {
+				titles = append(titles, suggestion.Title)
+			}
+			if tt.want == nil {
+				if len(titles) != tt.count {
+					t.Errorf("got %d suggestions, want %d", len(titles), tt.count)
+				}
+				return
+			}
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("suggestions = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
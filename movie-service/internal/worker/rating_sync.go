@@ -0,0 +1,96 @@
+// movie-service/internal/worker/rating_sync.go
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"movie-service/internal/domain"
+	"movie-service/internal/genproto/reviewpb"
+)
+
+// ratingSyncBatchSize - сколько оценок запрашивается у ReviewService за один вызов.
+const ratingSyncBatchSize = 500
+
+// MovieRatingsSource - источник оценок фильмов (gRPC клиент ReviewService).
+type MovieRatingsSource interface {
+	ListMovieRatings(ctx context.Context, afterMovieID string, limit int32) ([]*reviewpb.MovieRating, error)
+}
+
+// RatingStore - хранилище, в которое копируются оценки.
+type RatingStore interface {
+	SetRatings(ctx context.Context, ratings []domain.MovieRating, syncedAt time.Time) error
+	ResetStaleRatings(ctx context.Context, syncedAt time.Time) (int, error)
+}
+
+// RatingSyncer периодически копирует средние оценки и число отзывов из ReviewService
+// в таблицу movies, чтобы список фильмов можно было сортировать по ним в одном запросе.
+// Каждый проход читает все оценки заново, поэтому пропущенный из-за ошибки проход
+// догоняется следующим; фильмы, у которых не осталось отзывов, обнуляются в конце полного прохода.
+type RatingSyncer struct {
+	source   MovieRatingsSource
+	store    RatingStore
+	logger   *slog.Logger
+	interval time.Duration
+}
+
+// NewRatingSyncer создает RatingSyncer, синхронизирующий оценки раз в interval.
+func NewRatingSyncer(source MovieRatingsSource, s RatingStore, logger *slog.Logger, interval time.Duration) *RatingSyncer {
+	return &RatingSyncer{source: source, store: s, logger: logger, interval: interval}
+}
+
+// Run выполняет синхронизацию до отмены ctx.
+func (rs *RatingSyncer) Run(ctx context.Context) {
+	rs.logger.Info("Rating syncer started", slog.Duration("interval", rs.interval))
+	ticker := time.NewTicker(rs.interval)
+	defer ticker.Stop()
+	for {
+		if err := rs.sync(ctx); err != nil && ctx.Err() == nil {
+			rs.logger.ErrorContext(ctx, "Failed to sync movie ratings, will retry", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			rs.logger.Info("Rating syncer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync выполняет один полный проход по оценкам ReviewService.
+func (rs *RatingSyncer) sync(ctx context.Context) error {
+	syncedAt := time.Now().UTC()
+	afterMovieID := ""
+	synced := 0
+	for {
+		batch, err := rs.source.ListMovieRatings(ctx, afterMovieID, ratingSyncBatchSize)
+		if err != nil {
+			return err
+		}
+		ratings := make([]domain.MovieRating, 0, len(batch))
+		for _, rating := range batch {
+			ratings = append(ratings, domain.MovieRating{
+				MovieID:       rating.GetMovieId(),
+				AverageRating: rating.GetAverageRating(),
+				ReviewCount:   rating.GetReviewCount(),
+			})
+		}
+		if err := rs.store.SetRatings(ctx, ratings, syncedAt); err != nil {
+			return fmt.Errorf("save ratings after movie %q: %w", afterMovieID, err)
+		}
+		synced += len(ratings)
+		if len(batch) < ratingSyncBatchSize {
+			break
+		}
+		afterMovieID = batch[len(batch)-1].GetMovieId()
+	}
+
+	reset, err := rs.store.ResetStaleRatings(ctx, syncedAt)
+	if err != nil {
+		return err
+	}
+	rs.logger.InfoContext(ctx, "Movie ratings synced", slog.Int("synced", synced), slog.Int("reset", reset))
+	return nil
+}
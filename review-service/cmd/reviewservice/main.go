@@ -3,9 +3,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	_ "net" // Для gRPC клиента, если он будет здесь инициализироваться
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,12 +17,19 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/jmoiron/sqlx" // Для sqlx.DB
 	_ "github.com/lib/pq"     // Драйвер PostgreSQL
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	"review-service/internal/api"
 	"review-service/internal/clients"
+	"review-service/internal/genproto/reviewpb"
+	"review-service/internal/genproto/userpb"
+	grpcServer "review-service/internal/grpc"
 	"review-service/internal/store"
+	"shared/auth"
+	"shared/cursor"
+	"shared/userevents"
 	// "review-service/internal/genproto/moviepb" // Импорты для gRPC клиентов, если они здесь
-	// "review-service/internal/genproto/userpb"
 )
 
 // getDBConnectionString возвращает строку подключения к БД для ReviewService.
@@ -64,6 +72,24 @@ func connectToDB(dbURL string, logger *slog.Logger) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// newCursorCodec создает подпись курсоров пагинации с ключом из PAGINATION_CURSOR_SECRET.
+// Без переменной сервис не запускается. Для локальной разработки PAGINATION_CURSOR_INSECURE_DEV=true
+// разрешает случайный ключ: курсоры не переживают перезапуск и не подходят другим репликам сервиса.
+func newCursorCodec(logger *slog.Logger) (*cursor.Codec, error) {
+	if secret := os.Getenv("PAGINATION_CURSOR_SECRET"); secret != "" {
+		return cursor.NewCodec([]byte(secret)), nil
+	}
+	if os.Getenv("PAGINATION_CURSOR_INSECURE_DEV") != "true" {
+		return nil, errors.New("PAGINATION_CURSOR_SECRET environment variable not set (set PAGINATION_CURSOR_INSECURE_DEV=true to use a random key in development)")
+	}
+	key, err := cursor.RandomKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cursor signing key: %w", err)
+	}
+	logger.Warn("PAGINATION_CURSOR_SECRET environment variable not set and PAGINATION_CURSOR_INSECURE_DEV is true, using a random key. Pagination cursors will not survive restarts or work across replicas.")
+	return cursor.NewCodec(key), nil
+}
+
 // extractPassword (эта функция больше не нужна, если логируем URL без пароля по-другому)
 // func extractPassword(dbURL string) string { /* ... */ }
 
@@ -71,10 +97,29 @@ func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	validate := validator.New()
 	httpPort := "8082"
+	grpcPort := "9093"
 
 	userServiceGRPCAddr := "localhost:9091"
 	movieServiceGRPCAddr := "localhost:9092"
 
+	// --- Проверка JWT по публичным ключам UserService ---
+	jwksURL := os.Getenv("USER_SERVICE_JWKS_URL")
+	if jwksURL == "" {
+		jwksURL = "http://localhost:8080/.well-known/jwks.json"
+		logger.Warn("USER_SERVICE_JWKS_URL environment variable not set, using default.", slog.String("url", jwksURL))
+	}
+	tokenValidator, err := auth.NewTokenValidator(jwksURL, logger)
+	if err != nil {
+		logger.Error("Failed to create token validator", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Info("Token validator initialized.")
+
+	// Политика подтверждения email: по умолчанию пользователи с неподтвержденным email
+	// могут входить, но не могут выполнять действия, публикующие контент
+	requireVerifiedEmail := os.Getenv("REQUIRE_VERIFIED_EMAIL") != "false"
+	logger.Info("Email verification policy configured.", slog.Bool("require_verified_email", requireVerifiedEmail))
+
 	// --- Инициализация хранилища PostgreSQL для ReviewService ---
 	dbURL := getDBConnectionString()
 	db, err := connectToDB(dbURL, logger)
@@ -119,8 +164,37 @@ func main() {
 	logger.Info("MovieService gRPC client created and connected.")
 	clientCancel()
 
+	// --- Очистка данных удаленных пользователей по событиям UserService ---
+	consumerCtx, stopConsumers := context.WithCancel(context.Background())
+	defer stopConsumers()
+	userEventConsumer := userevents.NewConsumer[*userpb.UserEvent](userSvcClient, reviewStorage, reviewStorage.DeleteByUserID, logger, 30*time.Second)
+	go userEventConsumer.Run(consumerCtx)
+
+	// --- Настройка и запуск gRPC сервера (выгрузка персональных данных для UserService) ---
+	grpcServiceImplementation := grpcServer.NewServer(reviewStorage, logger)
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		logger.Error("Failed to listen for Review Service gRPC", slog.String("port", grpcPort), slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	grpcSrv := grpc.NewServer()
+	reviewpb.RegisterReviewInterServiceServer(grpcSrv, grpcServiceImplementation)
+	reflection.Register(grpcSrv)
+
+	go func() {
+		logger.Info("Review Service gRPC server starting", slog.String("port", grpcPort))
+		if err := grpcSrv.Serve(lis); err != nil {
+			logger.Error("Review Service gRPC server Serve() failed", slog.String("error", err.Error()))
+		}
+	}()
+
 	// Создание HTTP обработчика API
-	reviewAPIHandler := api.NewReviewHandler(reviewStorage, logger, validate, userSvcClient, movieSvcClient) // Передаем PostgresReviewStore
+	cursorCodec, err := newCursorCodec(logger)
+	if err != nil {
+		logger.Error("Failed to set up pagination cursors", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	reviewAPIHandler := api.NewReviewHandler(reviewStorage, logger, validate, userSvcClient, movieSvcClient, tokenValidator, cursorCodec, requireVerifiedEmail) // Передаем PostgresReviewStore
 	router := api.NewReviewRouter(reviewAPIHandler)
 
 	// Настройка и запуск HTTP-сервера
@@ -145,6 +219,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Review Service shutting down...")
+	stopConsumers()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
@@ -155,6 +230,9 @@ func main() {
 		logger.Info("Review Service HTTP Server gracefully stopped.")
 	}
 
+	grpcSrv.GracefulStop()
+	logger.Info("Review Service gRPC server gracefully stopped.")
+
 	if closer, ok := userSvcClient.(interface{ Close() error }); ok {
 		closer.Close()
 	}
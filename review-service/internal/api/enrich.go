@@ -0,0 +1,101 @@
+// review-service/internal/api/enrich.go
+package api
+
+import (
+	"context"
+	"log/slog"
+
+	"review-service/internal/domain"
+	"review-service/internal/genproto/moviepb"
+	"review-service/internal/genproto/userpb"
+)
+
+// Обогащение отзывов данными из UserService и MovieService. Данные всей страницы
+// запрашиваются одним вызовом к каждому сервису, а не по вызову на отзыв.
+
+// deletedUsername подставляется вместо имени автора, которого UserService не нашел (аккаунт удален).
+const deletedUsername = "deleted user"
+
+// lookupReviewAuthors получает авторов отзывов одним вызовом BatchGetUsers.
+// Если UserService недоступен, возвращает nil: отзывы отдаются без данных авторов.
+func (h *ReviewHandler) lookupReviewAuthors(ctx context.Context, reviews []*domain.Review) map[string]*userpb.UserResponse {
+	if len(reviews) == 0 {
+		return nil
+	}
+	userIDs := make([]string, 0, len(reviews))
+	seen := make(map[string]bool, len(reviews))
+	for _, rev := range reviews {
+		if !seen[rev.UserID] {
+			seen[rev.UserID] = true
+			userIDs = append(userIDs, rev.UserID)
+		}
+	}
+
+	users, err := h.userServiceClient.BatchGetUsers(ctx, userIDs)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to get review authors via gRPC", slog.Int("count", len(userIDs)), slog.String("error", err.Error()))
+		return nil
+	}
+	return users
+}
+
+// applyReviewAuthor заполняет данные автора из результата lookupReviewAuthors.
+// Автор, отсутствующий в ответе UserService, показывается как deletedUsername.
+func applyReviewAuthor(rev *domain.Review, users map[string]*userpb.UserResponse) {
+	if users == nil {
+		return
+	}
+	userInfo, ok := users[rev.UserID]
+	if !ok {
+		rev.Username = deletedUsername
+		return
+	}
+	rev.Username = userInfo.GetUsername()
+	rev.UserDisplayName = userInfo.GetDisplayName()
+	rev.UserAvatarURL = userInfo.GetAvatarUrl()
+}
+
+// lookupReviewMovies получает фильмы отзывов одним вызовом BatchGetMovieInfo (повторяющиеся ID запрашиваются один раз).
+// Если MovieService недоступен, возвращает nil: отзывы отдаются без названий фильмов.
+func (h *ReviewHandler) lookupReviewMovies(ctx context.Context, reviews []*domain.Review) map[string]*moviepb.MovieInfo {
+	if len(reviews) == 0 {
+		return nil
+	}
+	movieIDs := make([]string, 0, len(reviews))
+	seen := make(map[string]bool, len(reviews))
+	for _, rev := range reviews {
+		if !seen[rev.MovieID] {
+			seen[rev.MovieID] = true
+			movieIDs = append(movieIDs, rev.MovieID)
+		}
+	}
+
+	movies, err := h.movieServiceClient.BatchGetMovieInfo(ctx, movieIDs)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to get review movies via gRPC", slog.Int("count", len(movieIDs)), slog.String("error", err.Error()))
+		return nil
+	}
+	return movies
+}
+
+// applyMovieInfo заполняет название фильма из результата lookupReviewMovies.
+func applyMovieInfo(rev *domain.Review, movies map[string]*moviepb.MovieInfo) {
+	if movieInfo, ok := movies[rev.MovieID]; ok {
+		rev.MovieTitle = movieInfo.GetTitle()
+	}
+}
+
+// enrichReviews дополняет отзывы данными авторов и названиями фильмов.
+func (h *ReviewHandler) enrichReviews(ctx context.Context, reviews []*domain.Review) []domain.Review {
+	authors := h.lookupReviewAuthors(ctx, reviews)
+	movies := h.lookupReviewMovies(ctx, reviews)
+
+	enrichedReviews := make([]domain.Review, 0, len(reviews))
+	for _, rev := range reviews {
+		enrichedRev := *rev
+		applyReviewAuthor(&enrichedRev, authors)
+		applyMovieInfo(&enrichedRev, movies)
+		enrichedReviews = append(enrichedReviews, enrichedRev)
+	}
+	return enrichedReviews
+}
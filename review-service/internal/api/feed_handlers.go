@@ -0,0 +1,73 @@
+// review-service/internal/api/feed_handlers.go
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"review-service/internal/domain"
+	"review-service/internal/store"
+)
+
+// Размер страницы ленты
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+)
+
+// GetFeed возвращает отзывы пользователей, на которых подписан текущий пользователь, новые первыми
+// (GET /api/reviews/feed). Параметры: limit, cursor (next_cursor или prev_cursor из предыдущего ответа).
+func (h *ReviewHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+
+	queryParams := r.URL.Query()
+	limit, _ := strconv.Atoi(queryParams.Get("limit"))
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	} else if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+	feedCursor, err := h.cursors.FromQuery(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+
+	following, err := h.userServiceClient.ListFollowing(ctx, userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list followed users via gRPC", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusServiceUnavailable, "Could not load followed users")
+		return
+	}
+
+	page, err := h.store.GetFeed(ctx, following, store.ListReviewsParams{Page: 1, PageSize: limit, Cursor: feedCursor, SkipCount: true})
+	if errors.Is(err, store.ErrInvalidCursor) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor: it was issued for a different list")
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to get review feed from store", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve feed")
+		return
+	}
+
+	response := struct {
+		Reviews    []domain.Review `json:"reviews"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+		PrevCursor string          `json:"prev_cursor,omitempty"`
+	}{
+		Reviews:    h.enrichReviews(ctx, page.Reviews),
+		NextCursor: h.cursors.Encode(page.Next),
+		PrevCursor: h.cursors.Encode(page.Prev),
+	}
+	h.logger.InfoContext(ctx, "Review feed retrieved successfully", slog.String("userID", userID), slog.Int("following", len(following)), slog.Int("count", len(response.Reviews)))
+	h.respondJSON(w, r, http.StatusOK, response)
+}
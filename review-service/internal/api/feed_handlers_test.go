@@ -0,0 +1,146 @@
+// review-service/internal/api/feed_handlers_test.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"review-service/internal/domain"
+	"review-service/internal/genproto/moviepb"
+	"review-service/internal/genproto/userpb"
+	"review-service/internal/store"
+	"shared/cursor"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// fakeUserService отдает подписки из карты following и имена всех известных пользователей.
+type fakeUserService struct {
+	following map[string][]string
+	err       error
+}
+
+func (f *fakeUserService) GetUser(ctx context.Context, userID string) (*userpb.UserResponse, error) {
+	return &userpb.UserResponse{Id: userID, Username: userID}, nil
+}
+
+func (f *fakeUserService) BatchGetUsers(ctx context.Context, userIDs []string) (map[string]*userpb.UserResponse, error) {
+	users := make(map[string]*userpb.UserResponse, len(userIDs))
+	for _, id := range userIDs {
+		users[id] = &userpb.UserResponse{Id: id, Username: id}
+	}
+	return users, nil
+}
+
+func (f *fakeUserService) ListFollowing(ctx context.Context, userID string) ([]string, error) {
+	return f.following[userID], f.err
+}
+
+// fakeMovieService считает существующим любой фильм.
+type fakeMovieService struct{}
+
+func (fakeMovieService) CheckMovieExists(ctx context.Context, movieID string) (bool, error) {
+	return true, nil
+}
+
+func (fakeMovieService) GetMovieInfo(ctx context.Context, movieID string) (*moviepb.MovieInfo, error) {
+	return &moviepb.MovieInfo{Id: movieID, Title: movieID}, nil
+}
+
+func (fakeMovieService) BatchGetMovieInfo(ctx context.Context, movieIDs []string) (map[string]*moviepb.MovieInfo, error) {
+	movies := make(map[string]*moviepb.MovieInfo, len(movieIDs))
+	for _, id := range movieIDs {
+		movies[id] = &moviepb.MovieInfo{Id: id, Title: id}
+	}
+	return movies, nil
+}
+
+type feedResponse struct {
+	Reviews    []domain.Review `json:"reviews"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// getFeed запрашивает ленту от имени userID и проходит все страницы по next_cursor.
+func getFeed(t *testing.T, h *ReviewHandler, userID string) (authors []string, code int) {
+	t.Helper()
+	query := "?limit=2"
+	for {
+		req := httptest.NewRequest(http.MethodGet, "/api/reviews/feed"+query, nil)
+		req = req.WithContext(context.WithValue(req.Context(), UserIDKey, userID))
+		rec := httptest.NewRecorder()
+		h.GetFeed(rec, req)
+		if rec.Code != http.StatusOK {
+			return authors, rec.Code
+		}
+		var page feedResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+			t.Fatalf("decode feed page: %v", err)
+		}
+		if page.Reviews == nil {
+			t.Fatalf("feed page has null reviews: %s", rec.Body.String())
+		}
+		for _, review := range page.Reviews {
+			authors = append(authors, review.Username)
+		}
+		if page.NextCursor == "" {
+			return authors, rec.Code
+		}
+		query = "?limit=2&cursor=" + page.NextCursor
+	}
+}
+
+func TestGetFeedOnlyFollowedAuthors(t *testing.T) {
+	reviewStore := store.NewMockReviewStore()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Отзывы пишут alice (3), bob (2) и сам читатель ленты (1)
+	for i, author := range []string{"alice", "bob", "alice", "reader", "bob", "alice"} {
+		review := &domain.Review{
+			ID:        fmt.Sprintf("review-%d", i),
+			MovieID:   fmt.Sprintf("movie-%d", i),
+			UserID:    author,
+			Rating:    4,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Hour),
+			UpdatedAt: createdAt.Add(time.Duration(i) * time.Hour),
+		}
+		if err := reviewStore.Create(context.Background(), review); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		following   []string
+		usersErr    error
+		wantCode    int
+		wantAuthors []string // Авторы отзывов в ленте, новые первыми
+	}{
+		{name: "one followed author", following: []string{"alice"}, wantCode: http.StatusOK, wantAuthors: []string{"alice", "alice", "alice"}},
+		{name: "two followed authors", following: []string{"alice", "bob"}, wantCode: http.StatusOK, wantAuthors: []string{"alice", "bob", "alice", "bob", "alice"}},
+		{name: "followed author without reviews", following: []string{"carol"}, wantCode: http.StatusOK},
+		{name: "follows nobody", wantCode: http.StatusOK},
+		{name: "user service unavailable", usersErr: errors.New("unavailable"), wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUserService{following: map[string][]string{"reader": tt.following}, err: tt.usersErr}
+			h := NewReviewHandler(reviewStore, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), users, fakeMovieService{}, nil, cursor.NewCodec([]byte("test-secret")), false)
+
+			authors, code := getFeed(t, h, "reader")
+			if code != tt.wantCode {
+				t.Fatalf("code = %d, want %d", code, tt.wantCode)
+			}
+			if !slices.Equal(authors, tt.wantAuthors) {
+				t.Errorf("feed authors = %v, want %v", authors, tt.wantAuthors)
+			}
+		})
+	}
+}
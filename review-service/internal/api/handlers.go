@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"review-service/internal/domain"
 	"review-service/internal/store"
-	"strconv"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -18,17 +17,24 @@ import (
 
 	"review-service/internal/genproto/moviepb"
 	"review-service/internal/genproto/userpb"
+	"shared/auth"
+	"shared/authz"
+	"shared/cursor"
+	"shared/sortspec"
 )
 
 // UserServiceClient определяет интерфейс для клиента UserService
 type UserServiceClient interface {
 	GetUser(ctx context.Context, userID string) (*userpb.UserResponse, error)
+	BatchGetUsers(ctx context.Context, userIDs []string) (map[string]*userpb.UserResponse, error)
+	ListFollowing(ctx context.Context, userID string) ([]string, error)
 }
 
 // MovieServiceClient определяет интерфейс для клиента MovieService
 type MovieServiceClient interface {
 	CheckMovieExists(ctx context.Context, movieID string) (bool, error)
 	GetMovieInfo(ctx context.Context, movieID string) (*moviepb.MovieInfo, error)
+	BatchGetMovieInfo(ctx context.Context, movieIDs []string) (map[string]*moviepb.MovieInfo, error)
 }
 
 type ReviewHandler struct {
@@ -37,16 +43,23 @@ type ReviewHandler struct {
 	validator          *validator.Validate
 	userServiceClient  UserServiceClient
 	movieServiceClient MovieServiceClient
+	authn              *auth.Middleware // Проверка токенов UserService и политики подтвержденного email
+	cursors            *cursor.Codec    // Подпись курсоров пагинации списков отзывов
 }
 
-func NewReviewHandler(s store.ReviewStore, l *slog.Logger, v *validator.Validate, usc UserServiceClient, msc MovieServiceClient) *ReviewHandler {
-	return &ReviewHandler{
+// NewReviewHandler создает новый экземпляр ReviewHandler.
+// requireVerifiedEmail запрещает публиковать и редактировать отзывы пользователям с неподтвержденным email.
+func NewReviewHandler(s store.ReviewStore, l *slog.Logger, v *validator.Validate, usc UserServiceClient, msc MovieServiceClient, tv auth.TokenValidator, cursors *cursor.Codec, requireVerifiedEmail bool) *ReviewHandler {
+	h := &ReviewHandler{
 		store:              s,
 		logger:             l,
 		validator:          v,
 		userServiceClient:  usc,
 		movieServiceClient: msc,
+		cursors:            cursors,
 	}
+	h.authn = auth.NewMiddleware(tv, h.respondError, l, requireVerifiedEmail)
+	return h
 }
 
 // --- Вспомогательные функции ---
@@ -63,13 +76,28 @@ func (h *ReviewHandler) respondError(w http.ResponseWriter, r *http.Request, sta
 	h.respondJSON(w, r, status, map[string]string{"error": message})
 }
 
+// respondSortError отвечает 400 на неверную сортировку и перечисляет разрешенные поля.
+func (h *ReviewHandler) respondSortError(w http.ResponseWriter, r *http.Request, err error) {
+	var sortErr *sortspec.Error
+	if !errors.As(err, &sortErr) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid sort: "+err.Error())
+		return
+	}
+	h.respondJSON(w, r, http.StatusBadRequest, map[string]interface{}{
+		"error":               "Invalid sort: " + sortErr.Reason,
+		"allowed_sort_fields": sortErr.Allowed,
+	})
+}
+
 // --- Обработчики ---
 func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	// Используем валидный UUID пользователя, который существует в UserService (например, pguser1)
-	// Замените на ID пользователя, которого вы создали в UserService и для которого хотите оставить отзыв.
-	// Этот ID был f5fe253c-832a-4f0c-8606-af54f29e4ca8 из ваших логов для pguser1.
-	userID := "3aeb2a43-616f-4d62-a5e4-958e0802a31e" // <--- ИЗМЕНЕНО НА ВАЛИДНЫЙ UUID
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
 	h.logger.InfoContext(ctx, "User attempting to create review", slog.String("userID", userID), slog.String("path", r.URL.Path))
 
 	var req domain.CreateReviewRequest
@@ -103,7 +131,7 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	review := &domain.Review{
 		ID:        uuid.NewString(),
 		MovieID:   req.MovieID,
-		UserID:    userID, // Теперь это валидный UUID
+		UserID:    userID, // ID автора из JWT токена
 		Rating:    req.Rating,
 		Comment:   req.Comment,
 		CreatedAt: time.Now().UTC(),
@@ -131,62 +159,25 @@ func (h *ReviewHandler) GetReviewsForMovie(w http.ResponseWriter, r *http.Reques
 	queryParams := r.URL.Query()
 	h.logger.InfoContext(ctx, "Attempting to get reviews for movie", slog.String("movieID", movieID), slog.String("query", queryParams.Encode()))
 
-	page, _ := strconv.Atoi(queryParams.Get("page"))
-	if page <= 0 {
-		page = 1
-	}
-	limit, _ := strconv.Atoi(queryParams.Get("limit"))
-	if limit <= 0 {
-		limit = 10
-	} else if limit > 50 {
-		limit = 50
+	params, includeTotal, ok := h.parseListReviewsParams(w, r, queryParams)
+	if !ok {
+		return
 	}
 
-	params := store.ListReviewsParams{
-		Page:     page,
-		PageSize: limit,
-		SortBy:   queryParams.Get("sort_by"),
+	page, err := h.store.GetReviewsByMovieID(ctx, movieID, params)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor: it was issued for a different list or sort")
+		return
 	}
-
-	reviews, totalCount, err := h.store.GetReviewsByMovieID(ctx, movieID, params)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Failed to get reviews by movieID from store", slog.String("movieID", movieID), slog.String("error", err.Error()))
 		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve reviews")
 		return
 	}
 
-	enrichedReviews := make([]domain.Review, 0, len(reviews))
-	for _, rev := range reviews {
-		enrichedRev := *rev
-		userInfo, err := h.userServiceClient.GetUser(ctx, rev.UserID)
-		if err != nil {
-			h.logger.WarnContext(ctx, "Failed to get user info via gRPC for review",
-				slog.String("userID", rev.UserID), slog.String("reviewID", rev.ID), slog.String("error", err.Error()))
-		} else if userInfo != nil {
-			enrichedRev.Username = userInfo.GetUsername()
-		}
-
-		movieInfo, movieErr := h.movieServiceClient.GetMovieInfo(ctx, rev.MovieID)
-		if movieErr != nil {
-			h.logger.WarnContext(ctx, "Failed to get movie info via gRPC for review",
-				slog.String("movieID", rev.MovieID), slog.String("reviewID", rev.ID), slog.String("error", movieErr.Error()))
-		} else if movieInfo != nil {
-			enrichedRev.MovieTitle = movieInfo.GetTitle()
-		}
-		enrichedReviews = append(enrichedReviews, enrichedRev)
-	}
-
-	response := struct {
-		Reviews    []domain.Review `json:"reviews"`
-		TotalCount int             `json:"total_count"`
-		Page       int             `json:"page"`
-		PageSize   int             `json:"page_size"`
-	}{
-		Reviews:    enrichedReviews,
-		TotalCount: totalCount,
-		Page:       params.Page,
-		PageSize:   params.PageSize,
-	}
+	response := h.paginateReviews(page, params, includeTotal)
+	enrichedReviews := h.enrichReviews(ctx, page.Reviews)
+	response.Reviews = enrichedReviews
 
 	h.logger.InfoContext(ctx, "Reviews for movie retrieved successfully", slog.String("movieID", movieID), slog.Int("count", len(enrichedReviews)))
 	h.respondJSON(w, r, http.StatusOK, response)
@@ -227,82 +218,146 @@ func (h *ReviewHandler) GetMovieAggregatedRating(w http.ResponseWriter, r *http.
 	h.respondJSON(w, r, http.StatusOK, aggRating)
 }
 
+// GetReviewsByUserID возвращает отзывы пользователя. Аккаунт не проверяется заранее: авторы
+// дополняются пакетно (enrichReviews), и отзывы удаленного пользователя отдаются с deletedUsername.
 func (h *ReviewHandler) GetReviewsByUserID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	targetUserID := vars["userId"]
 
-	targetUserInfo, err := h.userServiceClient.GetUser(ctx, targetUserID)
+	queryParams := r.URL.Query()
+	h.logger.InfoContext(ctx, "Attempting to get reviews for user", slog.String("targetUserID", targetUserID), slog.String("query", queryParams.Encode()))
+
+	params, includeTotal, ok := h.parseListReviewsParams(w, r, queryParams)
+	if !ok {
+		return
+	}
+
+	page, err := h.store.GetReviewsByUserID(ctx, targetUserID, params)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor: it was issued for a different list or sort")
+		return
+	}
 	if err != nil {
-		h.logger.WarnContext(ctx, "Failed to get target user info via gRPC for GetReviewsByUserID",
-			slog.String("targetUserID", targetUserID), slog.String("error", err.Error()))
-		h.respondError(w, r, http.StatusNotFound, "User not found or error fetching user details.")
+		h.logger.ErrorContext(ctx, "Failed to get reviews by userID from store", slog.String("targetUserID", targetUserID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user's reviews")
 		return
 	}
+	response := h.paginateReviews(page, params, includeTotal)
 
-	queryParams := r.URL.Query()
-	h.logger.InfoContext(ctx, "Attempting to get reviews for user", slog.String("targetUserID", targetUserID), slog.String("username", targetUserInfo.GetUsername()), slog.String("query", queryParams.Encode()))
+	enrichedReviews := h.enrichReviews(ctx, page.Reviews)
+	response.Reviews = enrichedReviews
 
-	page, _ := strconv.Atoi(queryParams.Get("page"))
-	if page <= 0 {
-		page = 1
+	h.logger.InfoContext(ctx, "Reviews for user retrieved successfully", slog.String("targetUserID", targetUserID), slog.Int("count", len(enrichedReviews)))
+	h.respondJSON(w, r, http.StatusOK, response)
+}
+
+// UpdateReview частично обновляет отзыв (рейтинг и/или комментарий). Разрешено только автору отзыва.
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reviewID := mux.Vars(r)["reviewId"]
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
 	}
-	limit, _ := strconv.Atoi(queryParams.Get("limit"))
-	if limit <= 0 {
-		limit = 10
-	} else if limit > 50 {
-		limit = 50
+	h.logger.InfoContext(ctx, "User attempting to update review", slog.String("userID", userID), slog.String("reviewID", reviewID))
+
+	var req domain.UpdateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode request body for review update", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
 	}
+	defer r.Body.Close()
 
-	params := store.ListReviewsParams{
-		Page:     page,
-		PageSize: limit,
-		SortBy:   queryParams.Get("sort_by"),
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.logger.ErrorContext(ctx, "Review update request validation failed", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
 	}
 
-	reviews, totalCount, err := h.store.GetReviewsByUserID(ctx, targetUserID, params)
+	review, err := h.store.GetByID(ctx, reviewID)
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to get reviews by userID from store", slog.String("targetUserID", targetUserID), slog.String("error", err.Error()))
-		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user's reviews")
+		if errors.Is(err, store.ErrReviewNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Review not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get review for update", slog.String("reviewID", reviewID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to update review")
+		}
 		return
 	}
 
-	enrichedReviews := make([]domain.Review, 0, len(reviews))
-	for _, rev := range reviews {
-		enrichedRev := *rev
-		enrichedRev.Username = targetUserInfo.GetUsername()
-
-		movieInfo, movieErr := h.movieServiceClient.GetMovieInfo(ctx, rev.MovieID)
-		if movieErr != nil {
-			h.logger.WarnContext(ctx, "Failed to get movie info via gRPC for user's review",
-				slog.String("movieID", rev.MovieID), slog.String("reviewID", rev.ID), slog.String("error", movieErr.Error()))
-		} else if movieInfo != nil {
-			enrichedRev.MovieTitle = movieInfo.GetTitle()
-		}
-		enrichedReviews = append(enrichedReviews, enrichedRev)
+	if review.UserID != userID {
+		h.logger.WarnContext(ctx, "User attempted to update another user's review", slog.String("userID", userID), slog.String("reviewID", reviewID), slog.String("authorID", review.UserID))
+		h.respondError(w, r, http.StatusForbidden, "You can only edit your own reviews")
+		return
 	}
 
-	response := struct {
-		Reviews    []domain.Review `json:"reviews"`
-		TotalCount int             `json:"total_count"`
-		Page       int             `json:"page"`
-		PageSize   int             `json:"page_size"`
-	}{
-		Reviews:    enrichedReviews,
-		TotalCount: totalCount,
-		Page:       params.Page,
-		PageSize:   params.PageSize,
+	// Обновляем только переданные поля
+	if req.Rating != nil {
+		review.Rating = *req.Rating
+	}
+	if req.Comment != nil {
+		review.Comment = *req.Comment
 	}
 
-	h.logger.InfoContext(ctx, "Reviews for user retrieved successfully", slog.String("targetUserID", targetUserID), slog.Int("count", len(enrichedReviews)))
-	h.respondJSON(w, r, http.StatusOK, response)
-}
+	if err := h.store.Update(ctx, review); err != nil {
+		if errors.Is(err, store.ErrReviewNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Review not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to update review in store", slog.String("reviewID", reviewID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to update review")
+		}
+		return
+	}
 
-func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
-	h.logger.InfoContext(r.Context(), "UpdateReview endpoint hit (TODO: implement)")
-	h.respondJSON(w, r, http.StatusNotImplemented, map[string]string{"message": "UpdateReview not implemented"})
+	h.logger.InfoContext(ctx, "Review updated successfully", slog.String("reviewID", review.ID), slog.String("userID", userID))
+	h.respondJSON(w, r, http.StatusOK, review)
 }
+
+// DeleteReview удаляет отзыв. Разрешено автору отзыва и обладателям review:delete_any.
 func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
-	h.logger.InfoContext(r.Context(), "DeleteReview endpoint hit (TODO: implement)")
-	h.respondJSON(w, r, http.StatusNotImplemented, map[string]string{"message": "DeleteReview not implemented"})
+	ctx := r.Context()
+	reviewID := mux.Vars(r)["reviewId"]
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context after AuthMiddleware")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	role, _ := ctx.Value(UserRoleKey).(string)
+	h.logger.InfoContext(ctx, "User attempting to delete review", slog.String("userID", userID), slog.String("role", role), slog.String("reviewID", reviewID))
+
+	review, err := h.store.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, store.ErrReviewNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Review not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get review for delete", slog.String("reviewID", reviewID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete review")
+		}
+		return
+	}
+
+	if review.UserID != userID && !authz.Has(ctx, authz.PermReviewDeleteAny) {
+		h.logger.WarnContext(ctx, "User attempted to delete another user's review", slog.String("userID", userID), slog.String("reviewID", reviewID), slog.String("authorID", review.UserID))
+		h.respondError(w, r, http.StatusForbidden, "You can only delete your own reviews")
+		return
+	}
+
+	// Передаем ID автора отзыва: для администратора это может быть не его собственный ID
+	if err := h.store.Delete(ctx, reviewID, review.UserID); err != nil {
+		if errors.Is(err, store.ErrReviewNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Review not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to delete review in store", slog.String("reviewID", reviewID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete review")
+		}
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Review deleted successfully", slog.String("reviewID", reviewID), slog.String("deletedBy", userID))
+	w.WriteHeader(http.StatusNoContent)
 }
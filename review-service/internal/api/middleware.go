@@ -0,0 +1,10 @@
+// review-service/internal/api/middleware.go
+package api
+
+import "shared/auth"
+
+// Ключи контекста, которые заполняет auth.Middleware.
+const (
+	UserIDKey   = auth.UserIDKey
+	UserRoleKey = auth.UserRoleKey
+)
@@ -0,0 +1,85 @@
+// review-service/internal/api/review_pagination.go
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"review-service/internal/domain"
+	"review-service/internal/store"
+	"shared/cursor"
+	"shared/sortspec"
+)
+
+// Пагинация списков отзывов (GET /api/reviews/movie/{movieId}, GET /api/reviews/user/{userId}):
+//   - page, limit - по номеру страницы (прежний режим);
+//   - cursor - next_cursor или prev_cursor из предыдущего ответа; заменяет page и не требует OFFSET;
+//   - include_total=false - не считать total_count.
+//
+// Курсор действителен только для того списка и той сортировки (sort), с которыми он получен.
+
+// reviewPage - страница отзывов в ответе.
+type reviewPage struct {
+	Reviews    []domain.Review `json:"reviews"`
+	TotalCount *int            `json:"total_count,omitempty"` // Нет при include_total=false
+	Page       int             `json:"page,omitempty"`        // Только при пагинации по номеру страницы
+	PageSize   int             `json:"page_size"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	PrevCursor string          `json:"prev_cursor,omitempty"`
+}
+
+// parseListReviewsParams разбирает параметры пагинации и сортировки списка отзывов.
+// При неверных параметрах отвечает 400 и возвращает ok == false.
+func (h *ReviewHandler) parseListReviewsParams(w http.ResponseWriter, r *http.Request, queryParams url.Values) (params store.ListReviewsParams, includeTotal bool, ok bool) {
+	page, _ := strconv.Atoi(queryParams.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(queryParams.Get("limit"))
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 50 {
+		limit = 50
+	}
+
+	sortSpec, err := sortspec.FromQuery(queryParams, store.ReviewSortFields)
+	if err != nil {
+		h.respondSortError(w, r, err)
+		return params, false, false
+	}
+	pageCursor, err := h.cursors.FromQuery(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid cursor")
+		return params, false, false
+	}
+	includeTotal, err = cursor.IncludeTotal(queryParams)
+	if err != nil {
+		h.respondError(w, r, http.StatusBadRequest, err.Error())
+		return params, false, false
+	}
+	return store.ListReviewsParams{
+		Page:      page,
+		PageSize:  limit,
+		Sort:      sortSpec,
+		Cursor:    pageCursor,
+		SkipCount: !includeTotal,
+	}, includeTotal, true
+}
+
+// paginateReviews заполняет поля пагинации ответа по странице из хранилища
+// (кроме Reviews: отзывы еще нужно дополнить данными пользователей и фильмов).
+func (h *ReviewHandler) paginateReviews(page *store.ReviewPage, params store.ListReviewsParams, includeTotal bool) reviewPage {
+	response := reviewPage{
+		PageSize:   params.PageSize,
+		NextCursor: h.cursors.Encode(page.Next),
+		PrevCursor: h.cursors.Encode(page.Prev),
+	}
+	if includeTotal {
+		response.TotalCount = &page.TotalCount
+	}
+	if params.Cursor == nil {
+		response.Page = params.Page
+	}
+	return response
+}
@@ -15,22 +15,21 @@ func NewReviewRouter(handler *ReviewHandler) *mux.Router {
 
 	// Маршруты для отзывов, с префиксом /api/reviews
 	reviewsRouter := apiRouter.PathPrefix("/reviews").Subrouter()
-	reviewsRouter.HandleFunc("", handler.CreateReview).Methods(http.MethodPost)                      // POST /api/reviews - Создать отзыв
-	reviewsRouter.HandleFunc("/movie/{movieId}", handler.GetReviewsForMovie).Methods(http.MethodGet) // GET /api/reviews/movie/{movieId} - Получить отзывы для фильма
-	reviewsRouter.HandleFunc("/user/{userId}", handler.GetReviewsByUserID).Methods(http.MethodGet)   // GET /api/reviews/user/{userId} - Получить отзывы пользователя (TODO: implement handler)
-	reviewsRouter.HandleFunc("/{reviewId}", handler.UpdateReview).Methods(http.MethodPut)            // PUT /api/reviews/{reviewId} - Обновить отзыв (TODO: implement handler)
-	reviewsRouter.HandleFunc("/{reviewId}", handler.DeleteReview).Methods(http.MethodDelete)         // DELETE /api/reviews/{reviewId} - Удалить отзыв (TODO: implement handler)
+	reviewsRouter.Handle("", handler.authn.RequireVerifiedAuth(handler.CreateReview)).Methods(http.MethodPost)           // POST /api/reviews - Создать отзыв (требует JWT и подтвержденный email)
+	reviewsRouter.Handle("/feed", handler.authn.RequireAuth(handler.GetFeed)).Methods(http.MethodGet)                    // GET /api/reviews/feed - Лента отзывов от тех, на кого подписан пользователь
+	reviewsRouter.HandleFunc("/movie/{movieId}", handler.GetReviewsForMovie).Methods(http.MethodGet)                     // GET /api/reviews/movie/{movieId} - Получить отзывы для фильма
+	reviewsRouter.HandleFunc("/user/{userId}", handler.GetReviewsByUserID).Methods(http.MethodGet)                       // GET /api/reviews/user/{userId} - Получить отзывы пользователя
+	reviewsRouter.Handle("/{reviewId}", handler.authn.RequireVerifiedAuth(handler.UpdateReview)).Methods(http.MethodPut) // PUT /api/reviews/{reviewId} - Обновить отзыв (только автор, требует подтвержденный email)
+	reviewsRouter.Handle("/{reviewId}", handler.authn.RequireAuth(handler.DeleteReview)).Methods(http.MethodDelete)      // DELETE /api/reviews/{reviewId} - Удалить отзыв (автор или review:delete_any)
 
 	// Маршрут для получения агрегированного рейтинга фильма.
 	// Этот эндпоинт логически связан с отзывами, поэтому может быть здесь.
 	// Альтернативно, MovieService мог бы делать gRPC вызов к ReviewService для получения этих данных.
-	apiRouter.HandleFunc("/movies/{movieId}/rating", handler.GetMovieAggregatedRating).Methods(http.MethodGet) // GET /api/movies/{movieId}/rating (TODO: implement handler)
+	apiRouter.HandleFunc("/movies/{movieId}/rating", handler.GetMovieAggregatedRating).Methods(http.MethodGet) // GET /api/movies/{movieId}/rating
 
-	// TODO: В будущем здесь можно будет добавить middleware для аутентификации, логирования запросов и т.д.
-	// Например:
-	// loggedRouter := LoggingMiddleware(router)
-	// authRouter := AuthenticationMiddleware(loggedRouter) // или применять middleware к конкретным саб-роутерам
-	// return authRouter
+	// Операции записи (создание, обновление, удаление) требуют JWT от UserService,
+	// чтение отзывов и рейтингов остается публичным.
+	// TODO: В будущем здесь можно будет добавить middleware для логирования запросов и т.д.
 
 	return router
 }
@@ -26,6 +26,8 @@ import (
 type MovieServiceClient interface {
 	CheckMovieExists(ctx context.Context, movieID string) (bool, error)
 	GetMovieInfo(ctx context.Context, movieID string) (*moviepb.MovieInfo, error)
+	// BatchGetMovieInfo возвращает найденные фильмы по ID; удаленных и несуществующих в карте нет.
+	BatchGetMovieInfo(ctx context.Context, movieIDs []string) (map[string]*moviepb.MovieInfo, error)
 	Close() error // Добавляем метод для закрытия соединения
 }
 
@@ -131,6 +133,28 @@ func (c *movieServiceGRPCClient) GetMovieInfo(ctx context.Context, movieID strin
 	return res.GetMovieInfo(), nil
 }
 
+// batchGetMovieInfoSize - максимальное количество ID в одном вызове BatchGetMovieInfo (ограничение MovieService).
+const batchGetMovieInfoSize = 500
+
+// BatchGetMovieInfo вызывает gRPC метод BatchGetMovieInfo на MovieService, разбивая длинный список на части.
+func (c *movieServiceGRPCClient) BatchGetMovieInfo(ctx context.Context, movieIDs []string) (map[string]*moviepb.MovieInfo, error) {
+	movies := make(map[string]*moviepb.MovieInfo, len(movieIDs))
+	for start := 0; start < len(movieIDs); start += batchGetMovieInfoSize {
+		end := min(start+batchGetMovieInfoSize, len(movieIDs))
+		callCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		res, err := c.client.BatchGetMovieInfo(callCtx, &moviepb.BatchGetMovieInfoRequest{MovieIds: movieIDs[start:end]})
+		cancel()
+		if err != nil {
+			c.logger.ErrorContext(ctx, "MovieService.BatchGetMovieInfo gRPC call failed", slog.Int("count", end-start), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("grpc BatchGetMovieInfo failed for %d movie(s): %w", end-start, err)
+		}
+		for _, movie := range res.GetMovies() {
+			movies[movie.GetId()] = movie
+		}
+	}
+	return movies, nil
+}
+
 // Close закрывает gRPC соединение.
 func (c *movieServiceGRPCClient) Close() error {
 	if c.conn != nil {
@@ -31,6 +31,12 @@ import (
 // и возвращать конкретные типы userpb.
 type UserServiceClient interface {
 	GetUser(ctx context.Context, userID string) (*userpb.UserResponse, error)
+	// BatchGetUsers возвращает найденных пользователей по ID; отсутствующих в карте нет.
+	BatchGetUsers(ctx context.Context, userIDs []string) (map[string]*userpb.UserResponse, error)
+	// ListUserEvents возвращает события пользователей (удаления аккаунтов) после afterID.
+	ListUserEvents(ctx context.Context, afterID int64, limit int32) ([]*userpb.UserEvent, error)
+	// ListFollowing возвращает ID всех пользователей, на которых подписан userID.
+	ListFollowing(ctx context.Context, userID string) ([]string, error)
 }
 
 // userServiceGRPCClient реализует UserServiceClient с использованием gRPC.
@@ -110,6 +116,62 @@ func (c *userServiceGRPCClient) GetUser(ctx context.Context, userID string) (*us
 	return res, nil
 }
 
+// batchGetUsersSize - максимальное количество ID в одном вызове BatchGetUsers (ограничение UserService).
+const batchGetUsersSize = 500
+
+// BatchGetUsers вызывает gRPC метод BatchGetUsers на UserService, разбивая длинный список на части.
+func (c *userServiceGRPCClient) BatchGetUsers(ctx context.Context, userIDs []string) (map[string]*userpb.UserResponse, error) {
+	users := make(map[string]*userpb.UserResponse, len(userIDs))
+	for start := 0; start < len(userIDs); start += batchGetUsersSize {
+		end := min(start+batchGetUsersSize, len(userIDs))
+		callCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		res, err := c.client.BatchGetUsers(callCtx, &userpb.BatchGetUsersRequest{UserIds: userIDs[start:end]})
+		cancel()
+		if err != nil {
+			c.logger.ErrorContext(ctx, "UserService.BatchGetUsers gRPC call failed", slog.Int("count", end-start), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("grpc BatchGetUsers failed for %d user(s): %w", end-start, err)
+		}
+		for _, user := range res.GetUsers() {
+			users[user.GetId()] = user
+		}
+	}
+	return users, nil
+}
+
+// ListUserEvents вызывает gRPC метод ListUserEvents на UserService.
+func (c *userServiceGRPCClient) ListUserEvents(ctx context.Context, afterID int64, limit int32) ([]*userpb.UserEvent, error) {
+	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := c.client.ListUserEvents(callCtx, &userpb.ListUserEventsRequest{AfterId: afterID, Limit: limit})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "UserService.ListUserEvents gRPC call failed", slog.Int64("after_id", afterID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("grpc ListUserEvents failed after event %d: %w", afterID, err)
+	}
+	return res.GetEvents(), nil
+}
+
+// followingPageSize - размер страницы при постраничном чтении подписок (максимум UserService).
+const followingPageSize = 500
+
+// ListFollowing вызывает gRPC метод ListFollowing на UserService и читает все страницы подписок.
+func (c *userServiceGRPCClient) ListFollowing(ctx context.Context, userID string) ([]string, error) {
+	var userIDs []string
+	for page := int32(1); ; page++ {
+		callCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		res, err := c.client.ListFollowing(callCtx, &userpb.ListFollowingRequest{UserId: userID, Page: page, PageSize: followingPageSize})
+		cancel()
+		if err != nil {
+			c.logger.ErrorContext(ctx, "UserService.ListFollowing gRPC call failed", slog.String("user_id", userID), slog.Int("page", int(page)), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("grpc ListFollowing failed for userID %s: %w", userID, err)
+		}
+		userIDs = append(userIDs, res.GetUserIds()...)
+		if len(res.GetUserIds()) < followingPageSize || len(userIDs) >= int(res.GetTotalCount()) {
+			return userIDs, nil
+		}
+	}
+}
+
 // Close закрывает gRPC соединение.
 // Этот метод можно добавить, чтобы корректно закрывать соединение при завершении работы сервиса.
 func (c *userServiceGRPCClient) Close() error {
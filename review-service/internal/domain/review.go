@@ -7,15 +7,18 @@ import (
 
 // Review представляет модель отзыва/оценки
 type Review struct {
-	ID         string    `json:"id" db:"id"`                     // UUID
-	MovieID    string    `json:"movie_id" db:"movie_id"`         // Внешний ключ к MovieService
-	UserID     string    `json:"user_id" db:"user_id"`           // Внешний ключ к UserService
-	Rating     int32     `json:"rating" db:"rating"`             // Оценка (например, 1-10)
-	Comment    string    `json:"comment,omitempty" db:"comment"` // Текстовый комментарий (может быть пустым)
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
-	Username   string    `json:"username,omitempty"`    // Не хранится в БД reviews, подтягивается
-	MovieTitle string    `json:"movie_title,omitempty"` // Не хранится в БД reviews, подтягивается
+	ID        string    `json:"id" db:"id"`                     // UUID
+	MovieID   string    `json:"movie_id" db:"movie_id"`         // Внешний ключ к MovieService
+	UserID    string    `json:"user_id" db:"user_id"`           // Внешний ключ к UserService
+	Rating    int32     `json:"rating" db:"rating"`             // Оценка (например, 1-10)
+	Comment   string    `json:"comment,omitempty" db:"comment"` // Текстовый комментарий (может быть пустым)
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Username  string    `json:"username,omitempty"` // Не хранится в БД reviews, подтягивается
+	// Профиль автора из UserService (не хранится в БД reviews)
+	UserDisplayName string `json:"user_display_name,omitempty"`
+	UserAvatarURL   string `json:"user_avatar_url,omitempty"`
+	MovieTitle      string `json:"movie_title,omitempty"` // Не хранится в БД reviews, подтягивается
 }
 
 // CreateReviewRequest определяет тело запроса для создания нового отзыва.
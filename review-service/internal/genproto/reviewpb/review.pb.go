@@ -0,0 +1,485 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: proto/reviewpb/review.proto
+
+package reviewpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Отзыв пользователя, передаваемый между сервисами
+type Review struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MovieId       string                 `protobuf:"bytes,2,opt,name=movie_id,json=movieId,proto3" json:"movie_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Review) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Review) GetMovieId() string {
+	if x != nil {
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Review) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Review) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Review) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос отзывов пользователя, постранично
+type ListReviewsByUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // С 1, по умолчанию 1
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // По умолчанию 100, максимум 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsByUserRequest) Reset() {
+	*x = ListReviewsByUserRequest{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsByUserRequest) ProtoMessage() {}
+
+func (x *ListReviewsByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsByUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListReviewsByUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReviewsByUserRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReviewsByUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListReviewsByUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsByUserResponse) Reset() {
+	*x = ListReviewsByUserResponse{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsByUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsByUserResponse) ProtoMessage() {}
+
+func (x *ListReviewsByUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsByUserResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsByUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListReviewsByUserResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListReviewsByUserResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Средняя оценка и число отзывов фильма
+type MovieRating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovieId       string                 `protobuf:"bytes,1,opt,name=movie_id,json=movieId,proto3" json:"movie_id,omitempty"`
+	AverageRating float64                `protobuf:"fixed64,2,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	ReviewCount   int64                  `protobuf:"varint,3,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieRating) Reset() {
+	*x = MovieRating{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieRating) ProtoMessage() {}
+
+func (x *MovieRating) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieRating.ProtoReflect.Descriptor instead.
+func (*MovieRating) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MovieRating) GetMovieId() string {
+	if x != nil {
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *MovieRating) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *MovieRating) GetReviewCount() int64 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+// Запрос оценок всех фильмов с отзывами, постранично по movie_id
+type ListMovieRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterMovieId  string                 `protobuf:"bytes,1,opt,name=after_movie_id,json=afterMovieId,proto3" json:"after_movie_id,omitempty"` // Пусто - с начала
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                    // По умолчанию 100, максимум 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovieRatingsRequest) Reset() {
+	*x = ListMovieRatingsRequest{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovieRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovieRatingsRequest) ProtoMessage() {}
+
+func (x *ListMovieRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovieRatingsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovieRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMovieRatingsRequest) GetAfterMovieId() string {
+	if x != nil {
+		return x.AfterMovieId
+	}
+	return ""
+}
+
+func (x *ListMovieRatingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListMovieRatingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ratings       []*MovieRating         `protobuf:"bytes,1,rep,name=ratings,proto3" json:"ratings,omitempty"` // По возрастанию movie_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovieRatingsResponse) Reset() {
+	*x = ListMovieRatingsResponse{}
+	mi := &file_proto_reviewpb_review_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovieRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovieRatingsResponse) ProtoMessage() {}
+
+func (x *ListMovieRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reviewpb_review_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovieRatingsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovieRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reviewpb_review_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMovieRatingsResponse) GetRatings() []*MovieRating {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+var File_proto_reviewpb_review_proto protoreflect.FileDescriptor
+
+const file_proto_reviewpb_review_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/reviewpb/review.proto\x12\x06review\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x01\n" +
+	"\x06Review\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bmovie_id\x18\x02 \x01(\tR\amovieId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"d\n" +
+	"\x18ListReviewsByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"f\n" +
+	"\x19ListReviewsByUserResponse\x12(\n" +
+	"\areviews\x18\x01 \x03(\v2\x0e.review.ReviewR\areviews\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"r\n" +
+	"\vMovieRating\x12\x19\n" +
+	"\bmovie_id\x18\x01 \x01(\tR\amovieId\x12%\n" +
+	"\x0eaverage_rating\x18\x02 \x01(\x01R\raverageRating\x12!\n" +
+	"\freview_count\x18\x03 \x01(\x03R\vreviewCount\"U\n" +
+	"\x17ListMovieRatingsRequest\x12$\n" +
+	"\x0eafter_movie_id\x18\x01 \x01(\tR\fafterMovieId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"I\n" +
+	"\x18ListMovieRatingsResponse\x12-\n" +
+	"\aratings\x18\x01 \x03(\v2\x13.review.MovieRatingR\aratings2\xc5\x01\n" +
+	"\x12ReviewInterService\x12X\n" +
+	"\x11ListReviewsByUser\x12 .review.ListReviewsByUserRequest\x1a!.review.ListReviewsByUserResponse\x12U\n" +
+	"\x10ListMovieRatings\x12\x1f.review.ListMovieRatingsRequest\x1a .review.ListMovieRatingsResponseB+Z)review-service/internal/genproto/reviewpbb\x06proto3"
+
+var (
+	file_proto_reviewpb_review_proto_rawDescOnce sync.Once
+	file_proto_reviewpb_review_proto_rawDescData []byte
+)
+
+func file_proto_reviewpb_review_proto_rawDescGZIP() []byte {
+	file_proto_reviewpb_review_proto_rawDescOnce.Do(func() {
+		file_proto_reviewpb_review_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_reviewpb_review_proto_rawDesc), len(file_proto_reviewpb_review_proto_rawDesc)))
+	})
+	return file_proto_reviewpb_review_proto_rawDescData
+}
+
+var file_proto_reviewpb_review_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_reviewpb_review_proto_goTypes = []any{
+	(*Review)(nil),                    // 0: review.Review
+	(*ListReviewsByUserRequest)(nil),  // 1: review.ListReviewsByUserRequest
+	(*ListReviewsByUserResponse)(nil), // 2: review.ListReviewsByUserResponse
+	(*MovieRating)(nil),               // 3: review.MovieRating
+	(*ListMovieRatingsRequest)(nil),   // 4: review.ListMovieRatingsRequest
+	(*ListMovieRatingsResponse)(nil),  // 5: review.ListMovieRatingsResponse
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+}
+var file_proto_reviewpb_review_proto_depIdxs = []int32{
+	6, // 0: review.Review.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: review.Review.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: review.ListReviewsByUserResponse.reviews:type_name -> review.Review
+	3, // 3: review.ListMovieRatingsResponse.ratings:type_name -> review.MovieRating
+	1, // 4: review.ReviewInterService.ListReviewsByUser:input_type -> review.ListReviewsByUserRequest
+	4, // 5: review.ReviewInterService.ListMovieRatings:input_type -> review.ListMovieRatingsRequest
+	2, // 6: review.ReviewInterService.ListReviewsByUser:output_type -> review.ListReviewsByUserResponse
+	5, // 7: review.ReviewInterService.ListMovieRatings:output_type -> review.ListMovieRatingsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_reviewpb_review_proto_init() }
+func file_proto_reviewpb_review_proto_init() {
+	if File_proto_reviewpb_review_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_reviewpb_review_proto_rawDesc), len(file_proto_reviewpb_review_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_reviewpb_review_proto_goTypes,
+		DependencyIndexes: file_proto_reviewpb_review_proto_depIdxs,
+		MessageInfos:      file_proto_reviewpb_review_proto_msgTypes,
+	}.Build()
+	File_proto_reviewpb_review_proto = out.File
+	file_proto_reviewpb_review_proto_goTypes = nil
+	file_proto_reviewpb_review_proto_depIdxs = nil
+}
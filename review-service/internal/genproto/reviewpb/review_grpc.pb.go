@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: proto/reviewpb/review.proto
+
+package reviewpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReviewInterService_ListReviewsByUser_FullMethodName = "/review.ReviewInterService/ListReviewsByUser"
+	ReviewInterService_ListMovieRatings_FullMethodName  = "/review.ReviewInterService/ListMovieRatings"
+)
+
+// ReviewInterServiceClient is the client API for ReviewInterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Сервис для межсервисного взаимодействия ReviewService
+type ReviewInterServiceClient interface {
+	// Возвращает все отзывы пользователя (выгрузка персональных данных)
+	ListReviewsByUser(ctx context.Context, in *ListReviewsByUserRequest, opts ...grpc.CallOption) (*ListReviewsByUserResponse, error)
+	// Возвращает оценки фильмов (MovieService копирует их для сортировки списка фильмов)
+	ListMovieRatings(ctx context.Context, in *ListMovieRatingsRequest, opts ...grpc.CallOption) (*ListMovieRatingsResponse, error)
+}
+
+type reviewInterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReviewInterServiceClient(cc grpc.ClientConnInterface) ReviewInterServiceClient {
+	return &reviewInterServiceClient{cc}
+}
+
+func (c *reviewInterServiceClient) ListReviewsByUser(ctx context.Context, in *ListReviewsByUserRequest, opts ...grpc.CallOption) (*ListReviewsByUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewsByUserResponse)
+	err := c.cc.Invoke(ctx, ReviewInterService_ListReviewsByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewInterServiceClient) ListMovieRatings(ctx context.Context, in *ListMovieRatingsRequest, opts ...grpc.CallOption) (*ListMovieRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovieRatingsResponse)
+	err := c.cc.Invoke(ctx, ReviewInterService_ListMovieRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReviewInterServiceServer is the server API for ReviewInterService service.
+// All implementations must embed UnimplementedReviewInterServiceServer
+// for forward compatibility.
+//
+// Сервис для межсервисного взаимодействия ReviewService
+type ReviewInterServiceServer interface {
+	// Возвращает все отзывы пользователя (выгрузка персональных данных)
+	ListReviewsByUser(context.Context, *ListReviewsByUserRequest) (*ListReviewsByUserResponse, error)
+	// Возвращает оценки фильмов (MovieService копирует их для сортировки списка фильмов)
+	ListMovieRatings(context.Context, *ListMovieRatingsRequest) (*ListMovieRatingsResponse, error)
+	mustEmbedUnimplementedReviewInterServiceServer()
+}
+
+// UnimplementedReviewInterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReviewInterServiceServer struct{}
+
+func (UnimplementedReviewInterServiceServer) ListReviewsByUser(context.Context, *ListReviewsByUserRequest) (*ListReviewsByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviewsByUser not implemented")
+}
+func (UnimplementedReviewInterServiceServer) ListMovieRatings(context.Context, *ListMovieRatingsRequest) (*ListMovieRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovieRatings not implemented")
+}
+func (UnimplementedReviewInterServiceServer) mustEmbedUnimplementedReviewInterServiceServer() {}
+func (UnimplementedReviewInterServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeReviewInterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReviewInterServiceServer will
+// result in compilation errors.
+type UnsafeReviewInterServiceServer interface {
+	mustEmbedUnimplementedReviewInterServiceServer()
+}
+
+func RegisterReviewInterServiceServer(s grpc.ServiceRegistrar, srv ReviewInterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReviewInterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReviewInterService_ServiceDesc, srv)
+}
+
+func _ReviewInterService_ListReviewsByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewInterServiceServer).ListReviewsByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewInterService_ListReviewsByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewInterServiceServer).ListReviewsByUser(ctx, req.(*ListReviewsByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewInterService_ListMovieRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovieRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewInterServiceServer).ListMovieRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewInterService_ListMovieRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewInterServiceServer).ListMovieRatings(ctx, req.(*ListMovieRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReviewInterService_ServiceDesc is the grpc.ServiceDesc for ReviewInterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReviewInterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "review.ReviewInterService",
+	HandlerType: (*ReviewInterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReviewsByUser",
+			Handler:    _ReviewInterService_ListReviewsByUser_Handler,
+		},
+		{
+			MethodName: "ListMovieRatings",
+			Handler:    _ReviewInterService_ListMovieRatings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/reviewpb/review.proto",
+}
@@ -0,0 +1,118 @@
+// review-service/internal/grpc/server.go
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"review-service/internal/domain"
+	"review-service/internal/genproto/reviewpb" // Сгенерированный gRPC код
+	"review-service/internal/store"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultReviewsPageSize и maxReviewsPageSize ограничивают страницу ListReviewsByUser и ListMovieRatings.
+	defaultReviewsPageSize = 100
+	maxReviewsPageSize     = 500
+)
+
+// Server реализует интерфейс reviewpb.ReviewInterServiceServer
+type Server struct {
+	reviewpb.UnimplementedReviewInterServiceServer                   // Обязательно для прямой совместимости
+	store                                          store.ReviewStore // Зависимость от хранилища отзывов
+	logger                                         *slog.Logger
+}
+
+// NewServer создает новый экземпляр gRPC сервера для ReviewService.
+func NewServer(reviewStore store.ReviewStore, logger *slog.Logger) *Server {
+	return &Server{
+		store:  reviewStore,
+		logger: logger,
+	}
+}
+
+// domainReviewToProto преобразует доменную модель отзыва в protobuf сообщение
+func domainReviewToProto(review *domain.Review) *reviewpb.Review {
+	return &reviewpb.Review{
+		Id:        review.ID,
+		MovieId:   review.MovieID,
+		UserId:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		CreatedAt: timestamppb.New(review.CreatedAt),
+		UpdatedAt: timestamppb.New(review.UpdatedAt),
+	}
+}
+
+// ListReviewsByUser реализует gRPC метод ListReviewsByUser.
+func (s *Server) ListReviewsByUser(ctx context.Context, req *reviewpb.ListReviewsByUserRequest) (*reviewpb.ListReviewsByUserResponse, error) {
+	s.logger.InfoContext(ctx, "gRPC ListReviewsByUser called", slog.String("user_id", req.GetUserId()), slog.Int("page", int(req.GetPage())))
+
+	if req.GetUserId() == "" {
+		s.logger.WarnContext(ctx, "gRPC ListReviewsByUser called with empty user_id")
+		return nil, status.Errorf(codes.InvalidArgument, "user_id cannot be empty")
+	}
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultReviewsPageSize
+	}
+	if pageSize > maxReviewsPageSize {
+		pageSize = maxReviewsPageSize
+	}
+
+	reviewPage, err := s.store.GetReviewsByUserID(ctx, req.GetUserId(), store.ListReviewsParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list reviews by user from store", slog.String("user_id", req.GetUserId()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to list reviews by user: %v", err)
+	}
+
+	res := &reviewpb.ListReviewsByUserResponse{
+		Reviews:    make([]*reviewpb.Review, 0, len(reviewPage.Reviews)),
+		TotalCount: int32(reviewPage.TotalCount),
+	}
+	for _, review := range reviewPage.Reviews {
+		res.Reviews = append(res.Reviews, domainReviewToProto(review))
+	}
+	return res, nil
+}
+
+// ListMovieRatings реализует gRPC метод ListMovieRatings.
+func (s *Server) ListMovieRatings(ctx context.Context, req *reviewpb.ListMovieRatingsRequest) (*reviewpb.ListMovieRatingsResponse, error) {
+	if req.GetAfterMovieId() != "" {
+		if _, err := uuid.Parse(req.GetAfterMovieId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "after_movie_id must be a UUID")
+		}
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultReviewsPageSize
+	}
+	if limit > maxReviewsPageSize {
+		limit = maxReviewsPageSize
+	}
+
+	ratings, err := s.store.ListMovieRatings(ctx, req.GetAfterMovieId(), limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list movie ratings from store", slog.String("after_movie_id", req.GetAfterMovieId()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to list movie ratings: %v", err)
+	}
+
+	res := &reviewpb.ListMovieRatingsResponse{Ratings: make([]*reviewpb.MovieRating, 0, len(ratings))}
+	for _, rating := range ratings {
+		res.Ratings = append(res.Ratings, &reviewpb.MovieRating{
+			MovieId:       rating.MovieID,
+			AverageRating: rating.AverageRating,
+			ReviewCount:   rating.RatingCount,
+		})
+	}
+	return res, nil
+}
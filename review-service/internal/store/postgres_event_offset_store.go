@@ -0,0 +1,38 @@
+// review-service/internal/store/postgres_event_offset_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// GetEventOffset возвращает позицию потребителя из таблицы consumer_offsets.
+func (s *PostgresReviewStore) GetEventOffset(ctx context.Context, consumer string) (int64, error) {
+	query := `SELECT last_event_id FROM consumer_offsets WHERE consumer = $1`
+	var offset int64
+	err := s.db.GetContext(ctx, &offset, query, consumer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		s.logger.ErrorContext(ctx, "Failed to get event offset from DB", slog.String("consumer", consumer), slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to get event offset: %w", err)
+	}
+	return offset, nil
+}
+
+// SaveEventOffset сохраняет позицию потребителя (upsert).
+func (s *PostgresReviewStore) SaveEventOffset(ctx context.Context, consumer string, offset int64) error {
+	query := `INSERT INTO consumer_offsets (consumer, last_event_id, updated_at)
+              VALUES ($1, $2, $3)
+              ON CONFLICT (consumer) DO UPDATE SET last_event_id = EXCLUDED.last_event_id, updated_at = EXCLUDED.updated_at`
+	if _, err := s.db.ExecContext(ctx, query, consumer, offset, time.Now().UTC()); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save event offset in DB", slog.String("consumer", consumer), slog.Int64("offset", offset), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to save event offset: %w", err)
+	}
+	return nil
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"log/slog"
 	"review-service/internal/domain"
+	"slices"
 	"time"
 
+	"shared/cursor"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq" // Для обработки ошибок PostgreSQL
 )
@@ -86,81 +89,112 @@ func (s *PostgresReviewStore) GetByID(ctx context.Context, reviewID string) (*do
 }
 
 // GetReviewsByMovieID получает все отзывы для указанного фильма.
-func (s *PostgresReviewStore) GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) ([]*domain.Review, int, error) {
-	var reviews []*domain.Review
-	var totalCount int
-
-	countQuery := `SELECT COUNT(*) FROM reviews WHERE movie_id = $1`
-	selectQuery := `SELECT id, movie_id, user_id, rating, comment, created_at, updated_at 
-                    FROM reviews WHERE movie_id = $1`
-
-	s.logger.DebugContext(ctx, "Executing GetReviewsByMovieID count query", slog.String("movieID", movieID))
-	err := s.db.GetContext(ctx, &totalCount, countQuery, movieID)
+func (s *PostgresReviewStore) GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) (*ReviewPage, error) {
+	page, err := s.listReviews(ctx, reviewListFilter{where: "movie_id = $1", arg: movieID, hash: movieReviewsFilter(movieID), logAttr: slog.String("movieID", movieID)}, params)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to count reviews by movieID in DB", slog.String("movieID", movieID), slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to count reviews by movieID: %w", err)
+		return nil, fmt.Errorf("failed to list reviews by movieID: %w", err)
 	}
+	return page, nil
+}
 
-	if totalCount == 0 {
-		return []*domain.Review{}, 0, nil
+// GetReviewsByUserID получает все отзывы, оставленные пользователем.
+func (s *PostgresReviewStore) GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) (*ReviewPage, error) {
+	page, err := s.listReviews(ctx, reviewListFilter{where: "user_id = $1", arg: userID, hash: userReviewsFilter(userID), logAttr: slog.String("userID", userID)}, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews by userID: %w", err)
 	}
+	return page, nil
+}
 
-	orderBy := "created_at DESC"
-	if params.SortBy != "" {
-		if params.SortBy == "rating_desc" {
-			orderBy = "rating DESC, created_at DESC"
-		} else if params.SortBy == "rating_asc" {
-			orderBy = "rating ASC, created_at DESC"
+// GetFeed возвращает страницу ленты отзывов авторов userIDs (keyset-пагинация в порядке по умолчанию).
+func (s *PostgresReviewStore) GetFeed(ctx context.Context, userIDs []string, params ListReviewsParams) (*ReviewPage, error) {
+	params.Sort = nil // Лента всегда упорядочена по умолчанию: новые отзывы первыми
+	if len(userIDs) == 0 {
+		if err := checkReviewCursor(params.Cursor, reviewCursorScope(nil, feedFilter), reviewKeySpec(nil)); err != nil {
+			return nil, err
 		}
+		return &ReviewPage{Reviews: []*domain.Review{}}, nil
 	}
-	selectQuery += " ORDER BY " + orderBy
-	selectQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, (params.Page-1)*params.PageSize)
-
-	s.logger.DebugContext(ctx, "Executing GetReviewsByMovieID select query", slog.String("movieID", movieID), slog.String("query", selectQuery))
-	err = s.db.SelectContext(ctx, &reviews, selectQuery, movieID)
+	page, err := s.listReviews(ctx, reviewListFilter{where: "user_id = ANY($1::uuid[])", arg: pq.Array(userIDs), hash: feedFilter, logAttr: slog.Int("authors", len(userIDs))}, params)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to list reviews by movieID from DB", slog.String("movieID", movieID), slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to list reviews by movieID: %w", err)
+		return nil, fmt.Errorf("failed to get review feed: %w", err)
 	}
-	return reviews, totalCount, nil
+	return page, nil
 }
 
-// GetReviewsByUserID получает все отзывы, оставленные пользователем.
-func (s *PostgresReviewStore) GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) ([]*domain.Review, int, error) {
-	var reviews []*domain.Review
-	var totalCount int
+// reviewListFilter - условие списка отзывов с единственным аргументом $1.
+type reviewListFilter struct {
+	where   string
+	arg     interface{}
+	hash    string    // Отпечаток для cursor.Scope
+	logAttr slog.Attr // Описание списка в логах
+}
 
-	countQuery := `SELECT COUNT(*) FROM reviews WHERE user_id = $1`
-	selectQuery := `SELECT id, movie_id, user_id, rating, comment, created_at, updated_at 
-                    FROM reviews WHERE user_id = $1`
+// reviewRow - строка списка отзывов: отзыв и значения ключей сортировки для курсоров.
+type reviewRow struct {
+	domain.Review
+	SortKey pq.StringArray `db:"sort_key"`
+}
 
-	s.logger.DebugContext(ctx, "Executing GetReviewsByUserID count query", slog.String("userID", userID))
-	err := s.db.GetContext(ctx, &totalCount, countQuery, userID)
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to count reviews by userID in DB", slog.String("userID", userID), slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to count reviews by userID: %w", err)
+// listReviews возвращает страницу отзывов, подходящих под filter (по номеру страницы или по курсору),
+// и их общее количество (-1, если params.SkipCount).
+func (s *PostgresReviewStore) listReviews(ctx context.Context, filter reviewListFilter, params ListReviewsParams) (*ReviewPage, error) {
+	spec := reviewKeySpec(params.Sort)
+	scope := reviewCursorScope(params.Sort, filter.hash)
+	if err := checkReviewCursor(params.Cursor, scope, spec); err != nil {
+		return nil, err
 	}
+	where := filter.where
+	args := []interface{}{filter.arg}
 
-	if totalCount == 0 {
-		return []*domain.Review{}, 0, nil
+	totalCount := -1
+	if !params.SkipCount {
+		s.logger.DebugContext(ctx, "Executing list reviews count query", filter.logAttr)
+		if err := s.db.GetContext(ctx, &totalCount, `SELECT COUNT(*) FROM reviews WHERE `+where, args...); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to count reviews in DB", filter.logAttr, slog.String("error", err.Error()))
+			return nil, err
+		}
+		if totalCount == 0 {
+			return &ReviewPage{Reviews: []*domain.Review{}}, nil
+		}
 	}
 
-	orderBy := "created_at DESC"
-	if params.SortBy != "" {
-		if params.SortBy == "rating_desc" {
-			orderBy = "rating DESC, created_at DESC"
+	// Keyset-пагинация: строки после граничной; страница "назад" читается в обратном порядке и переворачивается
+	order := spec
+	offset := (params.Page - 1) * params.PageSize
+	backward := params.Cursor != nil && params.Cursor.Backward
+	if params.Cursor != nil {
+		if backward {
+			order = spec.Reverse()
 		}
+		keyset, keysetArgs := order.Keyset(reviewSortColumns, params.Cursor.Values, len(args)+1)
+		where += " AND " + keyset
+		args = append(args, keysetArgs...)
+		offset = 0
 	}
-	selectQuery += " ORDER BY " + orderBy
-	selectQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, (params.Page-1)*params.PageSize)
 
-	s.logger.DebugContext(ctx, "Executing GetReviewsByUserID select query", slog.String("userID", userID), slog.String("query", selectQuery))
-	err = s.db.SelectContext(ctx, &reviews, selectQuery, userID)
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to list reviews by userID from DB", slog.String("userID", userID), slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to list reviews by userID: %w", err)
+	// sort_key - значения ключей сортировки для курсоров; лишний отзыв показывает, есть ли соседняя страница
+	selectQuery := `SELECT id, movie_id, user_id, rating, comment, created_at, updated_at, ` + spec.KeyArray(reviewSortColumns) + ` AS sort_key
+                    FROM reviews WHERE ` + where +
+		" ORDER BY " + order.OrderBy(reviewSortColumns) +
+		fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize+1, offset)
+
+	var rows []reviewRow
+	s.logger.DebugContext(ctx, "Executing list reviews select query", filter.logAttr, slog.String("query", selectQuery))
+	if err := s.db.SelectContext(ctx, &rows, selectQuery, args...); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list reviews from DB", filter.logAttr, slog.String("error", err.Error()))
+		return nil, err
 	}
-	return reviews, totalCount, nil
+	if backward {
+		slices.Reverse(rows)
+	}
+	rows, next, prev := cursor.Paginate(rows, params.PageSize, params.Cursor, params.Cursor == nil && params.Page > 1,
+		scope, func(row reviewRow) []string { return row.SortKey })
+	reviews := make([]*domain.Review, len(rows))
+	for i := range rows {
+		reviews[i] = &rows[i].Review
+	}
+	return &ReviewPage{Reviews: reviews, TotalCount: totalCount, Next: next, Prev: prev}, nil
 }
 
 // GetAggregatedRatingByMovieID рассчитывает средний рейтинг и количество оценок для фильма.
@@ -181,6 +215,27 @@ func (s *PostgresReviewStore) GetAggregatedRatingByMovieID(ctx context.Context,
 	return &aggRating, nil
 }
 
+// nilUUID - начальная позиция для ListMovieRatings.
+const nilUUID = "00000000-0000-0000-0000-000000000000"
+
+// ListMovieRatings возвращает оценки фильмов постранично (keyset по movie_id).
+func (s *PostgresReviewStore) ListMovieRatings(ctx context.Context, afterMovieID string, limit int) ([]*domain.AggregatedRating, error) {
+	query := `SELECT movie_id, AVG(rating) AS average_rating, COUNT(*) AS rating_count
+              FROM reviews WHERE movie_id > $1::uuid
+              GROUP BY movie_id ORDER BY movie_id LIMIT $2`
+	if afterMovieID == "" {
+		afterMovieID = nilUUID // UUID упорядочиваются как их текстовая запись, нулевой - наименьший
+	}
+
+	s.logger.DebugContext(ctx, "Executing ListMovieRatings query", slog.String("after_movie_id", afterMovieID), slog.Int("limit", limit))
+	ratings := make([]*domain.AggregatedRating, 0)
+	if err := s.db.SelectContext(ctx, &ratings, query, afterMovieID, limit); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list movie ratings from DB", slog.String("after_movie_id", afterMovieID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list movie ratings after %q: %w", afterMovieID, err)
+	}
+	return ratings, nil
+}
+
 // Update обновляет существующий отзыв.
 func (s *PostgresReviewStore) Update(ctx context.Context, review *domain.Review) error {
 	query := `UPDATE reviews SET rating = $1, comment = $2, updated_at = $3 WHERE id = $4 AND user_id = $5`
@@ -227,3 +282,20 @@ func (s *PostgresReviewStore) Delete(ctx context.Context, reviewID string, userI
 	s.logger.InfoContext(ctx, "Review deleted successfully from DB", slog.String("reviewID", reviewID))
 	return nil
 }
+
+// DeleteByUserID удаляет все отзывы пользователя. Повторный вызов безопасен (удалит 0 строк).
+func (s *PostgresReviewStore) DeleteByUserID(ctx context.Context, userID string) (int, error) {
+	query := `DELETE FROM reviews WHERE user_id = $1`
+
+	result, err := s.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete user reviews from DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete user reviews: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check user reviews delete result: %w", err)
+	}
+	s.logger.InfoContext(ctx, "User reviews deleted from DB", slog.String("userID", userID), slog.Int64("count", rowsAffected))
+	return int(rowsAffected), nil
+}
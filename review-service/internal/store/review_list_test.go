@@ -0,0 +1,214 @@
+// review-service/internal/store/review_list_test.go
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"review-service/internal/domain"
+	"shared/sortspec"
+)
+
+const pagingMovieID = "movie-1"
+
+// newPagingStore возвращает MockReviewStore с отзывами на один фильм от трех авторов; у отзывов совпадают
+// оценки и время создания, чтобы порядок внутри групп определял id.
+func newPagingStore(t *testing.T) *MockReviewStore {
+	t.Helper()
+	reviewStore := NewMockReviewStore()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 11 {
+		review := &domain.Review{
+			ID:        fmt.Sprintf("review-%02d", i),
+			MovieID:   pagingMovieID,
+			UserID:    fmt.Sprintf("user-%d", i%3),
+			Rating:    int32(1 + i%4),
+			CreatedAt: createdAt.Add(time.Duration(i%4) * time.Hour),
+			UpdatedAt: createdAt.Add(time.Duration(i%2) * time.Hour),
+		}
+		if err := reviewStore.Create(context.Background(), review); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	return reviewStore
+}
+
+func reviewIDs(reviews []*domain.Review) []string {
+	ids := make([]string, len(reviews))
+	for i, review := range reviews {
+		ids[i] = review.ID
+	}
+	return ids
+}
+
+// walkReviewPages проходит список вперед по Next, затем от последней страницы назад по Prev
+// и возвращает отзывы в порядке обоих проходов.
+func walkReviewPages(t *testing.T, list func(ListReviewsParams) (*ReviewPage, error), params ListReviewsParams) (forward, backward []string) {
+	t.Helper()
+	var last *ReviewPage
+	for page, pages := (*ReviewPage)(nil), 0; page == nil || page.Next != nil; pages++ {
+		if pages > 10 {
+			t.Fatal("paging forward does not stop")
+		}
+		if page != nil {
+			params.Cursor = page.Next
+		}
+		var err error
+		if page, err = list(params); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		forward = append(forward, reviewIDs(page.Reviews)...)
+		last = page
+	}
+	backward = reviewIDs(last.Reviews)
+	for page := last; page.Prev != nil; {
+		params.Cursor = page.Prev
+		var err error
+		if page, err = list(params); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		backward = append(reviewIDs(page.Reviews), backward...)
+	}
+	return forward, backward
+}
+
+func TestMockReviewListKeysetPaging(t *testing.T) {
+	ctx := context.Background()
+	reviewStore := newPagingStore(t)
+	listByMovie := func(params ListReviewsParams) (*ReviewPage, error) {
+		return reviewStore.GetReviewsByMovieID(ctx, pagingMovieID, params)
+	}
+	for _, rawSort := range []string{"", "rating", "-rating,created_at", "-updated_at,-rating"} {
+		t.Run("sort="+rawSort, func(t *testing.T) {
+			spec, err := sortspec.Parse(rawSort, ReviewSortFields)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			full, err := listByMovie(ListReviewsParams{Sort: spec, Page: 1, PageSize: 100})
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			want := reviewIDs(full.Reviews)
+			if len(want) != 11 || full.TotalCount != 11 || full.Next != nil {
+				t.Fatalf("full list: %d reviews, total %d, next %v", len(want), full.TotalCount, full.Next)
+			}
+
+			forward, backward := walkReviewPages(t, listByMovie, ListReviewsParams{Sort: spec, Page: 1, PageSize: 3})
+			if !slices.Equal(forward, want) {
+				t.Errorf("forward = %v\nwant      %v", forward, want)
+			}
+			if !slices.Equal(backward, want) {
+				t.Errorf("backward = %v\nwant       %v", backward, want)
+			}
+		})
+	}
+}
+
+func TestMockReviewFeedPaging(t *testing.T) {
+	ctx := context.Background()
+	reviewStore := newPagingStore(t)
+	authors := []string{"user-0", "user-2"}
+	listFeed := func(params ListReviewsParams) (*ReviewPage, error) {
+		return reviewStore.GetFeed(ctx, authors, params)
+	}
+	full, err := listFeed(ListReviewsParams{Page: 1, PageSize: 100})
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	want := reviewIDs(full.Reviews)
+	if len(want) != 7 {
+		t.Fatalf("feed has %d reviews, want 7", len(want))
+	}
+	for i := 1; i < len(full.Reviews); i++ {
+		if full.Reviews[i].CreatedAt.After(full.Reviews[i-1].CreatedAt) {
+			t.Fatalf("feed is not newest first: %v", want)
+		}
+	}
+
+	forward, backward := walkReviewPages(t, listFeed, ListReviewsParams{Page: 1, PageSize: 3, SkipCount: true})
+	if !slices.Equal(forward, want) {
+		t.Errorf("forward = %v\nwant      %v", forward, want)
+	}
+	if !slices.Equal(backward, want) {
+		t.Errorf("backward = %v\nwant       %v", backward, want)
+	}
+}
+
+func TestMockReviewListRejectsForeignCursor(t *testing.T) {
+	ctx := context.Background()
+	reviewStore := newPagingStore(t)
+	byMovie, err := reviewStore.GetReviewsByMovieID(ctx, pagingMovieID, ListReviewsParams{Sort: sortspec.Spec{{Field: ReviewSortRating}}, Page: 1, PageSize: 3})
+	if err != nil || byMovie.Next == nil {
+		t.Fatalf("GetReviewsByMovieID: page %v, error %v", byMovie, err)
+	}
+	feed, err := reviewStore.GetFeed(ctx, []string{"user-0"}, ListReviewsParams{Page: 1, PageSize: 1})
+	if err != nil || feed.Next == nil {
+		t.Fatalf("GetFeed: page %v, error %v", feed, err)
+	}
+
+	tests := []struct {
+		name    string
+		list    func(ListReviewsParams) (*ReviewPage, error)
+		params  ListReviewsParams
+		wantErr bool
+	}{
+		{
+			name: "same list",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetReviewsByMovieID(ctx, pagingMovieID, p)
+			},
+			params: ListReviewsParams{Sort: sortspec.Spec{{Field: ReviewSortRating}}, PageSize: 3, Cursor: byMovie.Next},
+		},
+		{
+			name: "different sort",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetReviewsByMovieID(ctx, pagingMovieID, p)
+			},
+			params:  ListReviewsParams{Sort: sortspec.Spec{{Field: ReviewSortRating, Desc: true}}, PageSize: 3, Cursor: byMovie.Next},
+			wantErr: true,
+		},
+		{
+			name: "another movie",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetReviewsByMovieID(ctx, "movie-2", p)
+			},
+			params:  ListReviewsParams{Sort: sortspec.Spec{{Field: ReviewSortRating}}, PageSize: 3, Cursor: byMovie.Next},
+			wantErr: true,
+		},
+		{
+			name: "movie list cursor in user list",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetReviewsByUserID(ctx, "user-0", p)
+			},
+			params:  ListReviewsParams{Sort: sortspec.Spec{{Field: ReviewSortRating}}, PageSize: 3, Cursor: byMovie.Next},
+			wantErr: true,
+		},
+		{
+			name: "feed cursor after following more users",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetFeed(ctx, []string{"user-0", "user-1"}, p)
+			},
+			params: ListReviewsParams{PageSize: 1, Cursor: feed.Next},
+		},
+		{
+			name: "feed cursor in movie list",
+			list: func(p ListReviewsParams) (*ReviewPage, error) {
+				return reviewStore.GetReviewsByMovieID(ctx, pagingMovieID, p)
+			},
+			params:  ListReviewsParams{PageSize: 3, Cursor: feed.Next},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.list(tt.params)
+			if got := errors.Is(err, ErrInvalidCursor); got != tt.wantErr {
+				t.Errorf("error = %v, want ErrInvalidCursor %v", err, tt.wantErr)
+			}
+		})
+	}
+}
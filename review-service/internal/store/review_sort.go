@@ -0,0 +1,184 @@
+// review-service/internal/store/review_sort.go
+package store
+
+import (
+	"cmp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"review-service/internal/domain"
+	"shared/cursor"
+	"shared/sortspec"
+)
+
+// Поля сортировки отзывов (параметр sort).
+const (
+	ReviewSortCreatedAt = "created_at"
+	ReviewSortUpdatedAt = "updated_at"
+	ReviewSortRating    = "rating"
+)
+
+// ReviewSortFields - поля, по которым разрешено сортировать списки отзывов.
+var ReviewSortFields = []string{ReviewSortCreatedAt, ReviewSortUpdatedAt, ReviewSortRating}
+
+// defaultReviewSort - сортировка по умолчанию: новые отзывы первыми.
+var defaultReviewSort = sortspec.Spec{{Field: ReviewSortCreatedAt, Desc: true}}
+
+// reviewSortID завершает каждую сортировку отзывов (см. reviewKeySpec).
+const reviewSortID = "id"
+
+// reviewSortColumns сопоставляет полям сортировки колонки таблицы reviews.
+var reviewSortColumns = map[string]string{
+	ReviewSortCreatedAt: "created_at",
+	ReviewSortUpdatedAt: "updated_at",
+	ReviewSortRating:    "rating",
+	reviewSortID:        "id",
+}
+
+// ReviewSort возвращает сортировку списка отзывов: заданную или по умолчанию.
+func ReviewSort(spec sortspec.Spec) sortspec.Spec {
+	if len(spec) == 0 {
+		return defaultReviewSort
+	}
+	return spec
+}
+
+// reviewKeySpec возвращает полный порядок списка: ReviewSort и id в конце.
+func reviewKeySpec(spec sortspec.Spec) sortspec.Spec {
+	return ReviewSort(spec).WithTiebreak(reviewSortID)
+}
+
+// Отпечатки фильтров списков отзывов для cursor.Scope: курсор одного списка не принимается в другом.
+func movieReviewsFilter(movieID string) string { return cursor.FilterHash("movie_id", movieID) }
+func userReviewsFilter(userID string) string   { return cursor.FilterHash("user_id", userID) }
+
+// feedFilter не зависит от подписок: курсор ленты остается действительным, если пользователь подписался
+// или отписался между страницами.
+var feedFilter = cursor.FilterHash("feed")
+
+// reviewCursorScope возвращает список, для которого выдаются и принимаются курсоры: сортировку и фильтр.
+func reviewCursorScope(sort sortspec.Spec, filter string) cursor.Scope {
+	return cursor.Scope{Sort: ReviewSort(sort).String(), Filter: filter}
+}
+
+// checkReviewCursor проверяет, что курсор выдан для списка scope и содержит по значению
+// на каждый ключ spec из reviewKeySpec.
+func checkReviewCursor(cur *cursor.Cursor, scope cursor.Scope, spec sortspec.Spec) error {
+	if cur == nil {
+		return nil
+	}
+	if cur.Scope != scope || len(cur.Values) != len(spec) {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// --- Сортировка в памяти для MockReviewStore ---
+
+// compareReviews сравнивает отзывы в порядке spec так же, как ORDER BY в PostgreSQL.
+func compareReviews(spec sortspec.Spec, a, b *domain.Review) int {
+	return spec.Compare(func(field string) int {
+		switch field {
+		case ReviewSortCreatedAt:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		case ReviewSortUpdatedAt:
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		case ReviewSortRating:
+			return cmp.Compare(a.Rating, b.Rating)
+		case reviewSortID:
+			return strings.Compare(a.ID, b.ID)
+		}
+		return 0
+	})
+}
+
+// sortReviews сортирует отзывы в памяти в порядке spec (полном, с id).
+func sortReviews(reviews []*domain.Review, spec sortspec.Spec) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return compareReviews(spec, reviews[i], reviews[j]) < 0
+	})
+}
+
+// reviewSortKey возвращает значения ключей spec отзыва в виде текста (для курсора).
+func reviewSortKey(spec sortspec.Spec, review *domain.Review) []string {
+	values := make([]string, 0, len(spec))
+	for _, key := range spec {
+		switch key.Field {
+		case ReviewSortCreatedAt:
+			values = append(values, review.CreatedAt.Format(time.RFC3339Nano))
+		case ReviewSortUpdatedAt:
+			values = append(values, review.UpdatedAt.Format(time.RFC3339Nano))
+		case ReviewSortRating:
+			values = append(values, strconv.Itoa(int(review.Rating)))
+		case reviewSortID:
+			values = append(values, review.ID)
+		}
+	}
+	return values
+}
+
+// reviewFromSortKey восстанавливает из значений reviewSortKey отзыв, с которым можно сравнивать через compareReviews.
+func reviewFromSortKey(spec sortspec.Spec, values []string) (*domain.Review, error) {
+	review := &domain.Review{}
+	var err error
+	for i, key := range spec {
+		value := values[i]
+		switch key.Field {
+		case ReviewSortCreatedAt:
+			review.CreatedAt, err = time.Parse(time.RFC3339Nano, value)
+		case ReviewSortUpdatedAt:
+			review.UpdatedAt, err = time.Parse(time.RFC3339Nano, value)
+		case ReviewSortRating:
+			var rating int64
+			rating, err = strconv.ParseInt(value, 10, 32)
+			review.Rating = int32(rating)
+		case reviewSortID:
+			review.ID = value
+		}
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return review, nil
+}
+
+// pageReviews возвращает страницу отзывов списка filter по params (номер страницы или курсор).
+// reviews - все отзывы списка; сортируются на месте.
+func pageReviews(reviews []*domain.Review, params ListReviewsParams, filter string) (*ReviewPage, error) {
+	spec := reviewKeySpec(params.Sort)
+	scope := reviewCursorScope(params.Sort, filter)
+	if err := checkReviewCursor(params.Cursor, scope, spec); err != nil {
+		return nil, err
+	}
+	sortReviews(reviews, spec)
+
+	totalCount := len(reviews)
+	if params.SkipCount {
+		totalCount = -1
+	}
+	limit := params.PageSize + 1 // Лишний отзыв показывает, есть ли соседняя страница
+	start := (params.Page - 1) * params.PageSize
+	if params.Cursor != nil {
+		boundary, err := reviewFromSortKey(spec, params.Cursor.Values)
+		if err != nil {
+			return nil, err
+		}
+		// Первый отзыв после граничного; для страницы "назад" берем отзывы перед ним
+		start = sort.Search(len(reviews), func(i int) bool {
+			return compareReviews(spec, reviews[i], boundary) > 0
+		})
+		if params.Cursor.Backward {
+			end := sort.Search(len(reviews), func(i int) bool {
+				return compareReviews(spec, reviews[i], boundary) >= 0
+			})
+			start = max(end-limit, 0)
+			reviews = reviews[:end]
+		}
+	}
+	start = min(max(start, 0), len(reviews))
+	page, next, prev := cursor.Paginate(reviews[start:min(start+limit, len(reviews))], params.PageSize, params.Cursor,
+		params.Cursor == nil && params.Page > 1, scope, func(review *domain.Review) []string { return reviewSortKey(spec, review) })
+	return &ReviewPage{Reviews: page, TotalCount: totalCount, Next: next, Prev: prev}, nil
+}
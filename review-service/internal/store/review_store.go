@@ -5,21 +5,39 @@ import (
 	"errors"
 	"log" // Используем стандартный log для мока, можно заменить на slog если передавать его
 	"review-service/internal/domain"
+	"sort"
 	"sync" // Для безопасного доступа к картам из горутин
 	"time" // Для CreatedAt/UpdatedAt
+
+	"shared/cursor"
+	"shared/sortspec"
 )
 
 // Кастомные ошибки
 var (
 	ErrReviewNotFound  = errors.New("review not found")
 	ErrDuplicateReview = errors.New("user has already reviewed this movie")
+	ErrInvalidCursor   = errors.New("cursor does not match the list sort or filters")
 )
 
 // ListReviewsParams параметры для получения списка отзывов
 type ListReviewsParams struct {
 	Page     int
 	PageSize int
-	SortBy   string // Например, "created_at_desc", "rating_desc"
+	Sort     sortspec.Spec // Поля из ReviewSortFields; пустая - новые отзывы первыми
+
+	// Keyset-пагинация: страница после (или перед, если Backward) граничной строки курсора; Page не учитывается.
+	// Курсор должен быть выдан для того же списка и той же сортировки, иначе возвращается ErrInvalidCursor.
+	Cursor *cursor.Cursor
+	// SkipCount - не считать общее количество отзывов (ReviewPage.TotalCount будет -1).
+	SkipCount bool
+}
+
+// ReviewPage - страница списка отзывов.
+type ReviewPage struct {
+	Reviews    []*domain.Review
+	TotalCount int            // -1, если количество не считалось (SkipCount)
+	Next, Prev *cursor.Cursor // Курсоры соседних страниц; nil - страницы нет
 }
 
 // ReviewStore определяет интерфейс для операций с данными отзывов.
@@ -28,9 +46,15 @@ type ReviewStore interface {
 	GetByID(ctx context.Context, reviewID string) (*domain.Review, error)
 	Update(ctx context.Context, review *domain.Review) error
 	Delete(ctx context.Context, reviewID string, userID string) error
-	GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) ([]*domain.Review, int, error)
-	GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) ([]*domain.Review, int, error)
+	// DeleteByUserID удаляет все отзывы пользователя (удаление аккаунта) и возвращает их количество.
+	DeleteByUserID(ctx context.Context, userID string) (int, error)
+	GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) (*ReviewPage, error)
+	GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) (*ReviewPage, error)
 	GetAggregatedRatingByMovieID(ctx context.Context, movieID string) (*domain.AggregatedRating, error)
+	// ListMovieRatings возвращает до limit оценок фильмов с movie_id больше afterMovieID, по возрастанию movie_id.
+	ListMovieRatings(ctx context.Context, afterMovieID string, limit int) ([]*domain.AggregatedRating, error)
+	// GetFeed возвращает страницу ленты отзывов авторов userIDs, новые первыми. params.Sort не учитывается.
+	GetFeed(ctx context.Context, userIDs []string, params ListReviewsParams) (*ReviewPage, error)
 }
 
 // MockReviewStore для начальной разработки и тестов
@@ -39,6 +63,7 @@ type MockReviewStore struct {
 	reviews        map[string]*domain.Review   // Ключ: reviewID
 	reviewsByMovie map[string][]*domain.Review // Ключ: movieID, значение: слайс указателей на отзывы
 	nextReviewIdx  map[string]map[string]bool  // Для проверки ErrDuplicateReview: map[movieID]map[userID]bool
+	eventOffsets   map[string]int64            // Ключ: имя потребителя событий
 }
 
 // NewMockReviewStore создает новый экземпляр MockReviewStore
@@ -47,6 +72,7 @@ func NewMockReviewStore() *MockReviewStore {
 		reviews:        make(map[string]*domain.Review),
 		reviewsByMovie: make(map[string][]*domain.Review),
 		nextReviewIdx:  make(map[string]map[string]bool),
+		eventOffsets:   make(map[string]int64),
 	}
 }
 
@@ -81,43 +107,20 @@ func (m *MockReviewStore) Create(ctx context.Context, review *domain.Review) err
 	return nil
 }
 
-func (m *MockReviewStore) GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) ([]*domain.Review, int, error) {
+func (m *MockReviewStore) GetReviewsByMovieID(ctx context.Context, movieID string, params ListReviewsParams) (*ReviewPage, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	log.Printf("[MOCK REVIEW STORE] GetReviewsByMovieID called for MovieID='%s', Params: %+v\n", movieID, params)
 
-	movieReviews, ok := m.reviewsByMovie[movieID]
-	if !ok || len(movieReviews) == 0 {
-		return []*domain.Review{}, 0, nil
-	}
-
 	// Копируем, чтобы избежать изменения оригиналов при сортировке или других операциях
+	movieReviews := m.reviewsByMovie[movieID]
 	reviewsCopy := make([]*domain.Review, len(movieReviews))
 	for i, revPtr := range movieReviews {
 		temp := *revPtr // Создаем копию значения
 		reviewsCopy[i] = &temp
 	}
-
-	// TODO: Реализовать сортировку на основе params.SortBy, если нужно
-	// Пример: if params.SortBy == "created_at_desc" { sort.Slice(...) }
-
-	// Пагинация
-	totalCount := len(reviewsCopy)
-	start := (params.Page - 1) * params.PageSize
-	end := start + params.PageSize
-
-	if start < 0 {
-		start = 0
-	}
-	if start >= totalCount {
-		return []*domain.Review{}, totalCount, nil // Запрошенная страница за пределами данных
-	}
-	if end > totalCount {
-		end = totalCount
-	}
-
-	return reviewsCopy[start:end], totalCount, nil
+	return pageReviews(reviewsCopy, params, movieReviewsFilter(movieID))
 }
 
 func (m *MockReviewStore) GetByID(ctx context.Context, reviewID string) (*domain.Review, error) {
@@ -133,32 +136,55 @@ func (m *MockReviewStore) GetByID(ctx context.Context, reviewID string) (*domain
 func (m *MockReviewStore) Update(ctx context.Context, review *domain.Review) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	log.Printf("[MOCK REVIEW STORE] Update called for review ID %s (NOT FULLY IMPLEMENTED)\n", review.ID)
-	if _, ok := m.reviews[review.ID]; !ok {
+	log.Printf("[MOCK REVIEW STORE] Update called for review ID %s by UserID %s\n", review.ID, review.UserID)
+
+	existing, ok := m.reviews[review.ID]
+	if !ok || existing.UserID != review.UserID {
+		// Как и в PostgresReviewStore: отзыв не найден или принадлежит другому пользователю
 		return ErrReviewNotFound
 	}
-	// Просто обновляем время, в реальной реализации нужно обновлять поля
-	reviewCopy := *review
-	reviewCopy.UpdatedAt = time.Now().UTC()
-	m.reviews[review.ID] = &reviewCopy
-
-	// Обновление в m.reviewsByMovie более сложное, нужно найти и заменить элемент
-	// Для простоты мока пока не реализуем полное обновление в reviewsByMovie
+	// Обновляем по указателю, чтобы изменения были видны и в m.reviewsByMovie
+	existing.Rating = review.Rating
+	existing.Comment = review.Comment
+	existing.UpdatedAt = time.Now().UTC()
+	review.UpdatedAt = existing.UpdatedAt
 	return nil
 }
 
 func (m *MockReviewStore) Delete(ctx context.Context, reviewID string, userID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	log.Printf("[MOCK REVIEW STORE] Delete called for review ID %s by UserID %s (NOT FULLY IMPLEMENTED)\n", reviewID, userID)
+	log.Printf("[MOCK REVIEW STORE] Delete called for review ID %s by UserID %s\n", reviewID, userID)
 
 	reviewToDelete, ok := m.reviews[reviewID]
-	if !ok {
+	if !ok || reviewToDelete.UserID != userID {
+		// Как и в PostgresReviewStore: отзыв не найден или принадлежит другому пользователю
 		return ErrReviewNotFound
 	}
-	// TODO: Добавить проверку userID, если нужно, чтобы пользователь удалял только свои отзывы
-	// if reviewToDelete.UserID != userID { return errors.New("user not authorized to delete this review")}
 
+	m.removeReviewLocked(reviewToDelete)
+	return nil
+}
+
+func (m *MockReviewStore) DeleteByUserID(ctx context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK REVIEW STORE] DeleteByUserID called for UserID %s\n", userID)
+
+	deleted := 0
+	for _, review := range m.reviews {
+		if review.UserID == userID {
+			m.removeReviewLocked(review)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// removeReviewLocked удаляет отзыв из всех индексов мока. Вызывается под m.mu.
+func (m *MockReviewStore) removeReviewLocked(reviewToDelete *domain.Review) {
+	reviewID := reviewToDelete.ID
+	userID := reviewToDelete.UserID
 	delete(m.reviews, reviewID)
 
 	// Удаление из m.reviewsByMovie
@@ -184,23 +210,45 @@ func (m *MockReviewStore) Delete(ctx context.Context, reviewID string, userID st
 			delete(m.nextReviewIdx, movieID)
 		}
 	}
-	return nil
 }
 
-func (m *MockReviewStore) GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) ([]*domain.Review, int, error) {
+func (m *MockReviewStore) GetReviewsByUserID(ctx context.Context, userID string, params ListReviewsParams) (*ReviewPage, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	log.Printf("[MOCK REVIEW STORE] GetReviewsByUserID called for UserID='%s', Params: %+v\n", userID, params)
 
-	var userReviews []*domain.Review
+	userReviews := []*domain.Review{}
 	for _, review := range m.reviews { // Перебираем все отзывы
 		if review.UserID == userID {
 			reviewCopy := *review
 			userReviews = append(userReviews, &reviewCopy)
 		}
 	}
-	// TODO: Добавить сортировку и пагинацию аналогично GetReviewsByMovieID
-	return userReviews, len(userReviews), nil
+	if params.Cursor == nil && (params.Page < 1 || params.PageSize <= 0) {
+		sortReviews(userReviews, reviewKeySpec(params.Sort))
+		return &ReviewPage{Reviews: userReviews, TotalCount: len(userReviews)}, nil
+	}
+	return pageReviews(userReviews, params, userReviewsFilter(userID))
+}
+
+func (m *MockReviewStore) GetFeed(ctx context.Context, userIDs []string, params ListReviewsParams) (*ReviewPage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK REVIEW STORE] GetFeed called for %d authors, Params: %+v\n", len(userIDs), params)
+
+	authors := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		authors[userID] = true
+	}
+	feed := []*domain.Review{}
+	for _, review := range m.reviews {
+		if authors[review.UserID] {
+			reviewCopy := *review
+			feed = append(feed, &reviewCopy)
+		}
+	}
+	params.Sort = nil // Лента всегда упорядочена по умолчанию: новые отзывы первыми
+	return pageReviews(feed, params, feedFilter)
 }
 
 func (m *MockReviewStore) GetAggregatedRatingByMovieID(ctx context.Context, movieID string) (*domain.AggregatedRating, error) {
@@ -227,3 +275,30 @@ func (m *MockReviewStore) GetAggregatedRatingByMovieID(ctx context.Context, movi
 
 	return &domain.AggregatedRating{MovieID: movieID, AverageRating: avgRating, RatingCount: ratingCount}, nil
 }
+
+func (m *MockReviewStore) ListMovieRatings(ctx context.Context, afterMovieID string, limit int) ([]*domain.AggregatedRating, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK REVIEW STORE] ListMovieRatings called after MovieID='%s', limit %d\n", afterMovieID, limit)
+
+	ratings := make([]*domain.AggregatedRating, 0)
+	for movieID, movieReviews := range m.reviewsByMovie {
+		if movieID <= afterMovieID || len(movieReviews) == 0 {
+			continue
+		}
+		var sumRating int32
+		for _, reviewPtr := range movieReviews {
+			sumRating += reviewPtr.Rating
+		}
+		ratings = append(ratings, &domain.AggregatedRating{
+			MovieID:       movieID,
+			AverageRating: float64(sumRating) / float64(len(movieReviews)),
+			RatingCount:   int64(len(movieReviews)),
+		})
+	}
+	sort.Slice(ratings, func(i, j int) bool { return ratings[i].MovieID < ratings[j].MovieID })
+	if len(ratings) > limit {
+		ratings = ratings[:limit]
+	}
+	return ratings, nil
+}
@@ -0,0 +1,207 @@
+// shared/auth/jwks.go
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Алгоритмы подписи, которые использует UserService.
+const (
+	algRS256 = "RS256"
+	algEdDSA = "EdDSA"
+)
+
+const (
+	jwksFetchTimeout    = 5 * time.Second
+	jwksMaxAge          = time.Hour        // Плановое обновление набора ключей
+	jwksMinRefreshDelay = 10 * time.Second // Не чаще одного запроса на неизвестный kid
+)
+
+// jwk - публичный ключ в формате JSON Web Key (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+// verificationKey - разобранный публичный ключ.
+type verificationKey struct {
+	alg       string
+	publicKey interface{} // *rsa.PublicKey или ed25519.PublicKey
+}
+
+// jwksCache хранит публичные ключи UserService и обновляет их с /.well-known/jwks.json.
+// При появлении неизвестного kid (ротация ключей) набор перечитывается.
+// Одновременные запросы с устаревшим кэшем ждут одну общую загрузку.
+type jwksCache struct {
+	url        string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]verificationKey
+	lastFetched time.Time
+	inflight    chan struct{} // Закрывается по окончании текущей загрузки; nil, если загрузки нет
+}
+
+// NewTokenValidator создает TokenValidator, проверяющий токены по JWKS UserService.
+// jwksURL - адрес /.well-known/jwks.json UserService. Если UserService недоступен при старте,
+// ключи будут загружены при первой проверке токена.
+func NewTokenValidator(jwksURL string, logger *slog.Logger) (TokenValidator, error) {
+	if jwksURL == "" {
+		return nil, fmt.Errorf("JWKS URL cannot be empty")
+	}
+	cache := &jwksCache{
+		url:        jwksURL,
+		httpClient: &http.Client{Timeout: jwksFetchTimeout},
+		logger:     logger,
+		keys:       make(map[string]verificationKey),
+	}
+	if err := cache.refresh(); err != nil {
+		logger.Warn("Initial JWKS fetch failed, will retry on first token validation", slog.String("url", jwksURL), slog.String("error", err.Error()))
+	}
+	return &jwtValidator{keys: cache}, nil
+}
+
+// get возвращает ключ по kid, при необходимости перечитывая JWKS.
+func (c *jwksCache) get(kid string) (verificationKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	fetchedAt := c.lastFetched
+	inflight := c.inflight
+	c.mu.RUnlock()
+	stale := time.Since(fetchedAt) > jwksMaxAge
+	canRefresh := time.Since(fetchedAt) > jwksMinRefreshDelay
+
+	if ok && !stale {
+		return key, nil
+	}
+	if inflight != nil || stale || canRefresh {
+		if inflight != nil {
+			<-inflight // Ключ может прийти с загрузкой, которая уже идет
+		} else {
+			c.refreshOnce(fetchedAt)
+		}
+		c.mu.RLock()
+		key, ok = c.keys[kid]
+		c.mu.RUnlock()
+	}
+	if !ok {
+		return verificationKey{}, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// refreshOnce перечитывает JWKS, если с момента fetchedAt никто этого еще не сделал.
+// Если загрузка уже идет, дожидается ее вместо запуска новой.
+func (c *jwksCache) refreshOnce(fetchedAt time.Time) {
+	c.mu.Lock()
+	if done := c.inflight; done != nil {
+		c.mu.Unlock()
+		<-done
+		return
+	}
+	if !c.lastFetched.Equal(fetchedAt) {
+		c.mu.Unlock() // Набор уже перечитан, пока мы ждали блокировку
+		return
+	}
+	done := make(chan struct{})
+	c.inflight = done
+	c.mu.Unlock()
+
+	if err := c.refresh(); err != nil {
+		c.logger.Warn("Failed to refresh JWKS", slog.String("url", c.url), slog.String("error", err.Error()))
+	}
+
+	c.mu.Lock()
+	c.inflight = nil
+	c.mu.Unlock()
+	close(done)
+}
+
+// refresh загружает набор ключей с UserService.
+func (c *jwksCache) refresh() error {
+	c.mu.Lock()
+	c.lastFetched = time.Now() // Отмечаем попытку, чтобы не долбить UserService при ошибках
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected JWKS response status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, k := range set.Keys {
+		parsed, err := parseJWK(k)
+		if err != nil {
+			c.logger.Warn("Skipping invalid JWK", slog.String("kid", k.Kid), slog.String("error", err.Error()))
+			continue
+		}
+		keys[k.Kid] = parsed
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+	c.logger.Info("JWKS refreshed", slog.String("url", c.url), slog.Int("keys", len(keys)))
+	return nil
+}
+
+// parseJWK преобразует JWK в публичный ключ.
+func parseJWK(k jwk) (verificationKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return verificationKey{alg: algRS256, publicKey: pub}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return verificationKey{}, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return verificationKey{}, fmt.Errorf("invalid Ed25519 public key")
+		}
+		return verificationKey{alg: algEdDSA, publicKey: ed25519.PublicKey(x)}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,197 @@
+// shared/auth/jwks_test.go
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksServer отдает изменяемый набор Ed25519 ключей и считает запросы.
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    map[string]ed25519.PrivateKey
+	fetches atomic.Int32
+	delay   time.Duration // Задержка ответа, чтобы запросы успели пересечься
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: make(map[string]ed25519.PrivateKey)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		time.Sleep(s.delay)
+		s.mu.Lock()
+		set := struct {
+			Keys []jwk `json:"keys"`
+		}{}
+		for kid, key := range s.keys {
+			pub := key.Public().(ed25519.PublicKey)
+			set.Keys = append(set.Keys, jwk{Kty: "OKP", Kid: kid, Alg: algEdDSA, Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)})
+		}
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// addKey публикует новый ключ kid и возвращает его приватную часть.
+func (s *jwksServer) addKey(t *testing.T, kid string) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	s.mu.Lock()
+	s.keys[kid] = key
+	s.mu.Unlock()
+	return key
+}
+
+func signTestToken(t *testing.T, kid string, key ed25519.PrivateKey) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &Claims{
+		UserID: "user-1",
+		Role:   "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "user-service",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func newTestValidator(t *testing.T, url string) (TokenValidator, *jwksCache) {
+	t.Helper()
+	v, err := NewTokenValidator(url, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewTokenValidator: %v", err)
+	}
+	return v, v.(*jwtValidator).keys
+}
+
+// ageCache делает вид, что набор ключей загружен age назад.
+func ageCache(c *jwksCache, age time.Duration) {
+	c.mu.Lock()
+	c.lastFetched = time.Now().Add(-age)
+	c.mu.Unlock()
+}
+
+func TestJWKSKeyRotation(t *testing.T) {
+	server := newJWKSServer(t)
+	oldKey := server.addKey(t, "k1")
+	validator, cache := newTestValidator(t, server.URL)
+
+	if _, err := validator.Validate(signTestToken(t, "k1", oldKey)); err != nil {
+		t.Fatalf("token signed with the initial key: %v", err)
+	}
+
+	// UserService начинает подписывать новым ключом; старые токены продолжают проверяться
+	newKey := server.addKey(t, "k2")
+	ageCache(cache, 2*jwksMinRefreshDelay)
+	if _, err := validator.Validate(signTestToken(t, "k2", newKey)); err != nil {
+		t.Fatalf("token signed with the rotated key: %v", err)
+	}
+	if _, err := validator.Validate(signTestToken(t, "k1", oldKey)); err != nil {
+		t.Fatalf("token signed with the previous key after rotation: %v", err)
+	}
+	if got := server.fetches.Load(); got != 2 {
+		t.Errorf("JWKS fetches = %d, want 2 (startup and rotation)", got)
+	}
+}
+
+func TestJWKSUnknownKeyID(t *testing.T) {
+	server := newJWKSServer(t)
+	server.addKey(t, "k1")
+	_, foreignKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	validator, cache := newTestValidator(t, server.URL)
+	forged := signTestToken(t, "unknown", foreignKey)
+
+	// Сразу после загрузки неизвестный kid не вызывает повторного запроса
+	if _, err := validator.Validate(forged); err == nil {
+		t.Fatal("token with unknown kid was accepted")
+	}
+	if got := server.fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetches right after startup = %d, want 1", got)
+	}
+
+	// После паузы набор перечитывается один раз, но kid все равно неизвестен
+	ageCache(cache, 2*jwksMinRefreshDelay)
+	for i := 0; i < 3; i++ {
+		if _, err := validator.Validate(forged); err == nil {
+			t.Fatal("token with unknown kid was accepted after refresh")
+		}
+	}
+	if got := server.fetches.Load(); got != 2 {
+		t.Errorf("JWKS fetches = %d, want 2", got)
+	}
+}
+
+func TestJWKSConcurrentRefreshIsShared(t *testing.T) {
+	tests := []struct {
+		name   string
+		rotate bool          // Токены подписаны новым ключом, которого еще нет в кэше
+		age    time.Duration // Возраст кэша перед запросами
+	}{
+		{name: "stale cache", age: 2 * jwksMaxAge},
+		{name: "rotated key", rotate: true, age: 2 * jwksMinRefreshDelay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJWKSServer(t)
+			key := server.addKey(t, "k1")
+			validator, cache := newTestValidator(t, server.URL)
+			token := signTestToken(t, "k1", key)
+			if tt.rotate {
+				token = signTestToken(t, "k2", server.addKey(t, "k2"))
+			}
+
+			server.delay = 50 * time.Millisecond
+			ageCache(cache, tt.age)
+
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			errs := make(chan error, 20)
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					if _, err := validator.Validate(token); err != nil {
+						errs <- err
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				t.Errorf("Validate during refresh: %v", err)
+			}
+			if got := server.fetches.Load(); got != 2 {
+				t.Errorf("JWKS fetches = %d, want 2 (startup and one shared refresh)", got)
+			}
+		})
+	}
+}
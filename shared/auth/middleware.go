@@ -0,0 +1,125 @@
+// shared/auth/middleware.go
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"shared/authz"
+)
+
+// ContextKey используется для ключей в контексте запроса.
+type ContextKey string
+
+const (
+	// UserIDKey ключ для хранения ID пользователя в контексте.
+	UserIDKey ContextKey = "userID"
+	// UserRoleKey ключ для хранения роли пользователя в контексте.
+	UserRoleKey ContextKey = "userRole"
+	// EmailVerifiedKey ключ для хранения признака подтвержденного email в контексте.
+	EmailVerifiedKey ContextKey = "emailVerified"
+)
+
+// Ошибки разбора заголовка Authorization.
+var (
+	ErrMissingAuthHeader = errors.New("authorization header required")
+	ErrInvalidAuthHeader = errors.New("invalid authorization header format")
+)
+
+// BearerToken извлекает токен из заголовка "Authorization: Bearer <token>".
+func BearerToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", ErrMissingAuthHeader
+	}
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", ErrInvalidAuthHeader
+	}
+	return parts[1], nil
+}
+
+// ErrorResponder пишет ответ с ошибкой в формате вызывающего сервиса.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, status int, message string)
+
+// Middleware проверяет токены UserService в MovieService и ReviewService
+// и кладет данные пользователя в контекст запроса.
+type Middleware struct {
+	validator    TokenValidator
+	respondError ErrorResponder
+	logger       *slog.Logger
+	// requireVerifiedEmail включает проверку в VerifiedEmail
+	requireVerifiedEmail bool
+}
+
+// NewMiddleware создает Middleware. respondError используется для ответов 401 и 403.
+func NewMiddleware(v TokenValidator, respondError ErrorResponder, l *slog.Logger, requireVerifiedEmail bool) *Middleware {
+	return &Middleware{
+		validator:            v,
+		respondError:         respondError,
+		logger:               l,
+		requireVerifiedEmail: requireVerifiedEmail,
+	}
+}
+
+// Authenticate проверяет JWT токен из заголовка Authorization.
+// Если токен валиден, ID пользователя, его роль, признак подтвержденного email и разрешения
+// добавляются в контекст запроса.
+func (m *Middleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, err := BearerToken(r)
+		if errors.Is(err, ErrMissingAuthHeader) {
+			m.logger.WarnContext(r.Context(), "Authorization header missing")
+			m.respondError(w, r, http.StatusUnauthorized, "Authorization header required")
+			return
+		}
+		if err != nil {
+			m.logger.WarnContext(r.Context(), "Invalid Authorization header format")
+			m.respondError(w, r, http.StatusUnauthorized, "Invalid Authorization header format")
+			return
+		}
+
+		claims, err := m.validator.Validate(tokenString)
+		if err != nil {
+			m.logger.WarnContext(r.Context(), "Invalid or expired token", slog.String("error", err.Error()))
+			m.respondError(w, r, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+		ctx = context.WithValue(ctx, EmailVerifiedKey, claims.EmailVerified)
+		ctx = authz.WithPermissions(ctx, claims.Permissions)
+
+		m.logger.DebugContext(ctx, "Token validated successfully", slog.String("userID", claims.UserID), slog.String("role", claims.Role))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifiedEmail отклоняет запросы пользователей с неподтвержденным email (403),
+// если включена политика requireVerifiedEmail. Должен применяться после Authenticate.
+func (m *Middleware) VerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verified, _ := r.Context().Value(EmailVerifiedKey).(bool)
+		if m.requireVerifiedEmail && !verified {
+			userID, _ := r.Context().Value(UserIDKey).(string)
+			m.logger.WarnContext(r.Context(), "User with unverified email attempted a restricted action", slog.String("userID", userID), slog.String("path", r.URL.Path))
+			m.respondError(w, r, http.StatusForbidden, "Email address must be verified to perform this action")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAuth оборачивает отдельный обработчик в Authenticate.
+func (m *Middleware) RequireAuth(handlerFunc http.HandlerFunc) http.Handler {
+	return m.Authenticate(handlerFunc)
+}
+
+// RequireVerifiedAuth оборачивает обработчик в Authenticate и VerifiedEmail.
+func (m *Middleware) RequireVerifiedAuth(handlerFunc http.HandlerFunc) http.Handler {
+	return m.Authenticate(m.VerifiedEmail(handlerFunc))
+}
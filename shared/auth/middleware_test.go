@@ -0,0 +1,89 @@
+// shared/auth/middleware_test.go
+package auth
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"shared/authz"
+)
+
+// stubValidator принимает только токен "good" (и "unverified" - без подтвержденного email).
+type stubValidator struct{}
+
+func (stubValidator) Validate(tokenString string) (*Claims, error) {
+	switch tokenString {
+	case "good":
+		return &Claims{UserID: "user-1", Role: "moderator", EmailVerified: true, Permissions: []string{string(authz.PermMovieApprove)}}, nil
+	case "unverified":
+		return &Claims{UserID: "user-2", Role: "user"}, nil
+	}
+	return nil, errors.New("bad token")
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		verifiedOnly   bool
+		requireEmail   bool
+		wantCode       int
+		wantUserID     string
+		wantPermission bool
+	}{
+		{name: "missing header", wantCode: http.StatusUnauthorized},
+		{name: "not bearer", header: "Basic abc", wantCode: http.StatusUnauthorized},
+		{name: "extra parts", header: "Bearer good extra", wantCode: http.StatusUnauthorized},
+		{name: "invalid token", header: "Bearer nope", wantCode: http.StatusUnauthorized},
+		{name: "valid token", header: "Bearer good", wantCode: http.StatusOK, wantUserID: "user-1", wantPermission: true},
+		{name: "scheme is case-insensitive", header: "bearer good", wantCode: http.StatusOK, wantUserID: "user-1", wantPermission: true},
+		{name: "unverified email allowed without policy", header: "Bearer unverified", verifiedOnly: true, wantCode: http.StatusOK, wantUserID: "user-2"},
+		{name: "unverified email rejected by policy", header: "Bearer unverified", verifiedOnly: true, requireEmail: true, wantCode: http.StatusForbidden},
+		{name: "verified email passes policy", header: "Bearer good", verifiedOnly: true, requireEmail: true, wantCode: http.StatusOK, wantUserID: "user-1", wantPermission: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var respondedStatus int
+			respond := func(w http.ResponseWriter, r *http.Request, status int, message string) {
+				respondedStatus = status
+				http.Error(w, message, status)
+			}
+			m := NewMiddleware(stubValidator{}, respond, slog.New(slog.NewTextHandler(io.Discard, nil)), tt.requireEmail)
+
+			var gotUserID string
+			var gotPermission bool
+			next := func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = r.Context().Value(UserIDKey).(string)
+				gotPermission = authz.Has(r.Context(), authz.PermMovieApprove)
+			}
+			handler := m.RequireAuth(next)
+			if tt.verifiedOnly {
+				handler = m.RequireVerifiedAuth(next)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK && respondedStatus != tt.wantCode {
+				t.Errorf("responder got status %d, want %d", respondedStatus, tt.wantCode)
+			}
+			if gotUserID != tt.wantUserID {
+				t.Errorf("userID in context = %q, want %q", gotUserID, tt.wantUserID)
+			}
+			if gotPermission != tt.wantPermission {
+				t.Errorf("permission in context = %v, want %v", gotPermission, tt.wantPermission)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// shared/auth/token.go
+package auth
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenValidator проверяет JWT токены, выпущенные UserService.
+// MovieService и ReviewService не выпускают токены, поэтому здесь только валидация.
+type TokenValidator interface {
+	Validate(tokenString string) (*Claims, error)
+}
+
+// Claims определяет структуру данных, хранимых в JWT.
+// Должна совпадать с auth.Claims в UserService.
+type Claims struct {
+	UserID        string   `json:"user_id"`
+	Role          string   `json:"role"`
+	EmailVerified bool     `json:"email_verified"`
+	Permissions   []string `json:"permissions,omitempty"` // Разрешения роли (см. shared/authz)
+	jwt.RegisteredClaims
+}
+
+// jwtValidator реализует TokenValidator, проверяя подпись по публичным ключам UserService.
+type jwtValidator struct {
+	keys *jwksCache
+}
+
+// Validate проверяет JWT токен и возвращает извлеченные из него Claims.
+func (v *jwtValidator) Validate(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, fmt.Errorf("token has no key id")
+		}
+		key, err := v.keys.get(kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.alg {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.publicKey, nil
+	}, jwt.WithValidMethods([]string{algRS256, algEdDSA}), jwt.WithIssuer("user-service"))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.UserID == "" {
+		return nil, fmt.Errorf("token does not contain user_id")
+	}
+	return claims, nil
+}
@@ -0,0 +1,90 @@
+// shared/authz/authz.go
+//
+// Пакет общий для user-service, movie-service и review-service: UserService выдает разрешения
+// в токене, и все три сервиса проверяют их одними и теми же константами.
+package authz
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// Permission - атомарное право на действие, которое проверяют обработчики сервисов.
+type Permission string
+
+// Разрешения, известные сервисам. Токен несет список разрешений роли пользователя.
+const (
+	PermMovieApprove    Permission = "movie:approve"     // Очередь модерации, одобрение/отклонение и смена статуса фильма
+	PermMovieEditAny    Permission = "movie:edit_any"    // Редактирование чужих фильмов
+	PermMovieDeleteAny  Permission = "movie:delete_any"  // Удаление чужих фильмов
+	PermReviewDeleteAny Permission = "review:delete_any" // Удаление чужих отзывов
+	PermUserRead        Permission = "user:read"         // Просмотр списка и карточек пользователей
+	PermUserSuspend     Permission = "user:suspend"      // Блокировка, принудительный выход и снятие блокировки входа
+	PermUserManageRoles Permission = "user:manage_roles" // Назначение ролей и управление пользовательскими ролями
+)
+
+// AllPermissions перечисляет все известные разрешения (для валидации пользовательских ролей).
+var AllPermissions = []Permission{
+	PermMovieApprove,
+	PermMovieEditAny,
+	PermMovieDeleteAny,
+	PermReviewDeleteAny,
+	PermUserRead,
+	PermUserSuspend,
+	PermUserManageRoles,
+}
+
+// Встроенные роли. Пользовательские роли хранятся в user-service.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// BuiltinRoles сопоставляет встроенные роли с их разрешениями.
+var BuiltinRoles = map[string][]Permission{
+	RoleUser:      {},
+	RoleModerator: {PermMovieApprove, PermMovieEditAny, PermReviewDeleteAny},
+	RoleAdmin:     AllPermissions,
+}
+
+// IsKnown сообщает, является ли perm одним из известных разрешений.
+func IsKnown(perm Permission) bool {
+	return slices.Contains(AllPermissions, perm)
+}
+
+type contextKey struct{}
+
+// WithPermissions возвращает контекст с разрешениями из проверенного токена.
+func WithPermissions(ctx context.Context, permissions []string) context.Context {
+	return context.WithValue(ctx, contextKey{}, permissions)
+}
+
+// Permissions возвращает разрешения, сохраненные в контексте (nil, если их нет).
+func Permissions(ctx context.Context) []string {
+	permissions, _ := ctx.Value(contextKey{}).([]string)
+	return permissions
+}
+
+// Has сообщает, есть ли в контексте запроса разрешение perm.
+func Has(ctx context.Context, perm Permission) bool {
+	return slices.Contains(Permissions(ctx), string(perm))
+}
+
+// DenyFunc формирует ответ на запрос без нужного разрешения (обычно 403).
+type DenyFunc func(w http.ResponseWriter, r *http.Request, perm Permission)
+
+// Require возвращает middleware, пропускающий дальше только запросы с разрешением perm.
+// Должен применяться после middleware, которое кладет разрешения в контекст (WithPermissions).
+func Require(perm Permission, deny DenyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !Has(r.Context(), perm) {
+				deny(w, r, perm)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
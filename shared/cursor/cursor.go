@@ -0,0 +1,155 @@
+// shared/cursor/cursor.go
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid - курсор поврежден, подделан или подписан другим ключом.
+var ErrInvalid = errors.New("invalid cursor")
+
+// Scope - список, для которого выдан курсор: сортировка и отпечаток фильтров.
+// Хранилище принимает курсор, только если его Scope совпадает со Scope запроса.
+type Scope struct {
+	Sort   string `json:"s"`           // Сортировка (sortspec.Spec.String())
+	Filter string `json:"f,omitempty"` // FilterHash фильтров и поискового запроса списка
+}
+
+// Cursor - позиция в списке для keyset-пагинации: значения ключей сортировки граничной строки.
+type Cursor struct {
+	Scope
+	Values   []string `json:"v"`           // Значения ключей сортировки граничной строки, последнее - ее id
+	Backward bool     `json:"b,omitempty"` // true - страница перед граничной строкой (prev_cursor)
+}
+
+// FilterHash возвращает короткий отпечаток фильтров списка для Scope.Filter. Части разделяются
+// нулевым байтом, поэтому ("ab", "c") и ("a", "bc") дают разные отпечатки.
+func FilterHash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:12])
+}
+
+// Codec кодирует курсоры в непрозрачные строки и подписывает их HMAC-SHA256,
+// чтобы клиент не мог подставить в запрос произвольные значения.
+type Codec struct {
+	key []byte
+}
+
+// NewCodec создает Codec с ключом подписи key.
+func NewCodec(key []byte) *Codec {
+	return &Codec{key: key}
+}
+
+// RandomKey генерирует случайный ключ подписи. Курсоры, подписанные им, перестают
+// приниматься после перезапуска сервиса.
+func RandomKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Encode возвращает курсор в виде "<base64url(JSON)>.<base64url(подпись)>"; для nil - пустую строку
+// (соседней страницы нет).
+func (c *Codec) Encode(cur *Cursor) string {
+	if cur == nil {
+		return ""
+	}
+	payload, _ := json.Marshal(cur) // Cursor всегда сериализуется
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// Decode проверяет подпись и разбирает курсор, полученный от Encode.
+func (c *Codec) Decode(token string) (*Cursor, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return nil, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var cur Cursor
+	if err := json.Unmarshal(payload, &cur); err != nil || len(cur.Values) == 0 {
+		return nil, ErrInvalid
+	}
+	return &cur, nil
+}
+
+// FromQuery читает курсор из параметра запроса cursor (nil, если параметр не задан).
+func (c *Codec) FromQuery(query url.Values) (*Cursor, error) {
+	token := query.Get("cursor")
+	if token == "" {
+		return nil, nil
+	}
+	return c.Decode(token)
+}
+
+// IncludeTotal читает параметр запроса include_total (по умолчанию true). При false список
+// отдается без общего количества, и хранилище не выполняет COUNT(*).
+func IncludeTotal(query url.Values) (bool, error) {
+	value := query.Get("include_total")
+	if value == "" {
+		return true, nil
+	}
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("include_total must be true or false")
+	}
+	return include, nil
+}
+
+// Paginate готовит страницу списка, прочитанную с запасом в одну строку (limit+1), и возвращает курсоры
+// соседних страниц (nil - страницы нет): next - после последней строки, prev - перед первой. cur - курсор
+// запроса (nil при пагинации по номеру страницы), afterFirstPage - запрошена не первая страница по номеру.
+// scope - список, для которого выдаются курсоры; sortKey возвращает значения ключей сортировки строки.
+func Paginate[T any](items []T, limit int, cur *Cursor, afterFirstPage bool, scope Scope, sortKey func(T) []string) (page []T, next, prev *Cursor) {
+	backward := cur != nil && cur.Backward
+	more := len(items) > limit
+	if more {
+		// Лишняя строка - самая дальняя от граничной: в конце при чтении вперед, в начале при чтении назад
+		if backward {
+			items = items[len(items)-limit:]
+		} else {
+			items = items[:limit]
+		}
+	}
+	if len(items) == 0 {
+		return items, nil, nil
+	}
+	hasNext, hasPrev := more, cur != nil || afterFirstPage
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		next = &Cursor{Scope: scope, Values: sortKey(items[len(items)-1])}
+	}
+	if hasPrev {
+		prev = &Cursor{Scope: scope, Values: sortKey(items[0]), Backward: true}
+	}
+	return items, next, prev
+}
+
+func (c *Codec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
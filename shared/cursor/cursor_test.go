@@ -0,0 +1,129 @@
+// shared/cursor/cursor_test.go
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var testScope = Scope{Sort: "-release_year,title,id", Filter: FilterHash("approved", "sci-fi")}
+
+func TestCodecRoundTrip(t *testing.T) {
+	codec := NewCodec([]byte("secret"))
+	want := &Cursor{Scope: testScope, Values: []string{"1999", "Matrix", "b"}, Backward: true}
+	got, err := codec.Decode(codec.Encode(want))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+	if token := codec.Encode(nil); token != "" {
+		t.Errorf("Encode(nil) = %q, want empty", token)
+	}
+}
+
+func TestCodecRejectsTamperedTokens(t *testing.T) {
+	codec := NewCodec([]byte("secret"))
+	token := codec.Encode(&Cursor{Scope: testScope, Values: []string{"1999", "Matrix", "b"}})
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	// Клиент подменяет сортировку в payload, оставляя исходную подпись
+	forged := &Cursor{Scope: Scope{Sort: "title,id", Filter: testScope.Filter}, Values: []string{"Matrix", "b"}}
+	forgedEncoded, _, _ := strings.Cut(codec.Encode(forged), ".")
+
+	flipped := []byte(signature)
+	flipped[0] ^= 1
+
+	tests := []struct {
+		name  string
+		codec *Codec
+		token string
+	}{
+		{name: "sort replaced in payload", codec: codec, token: forgedEncoded + "." + signature},
+		{name: "signature changed", codec: codec, token: encoded + "." + string(flipped)},
+		{name: "signature removed", codec: codec, token: encoded},
+		{name: "signed by another key", codec: NewCodec([]byte("other")), token: token},
+		{name: "signature is not base64", codec: codec, token: encoded + ".!!!"},
+		{name: "payload is not JSON", codec: codec, token: signed(codec, "not json")},
+		{name: "no sort values", codec: codec, token: signed(codec, `{"s":"title,id","v":[]}`)},
+		{name: "empty token", codec: codec, token: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.codec.Decode(tt.token); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Decode error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+// signed подписывает произвольный payload ключом codec.
+func signed(codec *Codec, payload string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(codec.sign(encoded))
+}
+
+func TestFilterHash(t *testing.T) {
+	if FilterHash("approved", "sci-fi") != FilterHash("approved", "sci-fi") {
+		t.Error("FilterHash must be stable")
+	}
+	if FilterHash("ab", "c") == FilterHash("a", "bc") {
+		t.Error("FilterHash must separate parts")
+	}
+	if FilterHash("approved") == FilterHash("approved", "") {
+		t.Error("FilterHash must depend on the number of parts")
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	key := func(item string) []string { return []string{item} }
+	forward := &Cursor{Scope: testScope, Values: []string{"b"}}
+	backward := &Cursor{Scope: testScope, Values: []string{"f"}, Backward: true}
+	tests := []struct {
+		name           string
+		items          []string // Строки, прочитанные с запасом (limit+1)
+		cur            *Cursor
+		afterFirstPage bool
+		wantPage       []string
+		wantNext       []string // nil - курсора нет
+		wantPrev       []string
+	}{
+		{name: "first page with more", items: []string{"a", "b", "c"}, wantPage: []string{"a", "b"}, wantNext: []string{"b"}},
+		{name: "single page", items: []string{"a", "b"}, wantPage: []string{"a", "b"}},
+		{name: "empty list", items: nil, wantPage: nil},
+		{name: "numbered page after the first", items: []string{"c", "d"}, afterFirstPage: true, wantPage: []string{"c", "d"}, wantPrev: []string{"c"}},
+		{name: "forward cursor with more", items: []string{"c", "d", "e"}, cur: forward, wantPage: []string{"c", "d"}, wantNext: []string{"d"}, wantPrev: []string{"c"}},
+		{name: "forward cursor on the last page", items: []string{"c"}, cur: forward, wantPage: []string{"c"}, wantPrev: []string{"c"}},
+		{name: "backward cursor with more", items: []string{"c", "d", "e"}, cur: backward, wantPage: []string{"d", "e"}, wantNext: []string{"e"}, wantPrev: []string{"d"}},
+		{name: "backward cursor reaches the start", items: []string{"a", "b"}, cur: backward, wantPage: []string{"a", "b"}, wantNext: []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, next, prev := Paginate(tt.items, 2, tt.cur, tt.afterFirstPage, testScope, key)
+			if !slices.Equal(page, tt.wantPage) {
+				t.Errorf("page = %v, want %v", page, tt.wantPage)
+			}
+			checkCursor(t, "next", next, tt.wantNext, false)
+			checkCursor(t, "prev", prev, tt.wantPrev, true)
+		})
+	}
+}
+
+func checkCursor(t *testing.T, name string, got *Cursor, wantValues []string, wantBackward bool) {
+	t.Helper()
+	if wantValues == nil {
+		if got != nil {
+			t.Errorf("%s = %+v, want nil", name, got)
+		}
+		return
+	}
+	want := &Cursor{Scope: testScope, Values: wantValues, Backward: wantBackward}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %+v, want %+v", name, got, want)
+	}
+}
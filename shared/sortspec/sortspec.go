@@ -0,0 +1,188 @@
+// shared/sortspec/sortspec.go
+package sortspec
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MaxKeys - наибольшее число ключей в одной сортировке.
+const MaxKeys = 5
+
+// Key - поле сортировки и направление.
+type Key struct {
+	Field string
+	Desc  bool
+}
+
+// Spec - сортировка по нескольким ключам, главный ключ первый. Пустая Spec означает сортировку по умолчанию.
+type Spec []Key
+
+// Error - сортировка задана неверно (неизвестное поле, повтор поля и т.п.).
+// Allowed перечисляет поля, разрешенные для этого списка.
+type Error struct {
+	Reason  string
+	Allowed []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (allowed fields: %s)", e.Reason, strings.Join(e.Allowed, ", "))
+}
+
+// Parse разбирает сортировку вида "-release_year,title": поля через запятую,
+// "-" перед полем - по убыванию. Принимаются только поля из allowed,
+// поэтому Spec можно безопасно превращать в ORDER BY через белый список колонок.
+func Parse(raw string, allowed []string) (Spec, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxKeys {
+		return nil, &Error{Reason: fmt.Sprintf("at most %d sort fields are allowed", MaxKeys), Allowed: allowed}
+	}
+	spec := make(Spec, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		key := Key{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		switch {
+		case key.Field == "":
+			return nil, &Error{Reason: "empty sort field", Allowed: allowed}
+		case !slices.Contains(allowed, key.Field):
+			return nil, &Error{Reason: fmt.Sprintf("unknown sort field %q", key.Field), Allowed: allowed}
+		case seen[key.Field]:
+			return nil, &Error{Reason: fmt.Sprintf("sort field %q is repeated", key.Field), Allowed: allowed}
+		}
+		seen[key.Field] = true
+		spec = append(spec, key)
+	}
+	return spec, nil
+}
+
+// FromLegacy переводит прежний формат sort_by ("title_asc", "rating_desc") в формат Parse.
+// Значения без суффикса направления возвращаются без изменений.
+func FromLegacy(sortBy string) string {
+	if field, ok := strings.CutSuffix(sortBy, "_desc"); ok {
+		return "-" + field
+	}
+	if field, ok := strings.CutSuffix(sortBy, "_asc"); ok {
+		return field
+	}
+	return sortBy
+}
+
+// FromQuery читает сортировку из параметра запроса sort, а если он не задан - из прежнего sort_by.
+func FromQuery(query url.Values, allowed []string) (Spec, error) {
+	if raw := query.Get("sort"); raw != "" {
+		return Parse(raw, allowed)
+	}
+	return Parse(FromLegacy(query.Get("sort_by")), allowed)
+}
+
+// String возвращает сортировку в формате Parse.
+func (s Spec) String() string {
+	parts := make([]string, 0, len(s))
+	for _, key := range s {
+		if key.Desc {
+			parts = append(parts, "-"+key.Field)
+		} else {
+			parts = append(parts, key.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Has сообщает, есть ли в сортировке поле field.
+func (s Spec) Has(field string) bool {
+	return slices.ContainsFunc(s, func(key Key) bool { return key.Field == field })
+}
+
+// WithTiebreak возвращает сортировку s с полем field по возрастанию в конце, не изменяя s.
+// field должно быть уникальным (обычно id): тогда порядок строк однозначен, а значения всех ключей
+// строки задают ее точную позицию - по ним keyset-пагинация выдает и проверяет курсоры.
+// Если field уже есть в s, добавлять его повторно не нужно.
+func (s Spec) WithTiebreak(field string) Spec {
+	if s.Has(field) {
+		return slices.Clip(s)
+	}
+	return append(slices.Clip(s), Key{Field: field})
+}
+
+// OrderBy строит выражение ORDER BY (без самих слов ORDER BY). columns сопоставляет полям
+// SQL выражения; поля без выражения пропускаются.
+func (s Spec) OrderBy(columns map[string]string) string {
+	parts := make([]string, 0, len(s))
+	for _, key := range s {
+		column, ok := columns[key.Field]
+		if !ok {
+			continue
+		}
+		if key.Desc {
+			parts = append(parts, column+" DESC")
+		} else {
+			parts = append(parts, column+" ASC")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Reverse возвращает сортировку с противоположными направлениями всех ключей
+// (чтение страницы "назад" при keyset-пагинации).
+func (s Spec) Reverse() Spec {
+	reversed := make(Spec, len(s))
+	for i, key := range s {
+		reversed[i] = Key{Field: key.Field, Desc: !key.Desc}
+	}
+	return reversed
+}
+
+// KeyArray строит SQL выражение ARRAY[...] со значениями ключей сортировки строки в виде текста -
+// по ним клиенту выдается курсор. columns должны содержать выражения для всех полей s.
+func (s Spec) KeyArray(columns map[string]string) string {
+	parts := make([]string, 0, len(s))
+	for _, key := range s {
+		parts = append(parts, "("+columns[key.Field]+")::text")
+	}
+	return "ARRAY[" + strings.Join(parts, ", ") + "]"
+}
+
+// Keyset строит условие WHERE для keyset-пагинации: строки, идущие в порядке s строго после строки
+// со значениями ключей values (len(values) == len(s)). Значения передаются аргументами $firstArg, $firstArg+1, ...
+// без явного типа: PostgreSQL выводит его из сравниваемого выражения. Условие раскрывается через OR
+// ((k1 > v1) OR (k1 = v1 AND k2 > v2) ...), так как направления ключей могут различаться.
+func (s Spec) Keyset(columns map[string]string, values []string, firstArg int) (string, []interface{}) {
+	disjuncts := make([]string, 0, len(s))
+	args := make([]interface{}, 0, len(s))
+	for i, key := range s {
+		conjuncts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conjuncts = append(conjuncts, fmt.Sprintf("%s = $%d", columns[s[j].Field], firstArg+j))
+		}
+		operator := ">"
+		if key.Desc {
+			operator = "<"
+		}
+		conjuncts = append(conjuncts, fmt.Sprintf("%s %s $%d", columns[key.Field], operator, firstArg+i))
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+		args = append(args, values[i])
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
+
+// Compare сравнивает два элемента по ключам сортировки (для сортировки в памяти).
+// compareField сравнивает элементы по одному полю по возрастанию: <0, 0 или >0.
+func (s Spec) Compare(compareField func(field string) int) int {
+	for _, key := range s {
+		c := compareField(key.Field)
+		if key.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
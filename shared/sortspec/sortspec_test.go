@@ -0,0 +1,242 @@
+// shared/sortspec/sortspec_test.go
+package sortspec
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var testAllowed = []string{"title", "release_year", "created_at", "id"}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		want       Spec
+		wantReason string // Непустая - ожидается *Error с этой причиной
+	}{
+		{name: "empty means default", raw: "", want: nil},
+		{name: "blank means default", raw: "   ", want: nil},
+		{name: "single ascending", raw: "title", want: Spec{{Field: "title"}}},
+		{name: "minus prefix sorts descending", raw: "-release_year", want: Spec{{Field: "release_year", Desc: true}}},
+		{name: "several keys keep order", raw: "-release_year,title", want: Spec{{Field: "release_year", Desc: true}, {Field: "title"}}},
+		{name: "spaces around fields", raw: " -created_at , id ", want: Spec{{Field: "created_at", Desc: true}, {Field: "id"}}},
+		{name: "max keys", raw: "title,release_year,created_at,id", want: Spec{{Field: "title"}, {Field: "release_year"}, {Field: "created_at"}, {Field: "id"}}},
+		{name: "unknown field", raw: "rating", wantReason: `unknown sort field "rating"`},
+		{name: "unknown field after minus", raw: "title,-rating", wantReason: `unknown sort field "rating"`},
+		{name: "field is case sensitive", raw: "Title", wantReason: `unknown sort field "Title"`},
+		{name: "repeated field", raw: "title,title", wantReason: `sort field "title" is repeated`},
+		{name: "repeated field in other direction", raw: "title,-title", wantReason: `sort field "title" is repeated`},
+		{name: "bare minus", raw: "-", wantReason: "empty sort field"},
+		{name: "empty field between commas", raw: "title,,id", wantReason: "empty sort field"},
+		{name: "double minus", raw: "--title", wantReason: `unknown sort field "-title"`},
+		{name: "too many fields", raw: "title,a,b,c,d,e", wantReason: "at most 5 sort fields are allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw, testAllowed)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("Parse(%q) error = %v", tt.raw, err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Parse(%q) = %v, want %v", tt.raw, got, tt.want)
+				}
+				return
+			}
+			var specErr *Error
+			if !errors.As(err, &specErr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.raw, err)
+			}
+			if specErr.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", specErr.Reason, tt.wantReason)
+			}
+			// Ответ 400 перечисляет разрешенные поля
+			if !slices.Equal(specErr.Allowed, testAllowed) {
+				t.Errorf("Allowed = %v, want %v", specErr.Allowed, testAllowed)
+			}
+			if !strings.Contains(err.Error(), "allowed fields: title, release_year, created_at, id") {
+				t.Errorf("Error() = %q, want the allowed fields listed", err.Error())
+			}
+		})
+	}
+}
+
+func TestFromQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   url.Values
+		want    Spec
+		wantErr bool
+	}{
+		{name: "nothing set", query: url.Values{}, want: nil},
+		{name: "sort", query: url.Values{"sort": {"-created_at"}}, want: Spec{{Field: "created_at", Desc: true}}},
+		{name: "legacy desc", query: url.Values{"sort_by": {"release_year_desc"}}, want: Spec{{Field: "release_year", Desc: true}}},
+		{name: "legacy asc", query: url.Values{"sort_by": {"title_asc"}}, want: Spec{{Field: "title"}}},
+		{name: "legacy without direction", query: url.Values{"sort_by": {"title"}}, want: Spec{{Field: "title"}}},
+		{name: "sort wins over sort_by", query: url.Values{"sort": {"title"}, "sort_by": {"created_at_desc"}}, want: Spec{{Field: "title"}}},
+		{name: "legacy unknown field", query: url.Values{"sort_by": {"rating_desc"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromQuery(tt.query, testAllowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromQuery error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromQuery = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecStringRoundTrip(t *testing.T) {
+	for _, raw := range []string{"title", "-release_year,title", "-created_at,-id"} {
+		spec, err := Parse(raw, testAllowed)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", raw, err)
+		}
+		if got := spec.String(); got != raw {
+			t.Errorf("Parse(%q).String() = %q", raw, got)
+		}
+	}
+}
+
+var testColumns = map[string]string{
+	"title":        "title",
+	"release_year": "COALESCE(release_year, 0)",
+	"created_at":   "created_at",
+	"id":           "id",
+}
+
+func TestOrderByAndReverse(t *testing.T) {
+	spec := Spec{{Field: "release_year", Desc: true}, {Field: "title"}, {Field: "relevance"}, {Field: "id"}}
+	// relevance нет в columns: такое поле пропускается
+	if got, want := spec.OrderBy(testColumns), "COALESCE(release_year, 0) DESC, title ASC, id ASC"; got != want {
+		t.Errorf("OrderBy = %q, want %q", got, want)
+	}
+	if got, want := spec.Reverse().OrderBy(testColumns), "COALESCE(release_year, 0) ASC, title DESC, id DESC"; got != want {
+		t.Errorf("Reverse().OrderBy = %q, want %q", got, want)
+	}
+	if !spec[0].Desc {
+		t.Error("Reverse must not modify the original spec")
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     Spec
+		values   []string
+		firstArg int
+		want     string
+	}{
+		{
+			name:     "id only",
+			spec:     Spec{{Field: "id"}},
+			values:   []string{"b"},
+			firstArg: 1,
+			want:     "((id > $1))",
+		},
+		{
+			name:     "ascending with id tie-break",
+			spec:     Spec{{Field: "title"}, {Field: "id"}},
+			values:   []string{"Alien", "b"},
+			firstArg: 1,
+			want:     "((title > $1) OR (title = $1 AND id > $2))",
+		},
+		{
+			name:     "descending with id tie-break",
+			spec:     Spec{{Field: "created_at", Desc: true}, {Field: "id", Desc: true}},
+			values:   []string{"2024-01-01T00:00:00Z", "b"},
+			firstArg: 1,
+			want:     "((created_at < $1) OR (created_at = $1 AND id < $2))",
+		},
+		{
+			name:     "mixed directions after filter arguments",
+			spec:     Spec{{Field: "release_year", Desc: true}, {Field: "title"}, {Field: "id"}},
+			values:   []string{"1999", "Matrix", "b"},
+			firstArg: 4,
+			want: "((COALESCE(release_year, 0) < $4) OR (COALESCE(release_year, 0) = $4 AND title > $5) OR " +
+				"(COALESCE(release_year, 0) = $4 AND title = $5 AND id > $6))",
+		},
+		{
+			name:     "reversed spec reads the previous page",
+			spec:     Spec{{Field: "release_year", Desc: true}, {Field: "title"}, {Field: "id"}}.Reverse(),
+			values:   []string{"1999", "Matrix", "b"},
+			firstArg: 1,
+			want: "((COALESCE(release_year, 0) > $1) OR (COALESCE(release_year, 0) = $1 AND title < $2) OR " +
+				"(COALESCE(release_year, 0) = $1 AND title = $2 AND id < $3))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := tt.spec.Keyset(testColumns, tt.values, tt.firstArg)
+			if got != tt.want {
+				t.Errorf("Keyset =\n  %s\nwant\n  %s", got, tt.want)
+			}
+			wantArgs := make([]interface{}, len(tt.values))
+			for i, value := range tt.values {
+				wantArgs[i] = value
+			}
+			if !reflect.DeepEqual(args, wantArgs) {
+				t.Errorf("args = %v, want %v", args, wantArgs)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	type row struct {
+		year int
+		id   string
+	}
+	spec := Spec{{Field: "release_year", Desc: true}, {Field: "id"}}
+	compare := func(a, b row) int {
+		return spec.Compare(func(field string) int {
+			switch field {
+			case "release_year":
+				return a.year - b.year
+			default:
+				return strings.Compare(a.id, b.id)
+			}
+		})
+	}
+	rows := []row{{1999, "b"}, {2004, "c"}, {1999, "a"}, {0, "d"}}
+	slices.SortFunc(rows, compare)
+	want := []row{{2004, "c"}, {1999, "a"}, {1999, "b"}, {0, "d"}}
+	if !slices.Equal(rows, want) {
+		t.Errorf("sorted = %v, want %v", rows, want)
+	}
+}
+
+func TestSpecWithTiebreak(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want Spec
+	}{
+		{name: "default sort", spec: nil, want: Spec{{Field: "id"}}},
+		{name: "appended last ascending", spec: Spec{{Field: "title", Desc: true}}, want: Spec{{Field: "title", Desc: true}, {Field: "id"}}},
+		{name: "already present", spec: Spec{{Field: "id", Desc: true}, {Field: "title"}}, want: Spec{{Field: "id", Desc: true}, {Field: "title"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.WithTiebreak("id"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithTiebreak = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Исходная сортировка не меняется, даже если в ее массиве есть место
+	base := make(Spec, 1, 4)
+	base[0] = Key{Field: "title"}
+	first, second := base.WithTiebreak("id"), base.WithTiebreak("created_at")
+	if len(base) != 1 || first[1].Field != "id" || second[1].Field != "created_at" {
+		t.Errorf("base = %v, first = %v, second = %v", base, first, second)
+	}
+}
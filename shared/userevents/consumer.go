@@ -0,0 +1,124 @@
+// shared/userevents/consumer.go
+package userevents
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// ConsumerName - имя потребителя в consumer_offsets.
+	ConsumerName = "user-events"
+	// eventDeleted - аккаунт удален в UserService.
+	eventDeleted = "user.deleted"
+	// batchSize - сколько событий запрашивается за один вызов.
+	batchSize = 100
+)
+
+// Event - событие из outbox UserService. Ему соответствует userpb.UserEvent
+// (у каждого сервиса своя копия сгенерированного кода, поэтому тип события - параметр).
+type Event interface {
+	GetId() int64
+	GetType() string
+	GetUserId() string
+}
+
+// Source - источник событий пользователей (gRPC клиент UserService).
+type Source[E Event] interface {
+	ListUserEvents(ctx context.Context, afterID int64, limit int32) ([]E, error)
+}
+
+// OffsetStore хранит позицию потребителя (ID последнего обработанного события).
+type OffsetStore interface {
+	GetEventOffset(ctx context.Context, consumer string) (int64, error)
+	SaveEventOffset(ctx context.Context, consumer string, offset int64) error
+}
+
+// DeleteFunc убирает данные удаленного пользователя в хранилище сервиса
+// и возвращает количество затронутых записей. Должна быть идемпотентной.
+type DeleteFunc func(ctx context.Context, userID string) (int, error)
+
+// Consumer периодически забирает события из outbox UserService и убирает данные
+// удаленных пользователей через DeleteFunc. Позиция сохраняется после каждого события, поэтому
+// при недоступности UserService или падении сервиса обработка продолжится с того же места
+// (событие может быть применено повторно, обработчики идемпотентны).
+type Consumer[E Event] struct {
+	source      Source[E]
+	offsets     OffsetStore
+	userDeleted DeleteFunc
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewConsumer создает потребителя, опрашивающего UserService раз в interval.
+func NewConsumer[E Event](source Source[E], offsets OffsetStore, userDeleted DeleteFunc, logger *slog.Logger, interval time.Duration) *Consumer[E] {
+	return &Consumer[E]{source: source, offsets: offsets, userDeleted: userDeleted, logger: logger, interval: interval}
+}
+
+// Run обрабатывает события до отмены ctx.
+func (c *Consumer[E]) Run(ctx context.Context) {
+	c.logger.Info("User event consumer started", slog.Duration("interval", c.interval))
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.drain(ctx)
+		select {
+		case <-ctx.Done():
+			c.logger.Info("User event consumer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain обрабатывает все доступные события; при ошибке откладывает остаток до следующего тика.
+func (c *Consumer[E]) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := c.processBatch(ctx)
+		if err != nil {
+			c.logger.ErrorContext(ctx, "Failed to process user events, will retry", slog.String("error", err.Error()))
+			return
+		}
+		if processed < batchSize {
+			return
+		}
+	}
+}
+
+// processBatch обрабатывает одну порцию событий и возвращает их количество.
+func (c *Consumer[E]) processBatch(ctx context.Context) (int, error) {
+	offset, err := c.offsets.GetEventOffset(ctx, ConsumerName)
+	if err != nil {
+		return 0, err
+	}
+	events, err := c.source.ListUserEvents(ctx, offset, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		if err := c.handle(ctx, event); err != nil {
+			return 0, fmt.Errorf("event %d: %w", event.GetId(), err)
+		}
+		if err := c.offsets.SaveEventOffset(ctx, ConsumerName, event.GetId()); err != nil {
+			return 0, err
+		}
+	}
+	return len(events), nil
+}
+
+// handle применяет одно событие. Неизвестные типы событий пропускаются.
+func (c *Consumer[E]) handle(ctx context.Context, event E) error {
+	switch event.GetType() {
+	case eventDeleted:
+		affected, err := c.userDeleted(ctx, event.GetUserId())
+		if err != nil {
+			return err
+		}
+		c.logger.InfoContext(ctx, "Data of deleted user cleaned up", slog.String("userID", event.GetUserId()), slog.Int("count", affected))
+	default:
+		c.logger.DebugContext(ctx, "Skipping unsupported user event", slog.Int64("eventID", event.GetId()), slog.String("type", event.GetType()))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -17,9 +18,13 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	httpAPI "user-service/internal/api"
+	"user-service/internal/clients"
+	"user-service/internal/export"
 	"user-service/internal/genproto/userpb"
 	grpcServer "user-service/internal/grpc"
+	"user-service/internal/mailer"
 	"user-service/internal/store"
+	"user-service/internal/worker"
 	"user-service/pkg/auth"
 )
 
@@ -37,6 +42,77 @@ func getDBConnectionString() string {
 	return dbURL
 }
 
+// loadSigningKeys загружает ключи подписи JWT из каталога JWT_KEYS_DIR.
+// Активный ключ задается JWT_ACTIVE_KEY_ID (по умолчанию - последний по имени файла),
+// остальные ключи каталога используются только для проверки уже выданных токенов.
+// Если каталог не задан, генерируется временный Ed25519 ключ (только для разработки).
+func loadSigningKeys(logger *slog.Logger) ([]auth.SigningKey, string, error) {
+	keysDir := os.Getenv("JWT_KEYS_DIR")
+	if keysDir == "" {
+		key, err := auth.GenerateEd25519SigningKey()
+		if err != nil {
+			return nil, "", err
+		}
+		logger.Warn("JWT_KEYS_DIR environment variable not set, using ephemeral Ed25519 key for development. Tokens will become invalid after restart.", slog.String("kid", key.KeyID))
+		return []auth.SigningKey{key}, key.KeyID, nil
+	}
+
+	keys, err := auth.LoadSigningKeys(keysDir)
+	if err != nil {
+		return nil, "", err
+	}
+	activeKeyID := os.Getenv("JWT_ACTIVE_KEY_ID")
+	if activeKeyID == "" {
+		activeKeyID = keys[len(keys)-1].KeyID
+	}
+	return keys, activeKeyID, nil
+}
+
+// newMailer создает отправитель писем по MAILER_KIND: "log" (по умолчанию) пишет письма в лог,
+// "file" сохраняет их в каталог MAIL_OUTBOX_DIR.
+func newMailer(logger *slog.Logger) (mailer.Mailer, error) {
+	switch kind := os.Getenv("MAILER_KIND"); kind {
+	case "", "log":
+		return mailer.NewLogMailer(logger), nil
+	case "file":
+		outboxDir := os.Getenv("MAIL_OUTBOX_DIR")
+		if outboxDir == "" {
+			outboxDir = "./mail_outbox"
+		}
+		return mailer.NewFileMailer(outboxDir, logger)
+	default:
+		return nil, fmt.Errorf("unknown MAILER_KIND %q (expected \"log\" or \"file\")", kind)
+	}
+}
+
+// getEnvInt читает целое число из переменной окружения или возвращает значение по умолчанию.
+func getEnvInt(logger *slog.Logger, name string, def int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		logger.Warn("Invalid integer in environment variable, using default", slog.String("name", name), slog.String("value", raw), slog.Int("default", def))
+		return def
+	}
+	return value
+}
+
+// getEnvDuration читает длительность (например, "15m") из переменной окружения или возвращает значение по умолчанию.
+func getEnvDuration(logger *slog.Logger, name string, def time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		logger.Warn("Invalid duration in environment variable, using default", slog.String("name", name), slog.String("value", raw), slog.Duration("default", def))
+		return def
+	}
+	return value
+}
+
 func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	validate := validator.New()
@@ -45,19 +121,55 @@ func main() {
 	httpPort := "8080"
 
 	// --- Конфигурация для JWT ---
-	jwtSecretKey := os.Getenv("JWT_SECRET_KEY")
-	if jwtSecretKey == "" {
-		jwtSecretKey = "your-very-secret-and-long-enough-key-for-hmac256-dev-only"
-		logger.Warn("JWT_SECRET_KEY environment variable not set, using default insecure key for development.")
+	jwtTokenDuration := time.Minute * 15        // Access токен живет недолго, продлевается через refresh токен
+	refreshTokenDuration := time.Hour * 24 * 30 // Refresh токен хранится на сервере и может быть отозван
+
+	appBaseURL := os.Getenv("APP_BASE_URL") // Используется в ссылках из писем
+	if appBaseURL == "" {
+		appBaseURL = "http://localhost:8080"
 	}
-	jwtTokenDuration := time.Hour * 24
 
-	tokenManager, err := auth.NewTokenManager(jwtSecretKey, jwtTokenDuration)
+	signingKeys, activeKeyID, err := loadSigningKeys(logger)
+	if err != nil {
+		logger.Error("Failed to load JWT signing keys", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	tokenManager, err := auth.NewTokenManager(signingKeys, activeKeyID, jwtTokenDuration)
 	if err != nil {
 		logger.Error("Failed to create token manager", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
-	logger.Info("Token manager initialized.")
+	logger.Info("Token manager initialized.", slog.String("active_kid", activeKeyID), slog.Int("verification_keys", len(signingKeys)))
+
+	// --- Защита входа от перебора паролей ---
+	loginThrottle := httpAPI.LoginThrottleConfig{
+		Account: httpAPI.LoginThrottleRule{
+			BackoffAfter:     3,
+			LockoutThreshold: getEnvInt(logger, "LOGIN_LOCKOUT_THRESHOLD", 10),
+			LockoutDuration:  getEnvDuration(logger, "LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		},
+		IP: httpAPI.LoginThrottleRule{
+			BackoffAfter:     20,
+			LockoutThreshold: getEnvInt(logger, "LOGIN_IP_LOCKOUT_THRESHOLD", 100),
+			LockoutDuration:  getEnvDuration(logger, "LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		},
+		BaseDelay:         time.Second,
+		MaxDelay:          5 * time.Minute,
+		FailureWindow:     time.Hour,
+		TrustProxyHeaders: os.Getenv("TRUST_PROXY_HEADERS") == "true",
+	}
+
+	totpIssuer := os.Getenv("TOTP_ISSUER") // Название сервиса в приложении-аутентификаторе
+	if totpIssuer == "" {
+		totpIssuer = "MovieApp"
+	}
+
+	mailSender, err := newMailer(logger)
+	if err != nil {
+		logger.Error("Failed to create mailer", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
 	// --- Инициализация хранилища PostgreSQL ---
 	dbURL := getDBConnectionString()
@@ -77,8 +189,58 @@ func main() {
 	}()
 	logger.Info("PostgreSQL UserStore initialized.")
 
+	// --- gRPC клиенты для выгрузки персональных данных ---
+	movieServiceGRPCAddr := os.Getenv("MOVIE_SERVICE_GRPC_ADDR")
+	if movieServiceGRPCAddr == "" {
+		movieServiceGRPCAddr = "localhost:9092"
+	}
+	reviewServiceGRPCAddr := os.Getenv("REVIEW_SERVICE_GRPC_ADDR")
+	if reviewServiceGRPCAddr == "" {
+		reviewServiceGRPCAddr = "localhost:9093"
+	}
+	movieSvcClient, err := clients.NewMovieServiceGRPCClient(movieServiceGRPCAddr, logger)
+	if err != nil {
+		logger.Error("Failed to create MovieService gRPC client", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer movieSvcClient.Close()
+	reviewSvcClient, err := clients.NewReviewServiceGRPCClient(reviewServiceGRPCAddr, logger)
+	if err != nil {
+		logger.Error("Failed to create ReviewService gRPC client", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer reviewSvcClient.Close()
+	exportBuilder := export.NewBuilder(userStorage, movieSvcClient, reviewSvcClient)
+
+	// --- Фоновые задачи: удаление аккаунтов по истечении отсрочки, асинхронные выгрузки ---
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	accountPurger := worker.NewAccountPurger(userStorage, logger, getEnvDuration(logger, "ACCOUNT_PURGE_INTERVAL", time.Minute))
+	go accountPurger.Run(workerCtx)
+	exportWorker := worker.NewExportWorker(userStorage, exportBuilder, logger, 5*time.Second, getEnvDuration(logger, "EXPORT_RETENTION", 7*24*time.Hour))
+	go exportWorker.Run(workerCtx)
+
+	// --- Настройка и запуск HTTP сервера ---
+	httpAPIHandler := httpAPI.NewHTTPHandler(userStorage, logger, validate, tokenManager, mailSender, exportBuilder, httpAPI.Config{
+		RefreshTokenDuration:           refreshTokenDuration,
+		PasswordResetTokenDuration:     time.Hour,
+		EmailVerificationTokenDuration: time.Hour * 24,
+		VerificationResendInterval:     time.Minute,
+		AppBaseURL:                     appBaseURL,
+		LoginThrottle:                  loginThrottle,
+		LoginChallengeDuration:         5 * time.Minute,
+		TOTPIssuer:                     totpIssuer,
+		RequirePrivilegedTwoFactor:     os.Getenv("REQUIRE_ADMIN_2FA") != "false",
+		AccountDeletionGracePeriod:     getEnvDuration(logger, "ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+	}) // Передаем PostgresUserStore
+
 	// --- Настройка и запуск gRPC сервера ---
-	grpcServiceImplementation := grpcServer.NewServer(userStorage, logger) // Передаем PostgresUserStore
+	// Сервисный токен API шлюза: только с ним LoginUser учитывает переданный client_ip
+	gatewayToken := os.Getenv("GRPC_GATEWAY_TOKEN")
+	if gatewayToken == "" {
+		logger.Info("GRPC_GATEWAY_TOKEN is not set; LoginUser ignores client_ip and throttles by the caller's address")
+	}
+	grpcServiceImplementation := grpcServer.NewServer(userStorage, httpAPIHandler, logger, gatewayToken) // Регистрация и вход используют логику HTTP обработчиков
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 	if err != nil {
 		logger.Error("Failed to listen for gRPC", slog.String("port", grpcPort), slog.String("error", err.Error()))
@@ -95,8 +257,6 @@ func main() {
 		}
 	}()
 
-	// --- Настройка и запуск HTTP сервера ---
-	httpAPIHandler := httpAPI.NewHTTPHandler(userStorage, logger, validate, tokenManager) // Передаем PostgresUserStore
 	httpRouter := httpAPI.NewHTTPRouter(httpAPIHandler)
 	httpSrv := &http.Server{
 		Addr:         ":" + httpPort,
@@ -118,6 +278,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("User Service shutting down...")
+	stopWorkers()
 
 	ctxHttp, cancelHttp := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelHttp()
@@ -12,6 +12,7 @@ require (
 	golang.org/x/crypto v0.33.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
+	shared v0.0.0-00010101000000-000000000000
 )
 
 require (
@@ -24,3 +25,5 @@ require (
 	golang.org/x/text v0.22.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
 )
+
+replace shared => ../shared
@@ -0,0 +1,130 @@
+// user-service/internal/api/account_deletion_handlers.go
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/mailer"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+)
+
+// DeleteAccount назначает удаление аккаунта аутентифицированного пользователя (DELETE /api/users/me).
+// Требует текущий пароль. Аккаунт удаляется по истечении AccountDeletionGracePeriod;
+// до этого пользователь может войти и отменить удаление. Все refresh токены отзываются.
+func (h *HTTPHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for DeleteAccount")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "HTTP DeleteAccount request received", slog.String("userID", userID))
+
+	var req domain.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode delete account request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User associated with token not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for account deletion", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete account")
+		}
+		return
+	}
+
+	if !auth.CheckPasswordHash(req.Password, user.PasswordHash) {
+		h.logger.WarnContext(ctx, "Invalid password on account deletion", slog.String("userID", userID))
+		h.respondError(w, r, http.StatusUnauthorized, "Password is incorrect")
+		return
+	}
+	if user.DeletionScheduledAt != nil {
+		h.respondError(w, r, http.StatusConflict, "Account deletion is already scheduled")
+		return
+	}
+
+	scheduledAt := time.Now().UTC().Add(h.config.AccountDeletionGracePeriod)
+	if err := h.store.ScheduleDeletion(ctx, userID, scheduledAt); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to schedule account deletion", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to delete account")
+		return
+	}
+	if err := h.store.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		// Удаление уже назначено; сессии все равно исчезнут вместе с аккаунтом
+		h.logger.ErrorContext(ctx, "Failed to revoke refresh tokens on account deletion", slog.String("userID", userID), slog.String("error", err.Error()))
+	}
+	if err := h.sendAccountDeletionEmail(r, user, scheduledAt); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to send account deletion email", slog.String("userID", userID), slog.String("error", err.Error()))
+	}
+
+	h.logger.InfoContext(ctx, "Account deletion scheduled", slog.String("userID", userID), slog.Time("scheduledAt", scheduledAt))
+	h.respondJSON(w, r, http.StatusAccepted, domain.AccountDeletionResponse{
+		Message:             "Account deletion scheduled. Sign in and cancel the deletion before this time to keep the account.",
+		DeletionScheduledAt: scheduledAt,
+	})
+}
+
+// CancelAccountDeletion отменяет назначенное удаление аккаунта (POST /api/users/me/deletion/cancel).
+func (h *HTTPHandler) CancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for CancelAccountDeletion")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User associated with token not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for deletion cancel", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to cancel account deletion")
+		}
+		return
+	}
+	if user.DeletionScheduledAt == nil {
+		h.respondError(w, r, http.StatusConflict, "Account deletion is not scheduled")
+		return
+	}
+
+	if err := h.store.CancelDeletion(ctx, userID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to cancel account deletion", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to cancel account deletion")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Account deletion cancelled", slog.String("userID", userID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sendAccountDeletionEmail уведомляет пользователя о назначенном удалении аккаунта.
+func (h *HTTPHandler) sendAccountDeletionEmail(r *http.Request, user *domain.User, scheduledAt time.Time) error {
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Your account is scheduled for deletion",
+		Body: fmt.Sprintf("Hello, %s!\n\nYour account and all its data, including your reviews, will be permanently deleted on %s.\n\nTo keep your account, sign in before then and cancel the deletion. If you did not request this, sign in, cancel the deletion and change your password.\n",
+			user.Username, scheduledAt.Format(time.RFC1123)),
+	}
+	return h.mailer.Send(r.Context(), msg)
+}
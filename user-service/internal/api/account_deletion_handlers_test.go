@@ -0,0 +1,130 @@
+// user-service/internal/api/account_deletion_handlers_test.go
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+)
+
+func TestDeleteAccountWrongPassword(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "careful", "user")
+
+	rec := env.do(t, http.MethodDelete, "/api/users/me", env.tokens(t, user).Token, domain.DeleteAccountRequest{Password: "wrong-password"})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("code = %d, want 401 (body %s)", rec.Code, rec.Body.String())
+	}
+	stored, err := env.store.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.DeletionScheduledAt != nil {
+		t.Error("deletion scheduled despite the wrong password")
+	}
+}
+
+func TestCancelAccountDeletionWithinGracePeriod(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "hesitant", "user")
+	pair := env.tokens(t, user)
+
+	rec := env.do(t, http.MethodDelete, "/api/users/me", pair.Token, domain.DeleteAccountRequest{Password: testPassword})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("delete: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var scheduled domain.AccountDeletionResponse
+	decodeJSON(t, rec, &scheduled)
+	if len(env.mailer.sentTo(user.Email)) != 1 {
+		t.Error("deletion notice was not emailed")
+	}
+	rec = env.do(t, http.MethodDelete, "/api/users/me", pair.Token, domain.DeleteAccountRequest{Password: testPassword})
+	if rec.Code != http.StatusConflict {
+		t.Errorf("repeated delete: code = %d, want 409", rec.Code)
+	}
+
+	// Все сессии завершены, но войти заново можно
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(pair.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after deletion request: code = %d, want 401", rec.Code)
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/login", "", domain.LoginRequest{Email: user.Email, Password: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login within grace period: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var login domain.TokenPairResponse
+	decodeJSON(t, rec, &login)
+
+	rec = env.do(t, http.MethodPost, "/api/users/me/deletion/cancel", login.Token, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("cancel: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/me/deletion/cancel", login.Token, nil)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("repeated cancel: code = %d, want 409", rec.Code)
+	}
+
+	// Когда срок, на который было назначено удаление, наступает, аккаунт остается
+	purged, err := env.store.PurgeScheduledUsers(context.Background(), scheduled.DeletionScheduledAt.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("PurgeScheduledUsers: %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("purged %v after the deletion was cancelled", purged)
+	}
+	if _, err := env.store.GetByID(context.Background(), user.ID); err != nil {
+		t.Errorf("GetByID after cancelled deletion: %v", err)
+	}
+}
+
+func TestScheduledDeletionPurgedAfterGracePeriod(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "departing", "user")
+
+	rec := env.do(t, http.MethodDelete, "/api/users/me", env.tokens(t, user).Token, domain.DeleteAccountRequest{Password: testPassword})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("delete: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var scheduled domain.AccountDeletionResponse
+	decodeJSON(t, rec, &scheduled)
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		wantPurged bool
+	}{
+		{name: "within grace period", at: scheduled.DeletionScheduledAt.Add(-time.Minute)},
+		{name: "after grace period", at: scheduled.DeletionScheduledAt.Add(time.Minute), wantPurged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purged, err := env.store.PurgeScheduledUsers(context.Background(), tt.at, 10)
+			if err != nil {
+				t.Fatalf("PurgeScheduledUsers: %v", err)
+			}
+			if got := len(purged) == 1 && purged[0] == user.ID; got != tt.wantPurged {
+				t.Errorf("purged = %v, want user purged: %v", purged, tt.wantPurged)
+			}
+			_, err = env.store.GetByID(context.Background(), user.ID)
+			if exists := err == nil; exists == tt.wantPurged {
+				t.Errorf("user exists = %v after purge at %s (err %v)", exists, tt.at, err)
+			}
+			if tt.wantPurged && !errors.Is(err, store.ErrUserNotFound) {
+				t.Errorf("GetByID error = %v, want ErrUserNotFound", err)
+			}
+		})
+	}
+
+	events, err := env.store.ListUserEvents(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatalf("ListUserEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != domain.UserEventDeleted || events[0].UserID != user.ID {
+		t.Errorf("user events = %+v, want one user.deleted for %s", events, user.ID)
+	}
+}
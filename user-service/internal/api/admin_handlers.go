@@ -0,0 +1,260 @@
+// user-service/internal/api/admin_handlers.go
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// rejectSuspended отвечает 403, если аккаунт заблокирован. Возвращает true, если запрос отклонен.
+func (h *HTTPHandler) rejectSuspended(w http.ResponseWriter, r *http.Request, user *domain.User) bool {
+	if !user.IsSuspended(time.Now().UTC()) {
+		return false
+	}
+	h.logger.WarnContext(r.Context(), "Request from suspended account rejected", slog.String("userID", user.ID), slog.String("path", r.URL.Path))
+	response := map[string]interface{}{"error": "Account is suspended"}
+	if user.SuspensionReason != nil {
+		response["reason"] = *user.SuspensionReason
+	}
+	if user.SuspendedUntil != nil {
+		response["suspended_until"] = user.SuspendedUntil
+	}
+	h.respondJSON(w, r, http.StatusForbidden, response)
+	return true
+}
+
+// getTargetUser загружает пользователя из пути {userId}; при ошибке сам отвечает клиенту.
+func (h *HTTPHandler) getTargetUser(w http.ResponseWriter, r *http.Request) (*domain.User, bool) {
+	ctx := r.Context()
+	targetUserID := mux.Vars(r)["userId"]
+	user, err := h.store.GetByID(ctx, targetUserID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get target user", slog.String("userID", targetUserID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		}
+		return nil, false
+	}
+	return user, true
+}
+
+// ListUsers возвращает пользователей с поиском и пагинацией (GET /api/users/admin/users).
+// Параметры: page, limit, search (подстрока username/email), role, status (active|suspended).
+func (h *HTTPHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryParams := r.URL.Query()
+	h.logger.InfoContext(ctx, "HTTP ListUsers request received", slog.String("query", queryParams.Encode()))
+
+	page, _ := strconv.Atoi(queryParams.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(queryParams.Get("limit"))
+	if pageSize <= 0 {
+		pageSize = 20
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+
+	params := store.UserListParams{
+		Page:        page,
+		PageSize:    pageSize,
+		SearchQuery: queryParams.Get("search"),
+		Role:        queryParams.Get("role"),
+		Status:      queryParams.Get("status"),
+	}
+	if params.Status != "" && params.Status != store.UserStatusActive && params.Status != store.UserStatusSuspended {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid status filter, expected 'active' or 'suspended'")
+		return
+	}
+
+	users, totalCount, err := h.store.List(ctx, params)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list users from store", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve users")
+		return
+	}
+
+	response := struct {
+		Users      []*domain.User `json:"users"`
+		TotalCount int            `json:"total_count"`
+		Page       int            `json:"page"`
+		PageSize   int            `json:"page_size"`
+	}{
+		Users:      users,
+		TotalCount: totalCount,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+	}
+	h.respondJSON(w, r, http.StatusOK, response)
+}
+
+// GetUserAdmin возвращает полную карточку пользователя, включая данные блокировки (GET /api/users/admin/users/{userId}).
+func (h *HTTPHandler) GetUserAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+	h.respondJSON(w, r, http.StatusOK, user)
+}
+
+// ChangeUserRole меняет роль пользователя (PUT /api/users/admin/users/{userId}/role).
+// Новая роль попадает в токены при следующем входе или обновлении токена.
+func (h *HTTPHandler) ChangeUserRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+
+	var req domain.ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+	if user.ID == adminID {
+		h.respondError(w, r, http.StatusBadRequest, "Administrators cannot change their own role")
+		return
+	}
+
+	if _, err := h.resolveRole(ctx, req.Role); err != nil {
+		if errors.Is(err, store.ErrRoleNotFound) {
+			h.respondError(w, r, http.StatusBadRequest, "Unknown role: "+req.Role)
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to resolve role", slog.String("role", req.Role), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to change role")
+		}
+		return
+	}
+
+	previousRole := user.Role
+	user.Role = req.Role
+	if err := h.store.Update(ctx, user); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to change user role", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to change role")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User role changed by admin", slog.String("userID", user.ID), slog.String("adminID", adminID), slog.String("from", previousRole), slog.String("to", user.Role))
+	h.respondJSON(w, r, http.StatusOK, user)
+}
+
+// SuspendUser блокирует аккаунт и завершает все его сессии (POST /api/users/admin/users/{userId}/suspend).
+func (h *HTTPHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+
+	var req domain.SuspendUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+	if req.Until != nil && !req.Until.After(time.Now()) {
+		h.respondError(w, r, http.StatusBadRequest, "Suspension end time must be in the future")
+		return
+	}
+
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+	if user.ID == adminID {
+		h.respondError(w, r, http.StatusBadRequest, "Administrators cannot suspend themselves")
+		return
+	}
+
+	if err := h.store.Suspend(ctx, user.ID, req.Reason, req.Until); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to suspend user", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to suspend user")
+		return
+	}
+	if err := h.store.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to revoke sessions of suspended user", slog.String("userID", user.ID), slog.String("error", err.Error()))
+	}
+
+	h.logger.InfoContext(ctx, "User suspended by admin", slog.String("userID", user.ID), slog.String("adminID", adminID), slog.String("reason", req.Reason))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnsuspendUser снимает блокировку аккаунта (POST /api/users/admin/users/{userId}/unsuspend).
+func (h *HTTPHandler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.store.Unsuspend(ctx, user.ID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to unsuspend user", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to unsuspend user")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User unsuspended by admin", slog.String("userID", user.ID), slog.String("adminID", adminID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ForceLogoutUser отзывает все refresh токены пользователя (POST /api/users/admin/users/{userId}/logout).
+// Уже выданные access токены действуют до истечения (не более 15 минут).
+func (h *HTTPHandler) ForceLogoutUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.store.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to force logout user", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to log out user")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User sessions revoked by admin", slog.String("userID", user.ID), slog.String("adminID", adminID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnlockUser снимает блокировку входа и обнуляет счетчик неудачных попыток аккаунта
+// (POST /api/users/admin/users/{userId}/unlock, только для admin).
+func (h *HTTPHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+
+	user, ok := h.getTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.ResetLoginAttempts(ctx, accountThrottleKey(user.Email)); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to reset login attempts", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to unlock user")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User login unlocked by admin", slog.String("userID", user.ID), slog.String("adminID", adminID))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+// user-service/internal/api/admin_handlers_test.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestSuspendedUserCannotRefresh(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "suspect", "user")
+	session := env.tokens(t, user)
+
+	// Блокировка напрямую в хранилище: refresh токен не отозван, отказать должна сама проверка блокировки
+	if err := env.store.Suspend(context.Background(), user.ID, "spam", nil); err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+	rec := env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(session.RefreshToken))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("refresh while suspended: code = %d, want 403 (body %s)", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Reason string `json:"reason"`
+	}
+	decodeJSON(t, rec, &resp)
+	if resp.Reason != "spam" {
+		t.Errorf("reason = %q, want %q", resp.Reason, "spam")
+	}
+
+	rec = env.do(t, http.MethodGet, "/api/users/me", session.Token, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("GET /me while suspended: code = %d, want 403", rec.Code)
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/login", "", domain.LoginRequest{Email: user.Email, Password: testPassword})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("login while suspended: code = %d, want 403", rec.Code)
+	}
+}
+
+func TestAdminSuspendAndUnsuspend(t *testing.T) {
+	env := newTestEnv(t)
+	admin := env.createUser(t, "chief", "admin")
+	user := env.createUser(t, "member", "user")
+	adminToken := env.tokens(t, admin).Token
+	session := env.tokens(t, user)
+
+	rec := env.do(t, http.MethodPost, "/api/users/admin/users/"+user.ID+"/suspend", adminToken, domain.SuspendUserRequest{Reason: "abuse"})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("suspend: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	// Блокировка завершает сессии пользователя
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(session.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after suspension: code = %d, want 401", rec.Code)
+	}
+
+	rec = env.do(t, http.MethodPost, "/api/users/admin/users/"+user.ID+"/unsuspend", adminToken, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unsuspend: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/login", "", domain.LoginRequest{Email: user.Email, Password: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Errorf("login after unsuspend: code = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAdminEndpointsAccess(t *testing.T) {
+	env := newTestEnv(t)
+	admin := env.createUser(t, "chief", "admin")
+	moderator := env.createUser(t, "mod", "moderator")
+	user := env.createUser(t, "member", "user")
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		caller *domain.User
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{name: "anonymous list", method: http.MethodGet, path: "/api/users/admin/users", want: http.StatusUnauthorized},
+		{name: "user cannot list", caller: user, method: http.MethodGet, path: "/api/users/admin/users", want: http.StatusForbidden},
+		{name: "moderator cannot suspend", caller: moderator, method: http.MethodPost, path: "/api/users/admin/users/" + user.ID + "/suspend", body: domain.SuspendUserRequest{Reason: "x"}, want: http.StatusForbidden},
+		{name: "admin lists users", caller: admin, method: http.MethodGet, path: "/api/users/admin/users?search=member", want: http.StatusOK},
+		{name: "admin cannot suspend self", caller: admin, method: http.MethodPost, path: "/api/users/admin/users/" + admin.ID + "/suspend", body: domain.SuspendUserRequest{Reason: "x"}, want: http.StatusBadRequest},
+		{name: "suspension end in the past", caller: admin, method: http.MethodPost, path: "/api/users/admin/users/" + user.ID + "/suspend", body: domain.SuspendUserRequest{Reason: "x", Until: &past}, want: http.StatusBadRequest},
+		{name: "suspend unknown user", caller: admin, method: http.MethodPost, path: "/api/users/admin/users/00000000-0000-0000-0000-000000000000/suspend", body: domain.SuspendUserRequest{Reason: "x"}, want: http.StatusNotFound},
+		{name: "unknown role", caller: admin, method: http.MethodPut, path: "/api/users/admin/users/" + user.ID + "/role", body: domain.ChangeRoleRequest{Role: "emperor"}, want: http.StatusBadRequest},
+		{name: "admin cannot change own role", caller: admin, method: http.MethodPut, path: "/api/users/admin/users/" + admin.ID + "/role", body: domain.ChangeRoleRequest{Role: "user"}, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := ""
+			if tt.caller != nil {
+				token = env.tokens(t, tt.caller).Token
+			}
+			rec := env.do(t, tt.method, tt.path, token, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("code = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminListUsersSearch(t *testing.T) {
+	env := newTestEnv(t)
+	admin := env.createUser(t, "chief", "admin")
+	env.createUser(t, "alice", "user")
+	env.createUser(t, "alicia", "user")
+	env.createUser(t, "bob", "user")
+
+	rec := env.do(t, http.MethodGet, "/api/users/admin/users?search=ali", env.tokens(t, admin).Token, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Users      []domain.User `json:"users"`
+		TotalCount int           `json:"total_count"`
+	}
+	decodeJSON(t, rec, &resp)
+	if resp.TotalCount != 2 || len(resp.Users) != 2 {
+		t.Errorf("search=ali returned %d of %d users, want 2", len(resp.Users), resp.TotalCount)
+	}
+}
@@ -0,0 +1,183 @@
+// user-service/internal/api/auth_flow.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+// Регистрация, вход и проверка access токена без привязки к транспорту: их используют
+// HTTP обработчики и gRPC сервер (internal/grpc), чтобы правила были одни и те же.
+
+// Ошибки регистрации и входа. Ошибки валидации возвращаются как validator.ValidationErrors.
+var (
+	ErrUsernameReserved   = errors.New("username is reserved")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+)
+
+// LoginThrottledError - попытка входа отклонена защитой от перебора паролей.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter)
+}
+
+// AccountSuspendedError - аккаунт заблокирован администратором.
+type AccountSuspendedError struct {
+	User *domain.User
+}
+
+func (e *AccountSuspendedError) Error() string {
+	return "account is suspended"
+}
+
+// LoginResult - результат первого шага входа: либо токены, либо challenge для второго шага (2FA).
+type LoginResult struct {
+	User      *domain.User
+	Tokens    *domain.TokenPairResponse      // nil, если требуется код 2FA
+	Challenge *domain.LoginChallengeResponse // Не nil, если требуется код 2FA (второй шаг - POST /api/users/login/2fa)
+}
+
+// Register создает неподтвержденный аккаунт с ролью user и отправляет письмо подтверждения.
+func (h *HTTPHandler) Register(ctx context.Context, req domain.RegisterRequest) (*domain.User, error) {
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.logger.ErrorContext(ctx, "Registration request validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if isReservedUsername(req.Username) {
+		return nil, ErrUsernameReserved
+	}
+
+	hashedPassword, err := auth.HashPassword(req.Password)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to hash password", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	newUser := &domain.User{
+		ID:           uuid.NewString(),
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: hashedPassword,
+		Role:         "user",
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+	if err := h.store.Create(ctx, newUser); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to create user in store", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// Аккаунт создается неподтвержденным; ошибка отправки письма не мешает регистрации,
+	// письмо можно запросить повторно через /api/users/me/verify/resend
+	if err := h.sendVerificationEmail(ctx, newUser); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to send verification email", slog.String("userID", newUser.ID), slog.String("error", err.Error()))
+	}
+
+	h.logger.InfoContext(ctx, "User registered successfully", slog.String("userID", newUser.ID), slog.String("username", newUser.Username))
+	return newUser, nil
+}
+
+// Login проверяет email и пароль с учетом защиты от перебора. clientIPs - адреса клиента
+// для счетчиков по IP: попытка учитывается и проверяется по каждому из них.
+// При включенной 2FA возвращает challenge вместо токенов.
+func (h *HTTPHandler) Login(ctx context.Context, req domain.LoginRequest, clientIPs ...string) (*LoginResult, error) {
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.logger.ErrorContext(ctx, "Login request validation failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// Счетчики ведутся и для несуществующих email, чтобы ответ не раскрывал наличие аккаунта
+	accountKey, ipKeys := accountThrottleKey(req.Email), ipThrottleKeys(clientIPs...)
+	wait, err := h.loginRetryAfter(ctx, accountKey, ipKeys)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check login throttle", slog.String("email", req.Email), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to check login throttle: %w", err)
+	}
+	if wait > 0 {
+		h.logger.WarnContext(ctx, "Login attempt throttled", slog.String("email", req.Email), slog.String("ip_keys", strings.Join(ipKeys, ",")), slog.Duration("retry_after", wait))
+		return nil, &LoginThrottledError{RetryAfter: wait}
+	}
+
+	user, err := h.store.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.logger.WarnContext(ctx, "Login attempt for non-existent email", slog.String("email", req.Email))
+			h.recordLoginFailure(ctx, accountKey, ipKeys)
+			return nil, ErrInvalidCredentials
+		}
+		h.logger.ErrorContext(ctx, "Failed to get user by email from store", slog.String("email", req.Email), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if !auth.CheckPasswordHash(req.Password, user.PasswordHash) {
+		h.logger.WarnContext(ctx, "Invalid password attempt", slog.String("email", req.Email), slog.String("userID", user.ID))
+		h.recordLoginFailure(ctx, accountKey, ipKeys)
+		return nil, ErrInvalidCredentials
+	}
+	if user.IsSuspended(time.Now().UTC()) {
+		h.logger.WarnContext(ctx, "Login of suspended account rejected", slog.String("userID", user.ID))
+		return nil, &AccountSuspendedError{User: user}
+	}
+
+	// При включенной 2FA пароль - только первый шаг: выдаем challenge токен для /login/2fa
+	twoFactorEnabled, err := h.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check two-factor status", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if twoFactorEnabled {
+		challenge, err := h.createLoginChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	if err := h.store.ResetLoginAttempts(ctx, accountKey); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to reset login attempts after successful login", slog.String("userID", user.ID), slog.String("error", err.Error()))
+	}
+	// Каждый логин начинает новое семейство refresh токенов
+	tokens, err := h.issueTokenPair(ctx, user, uuid.NewString())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to issue tokens", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	h.logger.InfoContext(ctx, "User logged in successfully", slog.String("userID", user.ID), slog.String("email", user.Email))
+	return &LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// AuthenticateToken проверяет access токен и состояние аккаунта: токен не отражает блокировку,
+// выданную после его выпуска, поэтому пользователь загружается из хранилища.
+// Возвращает ErrInvalidToken, store.ErrUserNotFound или *AccountSuspendedError.
+func (h *HTTPHandler) AuthenticateToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	claims, err := h.tokenManager.Validate(tokenString)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Invalid or expired token", slog.String("error", err.Error()))
+		return nil, ErrInvalidToken
+	}
+	user, err := h.store.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if !errors.Is(err, store.ErrUserNotFound) {
+			h.logger.ErrorContext(ctx, "Failed to load user for token", slog.String("userID", claims.UserID), slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	if user.IsSuspended(time.Now().UTC()) {
+		return nil, &AccountSuspendedError{User: user}
+	}
+	return claims, nil
+}
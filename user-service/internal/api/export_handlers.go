@@ -0,0 +1,186 @@
+// user-service/internal/api/export_handlers.go
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/export"
+	"user-service/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// exportFormat читает формат архива из query параметра format (по умолчанию json).
+func exportFormat(r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return domain.ExportFormatJSON, true
+	}
+	return format, export.IsValidFormat(format)
+}
+
+// writeExportArchive отдает архив как файл для скачивания.
+func (h *HTTPHandler) writeExportArchive(w http.ResponseWriter, r *http.Request, userID string, format string, generatedAt time.Time, archive []byte) {
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.FileName(userID, format, generatedAt)+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(archive); err != nil {
+		h.logger.ErrorContext(r.Context(), "Failed to write export archive", slog.String("userID", userID), slog.String("error", err.Error()))
+	}
+}
+
+// exportJobResponse добавляет к выгрузке ссылку на скачивание, если архив готов.
+func exportJobResponse(job *domain.ExportJob) domain.ExportJobResponse {
+	response := domain.ExportJobResponse{ExportJob: job}
+	if job.Status == domain.ExportJobCompleted {
+		response.DownloadURL = "/api/users/me/export/jobs/" + job.ID + "/download"
+	}
+	return response
+}
+
+// ExportData синхронно собирает и отдает архив с данными пользователя (GET /api/users/me/export?format=json|zip).
+// Для аккаунтов с большим количеством данных следует использовать асинхронную выгрузку (StartExportJob).
+func (h *HTTPHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for ExportData")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	format, ok := exportFormat(r)
+	if !ok {
+		h.respondError(w, r, http.StatusBadRequest, "Unsupported format; use json or zip")
+		return
+	}
+
+	data, err := h.exporter.Collect(ctx, userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to collect user data for export", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusServiceUnavailable, "Failed to collect data; try again later or request an asynchronous export")
+		return
+	}
+	archive, err := export.Encode(data, format)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to encode export archive", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to build export")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User data exported", slog.String("userID", userID), slog.String("format", format), slog.Int("reviews", len(data.Reviews)), slog.Int("movies", len(data.SubmittedMovies)))
+	h.writeExportArchive(w, r, userID, format, data.GeneratedAt, archive)
+}
+
+// StartExportJob ставит в очередь асинхронную выгрузку (POST /api/users/me/export/jobs?format=json|zip).
+// Если у пользователя уже есть незавершенная выгрузка, возвращается она.
+func (h *HTTPHandler) StartExportJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for StartExportJob")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	format, ok := exportFormat(r)
+	if !ok {
+		h.respondError(w, r, http.StatusBadRequest, "Unsupported format; use json or zip")
+		return
+	}
+
+	job, err := h.store.GetActiveExportJob(ctx, userID)
+	switch {
+	case err == nil:
+		h.logger.InfoContext(ctx, "Export job already in progress", slog.String("userID", userID), slog.String("jobID", job.ID))
+	case errors.Is(err, store.ErrExportJobNotFound):
+		job = &domain.ExportJob{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Format:    format,
+			Status:    domain.ExportJobPending,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := h.store.CreateExportJob(ctx, job); err != nil {
+			h.logger.ErrorContext(ctx, "Failed to create export job", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to start export")
+			return
+		}
+		h.logger.InfoContext(ctx, "Export job queued", slog.String("userID", userID), slog.String("jobID", job.ID), slog.String("format", format))
+	default:
+		h.logger.ErrorContext(ctx, "Failed to check active export job", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to start export")
+		return
+	}
+
+	w.Header().Set("Location", "/api/users/me/export/jobs/"+job.ID)
+	h.respondJSON(w, r, http.StatusAccepted, exportJobResponse(job))
+}
+
+// ownExportJob возвращает выгрузку текущего пользователя; чужие выгрузки неотличимы от несуществующих.
+func (h *HTTPHandler) ownExportJob(w http.ResponseWriter, r *http.Request) (*domain.ExportJob, bool) {
+	ctx := r.Context()
+	userID, _ := ctx.Value(UserIDKey).(string)
+	jobID := mux.Vars(r)["jobId"]
+
+	job, err := h.store.GetExportJob(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, store.ErrExportJobNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Export job not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get export job", slog.String("jobID", jobID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve export job")
+		}
+		return nil, false
+	}
+	if job.UserID != userID {
+		h.respondError(w, r, http.StatusNotFound, "Export job not found")
+		return nil, false
+	}
+	return job, true
+}
+
+// GetExportJob возвращает состояние асинхронной выгрузки (GET /api/users/me/export/jobs/{jobId}).
+func (h *HTTPHandler) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.ownExportJob(w, r)
+	if !ok {
+		return
+	}
+	h.respondJSON(w, r, http.StatusOK, exportJobResponse(job))
+}
+
+// DownloadExportJob отдает готовый архив асинхронной выгрузки (GET /api/users/me/export/jobs/{jobId}/download).
+func (h *HTTPHandler) DownloadExportJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	job, ok := h.ownExportJob(w, r)
+	if !ok {
+		return
+	}
+	if job.Status != domain.ExportJobCompleted {
+		h.respondError(w, r, http.StatusConflict, "Export is not ready (status: "+string(job.Status)+")")
+		return
+	}
+
+	archive, err := h.store.GetExportArchive(ctx, job.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrExportJobNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Export job not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to load export archive", slog.String("jobID", job.ID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to download export")
+		}
+		return
+	}
+
+	generatedAt := job.CreatedAt
+	if job.CompletedAt != nil {
+		generatedAt = *job.CompletedAt
+	}
+	h.writeExportArchive(w, r, job.UserID, job.Format, generatedAt, archive)
+}
@@ -0,0 +1,92 @@
+// user-service/internal/api/export_handlers_test.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestExportJobLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	owner := env.tokens(t, env.createUser(t, "owner", "user")).Token
+	stranger := env.tokens(t, env.createUser(t, "stranger", "user")).Token
+
+	rec := env.do(t, http.MethodPost, "/api/users/me/export/jobs?format=zip", owner, nil)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("start: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var started domain.ExportJobResponse
+	decodeJSON(t, rec, &started)
+	if started.Status != domain.ExportJobPending || started.Format != domain.ExportFormatZip || started.DownloadURL != "" {
+		t.Errorf("started job = %+v, want pending zip without download_url", started)
+	}
+	jobPath := "/api/users/me/export/jobs/" + started.ID
+	if loc := rec.Header().Get("Location"); loc != jobPath {
+		t.Errorf("Location = %q, want %q", loc, jobPath)
+	}
+
+	// Пока выгрузка не завершена, повторный запрос возвращает ее же
+	rec = env.do(t, http.MethodPost, "/api/users/me/export/jobs", owner, nil)
+	var repeated domain.ExportJobResponse
+	decodeJSON(t, rec, &repeated)
+	if rec.Code != http.StatusAccepted || repeated.ID != started.ID {
+		t.Errorf("repeated start: code = %d, job %s, want 202 and job %s", rec.Code, repeated.ID, started.ID)
+	}
+
+	rec = env.do(t, http.MethodGet, jobPath+"/download", owner, nil)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("download before completion: code = %d, want 409", rec.Code)
+	}
+
+	completedAt := time.Now().UTC()
+	if err := env.store.CompleteExportJob(context.Background(), started.ID, []byte("archive"), completedAt, completedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("CompleteExportJob: %v", err)
+	}
+
+	rec = env.do(t, http.MethodGet, jobPath, owner, nil)
+	var completed domain.ExportJobResponse
+	decodeJSON(t, rec, &completed)
+	if completed.Status != domain.ExportJobCompleted || completed.DownloadURL != jobPath+"/download" {
+		t.Errorf("completed job = %+v, want completed with download_url", completed)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{name: "owner downloads archive", method: http.MethodGet, path: jobPath + "/download", token: owner, wantCode: http.StatusOK},
+		{name: "other user cannot see job", method: http.MethodGet, path: jobPath, token: stranger, wantCode: http.StatusNotFound},
+		{name: "other user cannot download", method: http.MethodGet, path: jobPath + "/download", token: stranger, wantCode: http.StatusNotFound},
+		{name: "unknown job", method: http.MethodGet, path: "/api/users/me/export/jobs/no-such-job", token: owner, wantCode: http.StatusNotFound},
+		{name: "unsupported format", method: http.MethodPost, path: "/api/users/me/export/jobs?format=xml", token: owner, wantCode: http.StatusBadRequest},
+		{name: "anonymous", method: http.MethodGet, path: jobPath, wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.token, nil)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode == http.StatusOK {
+				if rec.Body.String() != "archive" || rec.Header().Get("Content-Type") != "application/zip" {
+					t.Errorf("download: Content-Type %q, body %q", rec.Header().Get("Content-Type"), rec.Body.String())
+				}
+			}
+		})
+	}
+
+	// После завершения можно запросить новую выгрузку
+	rec = env.do(t, http.MethodPost, "/api/users/me/export/jobs", owner, nil)
+	var next domain.ExportJobResponse
+	decodeJSON(t, rec, &next)
+	if rec.Code != http.StatusAccepted || next.ID == started.ID {
+		t.Errorf("start after completion: code = %d, job %s, want a new job", rec.Code, next.ID)
+	}
+}
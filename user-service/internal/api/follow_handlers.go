@@ -0,0 +1,128 @@
+// user-service/internal/api/follow_handlers.go
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// Размер страницы списков подписчиков и подписок
+const (
+	defaultFollowPageSize = 20
+	maxFollowPageSize     = 100
+)
+
+// FollowUser подписывает текущего пользователя на пользователя из пути (POST /api/users/me/following/{username}).
+// Повторная подписка не является ошибкой.
+func (h *HTTPHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.respondError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	target, ok := h.getListedUser(w, r)
+	if !ok {
+		return
+	}
+	if target.ID == userID {
+		h.respondError(w, r, http.StatusBadRequest, "You cannot follow yourself")
+		return
+	}
+
+	if err := h.store.Follow(ctx, userID, target.ID); err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User not found")
+			return
+		}
+		h.logger.ErrorContext(ctx, "Failed to follow user", slog.String("userID", userID), slog.String("targetID", target.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to follow user")
+		return
+	}
+	h.logger.InfoContext(ctx, "User followed", slog.String("userID", userID), slog.String("targetID", target.ID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnfollowUser отменяет подписку текущего пользователя (DELETE /api/users/me/following/{username}).
+// Отписаться можно и от заблокированного аккаунта; отсутствие подписки не является ошибкой.
+func (h *HTTPHandler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.respondError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	username := mux.Vars(r)["username"]
+	target, err := h.store.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user by username for unfollow", slog.String("username", username), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to unfollow user")
+		}
+		return
+	}
+
+	if err := h.store.Unfollow(ctx, userID, target.ID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to unfollow user", slog.String("userID", userID), slog.String("targetID", target.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to unfollow user")
+		return
+	}
+	h.logger.InfoContext(ctx, "User unfollowed", slog.String("userID", userID), slog.String("targetID", target.ID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListFollowers возвращает подписчиков пользователя (GET /api/users/{username}/followers). Параметры: page, limit.
+func (h *HTTPHandler) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.store.ListFollowers)
+}
+
+// ListFollowing возвращает подписки пользователя (GET /api/users/{username}/following). Параметры: page, limit.
+func (h *HTTPHandler) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.store.ListFollowing)
+}
+
+// listFollows - общая часть ListFollowers и ListFollowing.
+func (h *HTTPHandler) listFollows(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error)) {
+	ctx := r.Context()
+	user, ok := h.getListedUser(w, r)
+	if !ok {
+		return
+	}
+
+	queryParams := r.URL.Query()
+	page, _ := strconv.Atoi(queryParams.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(queryParams.Get("limit"))
+	if pageSize <= 0 {
+		pageSize = defaultFollowPageSize
+	} else if pageSize > maxFollowPageSize {
+		pageSize = maxFollowPageSize
+	}
+
+	entries, totalCount, err := list(ctx, user.ID, page, pageSize)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list follows", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve users")
+		return
+	}
+	h.respondJSON(w, r, http.StatusOK, &domain.FollowListResponse{
+		Users:      entries,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
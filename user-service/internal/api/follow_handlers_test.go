@@ -0,0 +1,138 @@
+// user-service/internal/api/follow_handlers_test.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"user-service/internal/domain"
+)
+
+// followUsernames возвращает имена пользователей из ответа ListFollowers/ListFollowing.
+func followUsernames(t *testing.T, env *testEnv, path string) []string {
+	t.Helper()
+	rec := env.do(t, http.MethodGet, path, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: code = %d, body %s", path, rec.Code, rec.Body.String())
+	}
+	var resp domain.FollowListResponse
+	decodeJSON(t, rec, &resp)
+	if resp.TotalCount != len(resp.Users) {
+		t.Errorf("GET %s: total_count = %d, but %d users on the only page", path, resp.TotalCount, len(resp.Users))
+	}
+	names := make([]string, 0, len(resp.Users))
+	for _, entry := range resp.Users {
+		names = append(names, entry.Username)
+	}
+	return names
+}
+
+func followCounts(t *testing.T, env *testEnv, username string) domain.FollowCounts {
+	t.Helper()
+	rec := env.do(t, http.MethodGet, "/api/users/"+username, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET profile %s: code = %d, body %s", username, rec.Code, rec.Body.String())
+	}
+	var profile domain.PublicProfile
+	decodeJSON(t, rec, &profile)
+	return profile.FollowCounts
+}
+
+func TestFollowAndUnfollow(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.tokens(t, env.createUser(t, "alice", "user")).Token
+	carol := env.tokens(t, env.createUser(t, "carol", "user")).Token
+	env.createUser(t, "bob", "user")
+
+	// Подписка идемпотентна
+	for i := 0; i < 2; i++ {
+		if rec := env.do(t, http.MethodPost, "/api/users/me/following/bob", alice, nil); rec.Code != http.StatusNoContent {
+			t.Fatalf("follow #%d: code = %d, body %s", i+1, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := env.do(t, http.MethodPost, "/api/users/me/following/bob", carol, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("carol follows bob: code = %d", rec.Code)
+	}
+
+	followers := followUsernames(t, env, "/api/users/bob/followers")
+	if len(followers) != 2 || followers[0] != "carol" || followers[1] != "alice" {
+		t.Errorf("bob's followers = %v, want [carol alice] (newest first)", followers)
+	}
+	if following := followUsernames(t, env, "/api/users/alice/following"); len(following) != 1 || following[0] != "bob" {
+		t.Errorf("alice follows %v, want [bob]", following)
+	}
+	if counts := followCounts(t, env, "bob"); counts.Followers != 2 || counts.Following != 0 {
+		t.Errorf("bob's counts = %+v, want 2 followers", counts)
+	}
+
+	// Отписка тоже идемпотентна
+	for i := 0; i < 2; i++ {
+		if rec := env.do(t, http.MethodDelete, "/api/users/me/following/bob", alice, nil); rec.Code != http.StatusNoContent {
+			t.Fatalf("unfollow #%d: code = %d, body %s", i+1, rec.Code, rec.Body.String())
+		}
+	}
+	if followers := followUsernames(t, env, "/api/users/bob/followers"); len(followers) != 1 || followers[0] != "carol" {
+		t.Errorf("bob's followers after unfollow = %v, want [carol]", followers)
+	}
+	if counts := followCounts(t, env, "alice"); counts.Following != 0 {
+		t.Errorf("alice's counts after unfollow = %+v, want 0 following", counts)
+	}
+}
+
+func TestFollowRejected(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.tokens(t, env.createUser(t, "alice", "user")).Token
+	banned := env.createUser(t, "banned", "user")
+	if err := env.store.Suspend(context.Background(), banned.ID, "spam", nil); err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		username string
+		token    string
+		wantCode int
+	}{
+		{name: "yourself", method: http.MethodPost, username: "alice", token: alice, wantCode: http.StatusBadRequest},
+		{name: "unknown user", method: http.MethodPost, username: "nobody", token: alice, wantCode: http.StatusNotFound},
+		{name: "suspended user", method: http.MethodPost, username: "banned", token: alice, wantCode: http.StatusNotFound},
+		{name: "anonymous", method: http.MethodPost, username: "banned", wantCode: http.StatusUnauthorized},
+		{name: "unfollow suspended user", method: http.MethodDelete, username: "banned", token: alice, wantCode: http.StatusNoContent},
+		{name: "unfollow unknown user", method: http.MethodDelete, username: "nobody", token: alice, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, "/api/users/me/following/"+tt.username, tt.token, nil)
+			if rec.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestFollowListsHideSuspendedAccounts(t *testing.T) {
+	env := newTestEnv(t)
+	env.createUser(t, "star", "user")
+	fan := env.createUser(t, "fan", "user")
+	troll := env.createUser(t, "troll", "user")
+	for _, user := range []*domain.User{fan, troll} {
+		if rec := env.do(t, http.MethodPost, "/api/users/me/following/star", env.tokens(t, user).Token, nil); rec.Code != http.StatusNoContent {
+			t.Fatalf("%s follows star: code = %d", user.Username, rec.Code)
+		}
+	}
+	if err := env.store.Suspend(context.Background(), troll.ID, "abuse", nil); err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+
+	if followers := followUsernames(t, env, "/api/users/star/followers"); len(followers) != 1 || followers[0] != "fan" {
+		t.Errorf("star's followers = %v, want [fan]", followers)
+	}
+	if counts := followCounts(t, env, "star"); counts.Followers != 1 {
+		t.Errorf("star's followers_count = %d, want 1", counts.Followers)
+	}
+	if rec := env.do(t, http.MethodGet, "/api/users/troll/following", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("following list of a suspended user: code = %d, want 404", rec.Code)
+	}
+}
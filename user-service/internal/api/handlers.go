@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"user-service/internal/domain"
+	"user-service/internal/export"
+	"user-service/internal/mailer"
 	"user-service/internal/store"
 	"user-service/pkg/auth" // Наш пакет для хеширования и JWT
 
@@ -16,20 +18,40 @@ import (
 	"github.com/google/uuid"
 )
 
+// Config содержит настраиваемые параметры HTTP слоя UserService.
+type Config struct {
+	RefreshTokenDuration           time.Duration       // Время жизни refresh токена
+	PasswordResetTokenDuration     time.Duration       // Время жизни ссылки на сброс пароля
+	EmailVerificationTokenDuration time.Duration       // Время жизни ссылки на подтверждение email
+	VerificationResendInterval     time.Duration       // Минимальный интервал между письмами подтверждения
+	LoginThrottle                  LoginThrottleConfig // Защита входа от перебора паролей
+	LoginChallengeDuration         time.Duration       // Время жизни challenge токена второго шага входа (2FA)
+	TOTPIssuer                     string              // Название сервиса в приложении-аутентификаторе
+	RequirePrivilegedTwoFactor     bool                // Роли с разрешениями попадают в токен только при включенной 2FA
+	AccountDeletionGracePeriod     time.Duration       // Через сколько после запроса аккаунт удаляется безвозвратно
+	AppBaseURL                     string              // Базовый URL фронтенда для ссылок в письмах
+}
+
 // HTTPHandler (структура и NewHTTPHandler остаются прежними)
 type HTTPHandler struct {
 	store        store.UserStore
 	logger       *slog.Logger
 	validator    *validator.Validate
 	tokenManager auth.TokenManager
+	mailer       mailer.Mailer
+	exporter     *export.Builder // Сборка выгрузки персональных данных
+	config       Config
 }
 
-func NewHTTPHandler(s store.UserStore, l *slog.Logger, v *validator.Validate, tm auth.TokenManager) *HTTPHandler {
+func NewHTTPHandler(s store.UserStore, l *slog.Logger, v *validator.Validate, tm auth.TokenManager, m mailer.Mailer, ex *export.Builder, cfg Config) *HTTPHandler {
 	return &HTTPHandler{
 		store:        s,
 		logger:       l,
 		validator:    v,
 		tokenManager: tm,
+		mailer:       m,
+		exporter:     ex,
+		config:       cfg,
 	}
 }
 
@@ -48,7 +70,7 @@ func (h *HTTPHandler) respondError(w http.ResponseWriter, r *http.Request, statu
 	h.respondJSON(w, r, status, map[string]string{"error": message})
 }
 
-// RegisterUser (остается прежним)
+// RegisterUser регистрирует пользователя (POST /api/users/register), см. Register.
 func (h *HTTPHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	h.logger.InfoContext(ctx, "HTTP RegisterUser request received", slog.String("path", r.URL.Path))
@@ -61,53 +83,35 @@ func (h *HTTPHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	if err := h.validator.StructCtx(ctx, req); err != nil {
-		h.logger.ErrorContext(ctx, "Registration request validation failed", slog.String("error", err.Error()))
-		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
-		return
-	}
-
-	hashedPassword, err := auth.HashPassword(req.Password)
+	newUser, err := h.Register(ctx, req)
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to hash password", slog.String("error", err.Error()))
-		h.respondError(w, r, http.StatusInternalServerError, "Error processing registration")
-		return
-	}
-
-	newUser := &domain.User{
-		ID:           uuid.NewString(),
-		Username:     req.Username,
-		Email:        req.Email,
-		PasswordHash: hashedPassword,
-		Role:         "user",
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-	}
-
-	if err := h.store.Create(ctx, newUser); err != nil {
-		h.logger.ErrorContext(ctx, "Failed to create user in store", slog.String("error", err.Error()))
-		if errors.Is(err, store.ErrUserAlreadyExists) {
+		var validationErrs validator.ValidationErrors
+		switch {
+		case errors.As(err, &validationErrs):
+			h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		case errors.Is(err, ErrUsernameReserved):
+			h.respondError(w, r, http.StatusBadRequest, "This username is reserved")
+		case errors.Is(err, store.ErrUserAlreadyExists):
 			h.respondError(w, r, http.StatusConflict, "User with this email or username already exists")
-		} else {
+		default:
 			h.respondError(w, r, http.StatusInternalServerError, "Failed to register user")
 		}
 		return
 	}
 
 	userResponse := &domain.User{
-		ID:        newUser.ID,
-		Username:  newUser.Username,
-		Email:     newUser.Email,
-		Role:      newUser.Role,
-		CreatedAt: newUser.CreatedAt,
-		UpdatedAt: newUser.UpdatedAt,
+		ID:         newUser.ID,
+		Username:   newUser.Username,
+		Email:      newUser.Email,
+		Role:       newUser.Role,
+		VerifiedAt: newUser.VerifiedAt,
+		CreatedAt:  newUser.CreatedAt,
+		UpdatedAt:  newUser.UpdatedAt,
 	}
-
-	h.logger.InfoContext(ctx, "User registered successfully", slog.String("userID", newUser.ID), slog.String("username", newUser.Username))
 	h.respondJSON(w, r, http.StatusCreated, userResponse)
 }
 
-// LoginUser (остается прежним)
+// LoginUser - первый шаг входа по email и паролю (POST /api/users/login), см. Login.
 func (h *HTTPHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	h.logger.InfoContext(ctx, "HTTP LoginUser request received", slog.String("path", r.URL.Path))
@@ -120,51 +124,66 @@ func (h *HTTPHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	if err := h.validator.StructCtx(ctx, req); err != nil {
-		h.logger.ErrorContext(ctx, "Login request validation failed", slog.String("error", err.Error()))
-		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
-		return
-	}
-
-	user, err := h.store.GetByEmail(ctx, req.Email)
+	result, err := h.Login(ctx, req, h.clientIP(r))
 	if err != nil {
-		if errors.Is(err, store.ErrUserNotFound) {
-			h.logger.WarnContext(ctx, "Login attempt for non-existent email", slog.String("email", req.Email))
+		var validationErrs validator.ValidationErrors
+		var throttled *LoginThrottledError
+		var suspended *AccountSuspendedError
+		switch {
+		case errors.As(err, &validationErrs):
+			h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		case errors.As(err, &throttled):
+			h.respondTooManyLoginAttempts(w, r, throttled.RetryAfter)
+		case errors.Is(err, ErrInvalidCredentials):
 			h.respondError(w, r, http.StatusUnauthorized, "Invalid email or password")
-		} else {
-			h.logger.ErrorContext(ctx, "Failed to get user by email from store", slog.String("email", req.Email), slog.String("error", err.Error()))
+		case errors.As(err, &suspended):
+			h.rejectSuspended(w, r, suspended.User)
+		default:
 			h.respondError(w, r, http.StatusInternalServerError, "Login failed")
 		}
 		return
 	}
 
-	if !auth.CheckPasswordHash(req.Password, user.PasswordHash) {
-		h.logger.WarnContext(ctx, "Invalid password attempt", slog.String("email", req.Email), slog.String("userID", user.ID))
-		h.respondError(w, r, http.StatusUnauthorized, "Invalid email or password")
+	if result.Challenge != nil {
+		h.respondJSON(w, r, http.StatusOK, result.Challenge)
 		return
 	}
+	h.respondJSON(w, r, http.StatusOK, newLoginResponse(result.User, result.Tokens))
+}
+
+// completeLogin выпускает токены и отвечает LoginResponse после успешной аутентификации.
+func (h *HTTPHandler) completeLogin(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	ctx := r.Context()
 
-	tokenString, err := h.tokenManager.Generate(user.ID, user.Role)
+	// Каждый логин начинает новое семейство refresh токенов
+	tokens, err := h.issueTokenPair(ctx, user, uuid.NewString())
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to generate JWT token", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.logger.ErrorContext(ctx, "Failed to issue tokens", slog.String("userID", user.ID), slog.String("error", err.Error()))
 		h.respondError(w, r, http.StatusInternalServerError, "Login failed (token generation)")
 		return
 	}
 
-	loginResponse := domain.LoginResponse{
+	h.logger.InfoContext(ctx, "User logged in successfully", slog.String("userID", user.ID), slog.String("email", user.Email))
+	h.respondJSON(w, r, http.StatusOK, newLoginResponse(user, tokens))
+}
+
+// newLoginResponse собирает ответ успешного входа (без хеша пароля и служебных полей).
+func newLoginResponse(user *domain.User, tokens *domain.TokenPairResponse) domain.LoginResponse {
+	return domain.LoginResponse{
 		User: &domain.User{
-			ID:        user.ID,
-			Username:  user.Username,
-			Email:     user.Email,
-			Role:      user.Role,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
+			ID:         user.ID,
+			Username:   user.Username,
+			Email:      user.Email,
+			Role:       user.Role,
+			VerifiedAt: user.VerifiedAt,
+			CreatedAt:  user.CreatedAt,
+			UpdatedAt:  user.UpdatedAt,
 		},
-		Token: tokenString,
+		Token:                  tokens.Token,
+		RefreshToken:           tokens.RefreshToken,
+		ExpiresIn:              tokens.ExpiresIn,
+		TwoFactorSetupRequired: tokens.TwoFactorSetupRequired,
 	}
-
-	h.logger.InfoContext(ctx, "User logged in successfully", slog.String("userID", user.ID), slog.String("email", user.Email))
-	h.respondJSON(w, r, http.StatusOK, loginResponse)
 }
 
 // GetUserProfile (остается прежним)
@@ -189,15 +208,7 @@ func (h *HTTPHandler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	userResponse := &domain.User{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
-	h.respondJSON(w, r, http.StatusOK, userResponse)
+	h.respondJSON(w, r, http.StatusOK, ownProfileResponse(user))
 }
 
 // UpdateUserProfile обновляет профиль текущего аутентифицированного пользователя.
@@ -225,6 +236,10 @@ func (h *HTTPHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request)
 		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
 		return
 	}
+	if req.Username != nil && isReservedUsername(*req.Username) {
+		h.respondError(w, r, http.StatusBadRequest, "This username is reserved")
+		return
+	}
 
 	// Получаем текущего пользователя из хранилища
 	currentUser, err := h.store.GetByID(ctx, userID)
@@ -237,32 +252,25 @@ func (h *HTTPHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request)
 
 	// Обновляем поля, если они были предоставлены в запросе
 	updated := false
+	emailChanged := false
 	if req.Username != nil {
 		currentUser.Username = *req.Username
 		updated = true
 	}
 	if req.Email != nil {
-		// TODO: Добавить проверку на уникальность нового email, если он меняется
-		// и если он отличается от текущего email пользователя.
-		// userWithNewEmail, err := h.store.GetByEmail(ctx, *req.Email)
-		// if err == nil && userWithNewEmail.ID != userID {
-		//    h.respondError(w, r, http.StatusConflict, "Email already in use by another account")
-		//    return
-		// }
-		// if err != nil && !errors.Is(err, store.ErrUserNotFound) {
-		//    h.logger.ErrorContext(ctx, "Error checking new email uniqueness", slog.String("email", *req.Email), slog.String("error", err.Error()))
-		//    h.respondError(w, r, http.StatusInternalServerError, "Failed to update profile")
-		//    return
-		// }
+		// Занятый адрес отклоняет уникальный индекс: store.Update вернет ErrUserAlreadyExists
+		emailChanged = *req.Email != currentUser.Email
 		currentUser.Email = *req.Email
 		updated = true
 	}
+	if applyProfileUpdate(currentUser, &req) {
+		updated = true
+	}
 
 	if updated {
 		currentUser.UpdatedAt = time.Now().UTC()
 		if err := h.store.Update(ctx, currentUser); err != nil {
-			// Обработка возможных ошибок от store.Update, например, если новый email/username уже занят (если store это проверяет)
-			if errors.Is(err, store.ErrUserAlreadyExists) { // Предполагаем, что store.Update может вернуть эту ошибку
+			if errors.Is(err, store.ErrUserAlreadyExists) {
 				h.respondError(w, r, http.StatusConflict, "Username or email may already be in use.")
 			} else {
 				h.logger.ErrorContext(ctx, "Failed to update user profile in store", slog.String("userID", userID), slog.String("error", err.Error()))
@@ -272,13 +280,13 @@ func (h *HTTPHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	userResponse := &domain.User{
-		ID:        currentUser.ID,
-		Username:  currentUser.Username,
-		Email:     currentUser.Email,
-		Role:      currentUser.Role,
-		CreatedAt: currentUser.CreatedAt,
-		UpdatedAt: currentUser.UpdatedAt,
+	// Новый адрес нужно подтвердить заново; store.Update уже снял отметку о подтверждении
+	if emailChanged {
+		currentUser.VerifiedAt = nil
+		if err := h.sendVerificationEmail(ctx, currentUser); err != nil {
+			h.logger.ErrorContext(ctx, "Failed to send verification email for new address", slog.String("userID", userID), slog.String("error", err.Error()))
+		}
 	}
-	h.respondJSON(w, r, http.StatusOK, userResponse)
+
+	h.respondJSON(w, r, http.StatusOK, ownProfileResponse(currentUser))
 }
@@ -0,0 +1,201 @@
+// user-service/internal/api/handlers_test.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/mailer"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const testPassword = "correct-horse-battery"
+
+// recordingMailer запоминает отправленные письма.
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// sentTo возвращает письма на адрес to в порядке отправки.
+func (m *recordingMailer) sentTo(to string) []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var messages []mailer.Message
+	for _, msg := range m.messages {
+		if msg.To == to {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// testEnv - HTTPHandler поверх MockUserStore вместе с настоящим роутером.
+type testEnv struct {
+	handler *HTTPHandler
+	router  *mux.Router
+	store   *store.MockUserStore
+	mailer  *recordingMailer
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	key, err := auth.GenerateEd25519SigningKey()
+	if err != nil {
+		t.Fatalf("GenerateEd25519SigningKey: %v", err)
+	}
+	tokenManager, err := auth.NewTokenManager([]auth.SigningKey{key}, key.KeyID, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("NewTokenManager: %v", err)
+	}
+	userStore := store.NewMockUserStore()
+	recorder := &recordingMailer{}
+	cfg := Config{
+		RefreshTokenDuration:           24 * time.Hour,
+		PasswordResetTokenDuration:     time.Hour,
+		EmailVerificationTokenDuration: time.Hour,
+		VerificationResendInterval:     time.Minute,
+		LoginThrottle: LoginThrottleConfig{
+			Account:       LoginThrottleRule{BackoffAfter: 3, LockoutThreshold: 10, LockoutDuration: 15 * time.Minute},
+			IP:            LoginThrottleRule{BackoffAfter: 20, LockoutThreshold: 100, LockoutDuration: 15 * time.Minute},
+			BaseDelay:     time.Second,
+			MaxDelay:      5 * time.Minute,
+			FailureWindow: time.Hour,
+		},
+		LoginChallengeDuration:     5 * time.Minute,
+		TOTPIssuer:                 "MovieApp",
+		AccountDeletionGracePeriod: 7 * 24 * time.Hour,
+		AppBaseURL:                 "http://app.test",
+	}
+	handler := NewHTTPHandler(userStore, slog.New(slog.NewTextHandler(io.Discard, nil)), validator.New(), tokenManager, recorder, nil, cfg)
+	return &testEnv{handler: handler, router: NewHTTPRouter(handler), store: userStore, mailer: recorder}
+}
+
+// createUser сохраняет в хранилище пользователя с подтвержденным email и паролем testPassword.
+func (e *testEnv) createUser(t *testing.T, username, role string) *domain.User {
+	t.Helper()
+	hash, err := auth.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	verifiedAt := time.Now().UTC().Add(-time.Hour)
+	user := &domain.User{
+		ID:           uuid.NewString(),
+		Username:     username,
+		Email:        username + "@example.com",
+		PasswordHash: hash,
+		Role:         role,
+		VerifiedAt:   &verifiedAt,
+	}
+	if err := e.store.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create user %s: %v", username, err)
+	}
+	return user
+}
+
+// tokens выпускает пользователю пару токенов в новом семействе, как при входе.
+func (e *testEnv) tokens(t *testing.T, user *domain.User) *domain.TokenPairResponse {
+	t.Helper()
+	pair, err := e.handler.issueTokenPair(context.Background(), user, uuid.NewString())
+	if err != nil {
+		t.Fatalf("issueTokenPair: %v", err)
+	}
+	return pair
+}
+
+// do выполняет запрос через роутер; accessToken добавляется в заголовок Authorization, если не пуст.
+func (e *testEnv) do(t *testing.T, method, path, accessToken string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal request body: %v", err)
+		}
+		reader = strings.NewReader(string(data))
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeJSON разбирает тело ответа в v.
+func decodeJSON(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+}
+
+func TestUpdateUserProfileEmail(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	tests := []struct {
+		name         string
+		email        string // Новый адрес; "taken" - адрес другого пользователя
+		wantCode     int
+		wantVerified bool
+		wantEmail    bool // Отправлено ли письмо подтверждения на новый адрес
+	}{
+		{name: "same email keeps verification", email: "owner@example.com", wantCode: http.StatusOK, wantVerified: true},
+		{name: "new email resets verification", email: "owner-new@example.com", wantCode: http.StatusOK, wantEmail: true},
+		{name: "email of another account", email: "taken", wantCode: http.StatusConflict, wantVerified: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			user := env.createUser(t, "owner", "user")
+			other := env.createUser(t, "other", "user")
+			email := tt.email
+			if email == "taken" {
+				email = other.Email
+			}
+
+			rec := env.do(t, http.MethodPut, "/api/users/me", env.tokens(t, user).Token, domain.UpdateProfileRequest{Email: strPtr(email)})
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			stored, err := env.store.GetByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if stored.IsVerified() != tt.wantVerified {
+				t.Errorf("stored verified = %v, want %v", stored.IsVerified(), tt.wantVerified)
+			}
+			if tt.wantCode == http.StatusOK {
+				var resp domain.User
+				decodeJSON(t, rec, &resp)
+				if resp.IsVerified() != tt.wantVerified {
+					t.Errorf("response verified = %v, want %v", resp.IsVerified(), tt.wantVerified)
+				}
+			}
+			if sent := len(env.mailer.sentTo(email)) > 0 && email != other.Email; sent != tt.wantEmail {
+				t.Errorf("verification email sent = %v, want %v", sent, tt.wantEmail)
+			}
+		})
+	}
+}
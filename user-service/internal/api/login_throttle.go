@@ -0,0 +1,148 @@
+// user-service/internal/api/login_throttle.go
+package api
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// LoginThrottleRule задает правила для одного вида ключа (аккаунт или IP).
+type LoginThrottleRule struct {
+	BackoffAfter     int           // С какой неудачной попытки включается экспоненциальная задержка
+	LockoutThreshold int           // После скольких неудач подряд вход блокируется; 0 - без блокировки
+	LockoutDuration  time.Duration // Длительность блокировки
+}
+
+// LoginThrottleConfig - настройки защиты входа от перебора паролей.
+type LoginThrottleConfig struct {
+	Account       LoginThrottleRule
+	IP            LoginThrottleRule
+	BaseDelay     time.Duration // Задержка после первой "штрафной" попытки, далее удваивается
+	MaxDelay      time.Duration // Верхняя граница задержки
+	FailureWindow time.Duration // Счетчик сбрасывается, если последняя неудача была раньше
+	// TrustProxyHeaders разрешает брать IP клиента из X-Forwarded-For
+	// (включать только за доверенным reverse proxy).
+	TrustProxyHeaders bool
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// ipThrottleKeys возвращает ключи счетчиков для адресов клиента (повторяющиеся адреса учитываются один раз).
+func ipThrottleKeys(ips ...string) []string {
+	keys := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if key := "ip:" + ip; !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// clientIP определяет IP клиента для счетчика попыток входа.
+func (h *HTTPHandler) clientIP(r *http.Request) string {
+	if h.config.LoginThrottle.TrustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// Первый адрес в списке - исходный клиент
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// retryAfter возвращает, сколько еще нужно ждать до следующей попытки по этому ключу.
+func (c LoginThrottleConfig) retryAfter(attempts *domain.LoginAttempts, rule LoginThrottleRule, now time.Time) time.Duration {
+	var wait time.Duration
+	if attempts.LockedUntil != nil && attempts.LockedUntil.After(now) {
+		wait = attempts.LockedUntil.Sub(now)
+	}
+	if attempts.LastFailureAt == nil || rule.BackoffAfter <= 0 || attempts.Failures < rule.BackoffAfter {
+		return wait
+	}
+	if attempts.LastFailureAt.Before(now.Add(-c.FailureWindow)) {
+		return wait
+	}
+
+	// Экспоненциальная задержка: BaseDelay, 2*BaseDelay, 4*BaseDelay, ... но не больше MaxDelay
+	delay := c.MaxDelay
+	if shift := attempts.Failures - rule.BackoffAfter; shift < 32 {
+		if d := c.BaseDelay << shift; d > 0 && d < c.MaxDelay {
+			delay = d
+		}
+	}
+	if backoff := attempts.LastFailureAt.Add(delay).Sub(now); backoff > wait {
+		wait = backoff
+	}
+	return wait
+}
+
+// loginRetryAfter проверяет ключи аккаунта и IP и возвращает наибольшее время ожидания.
+func (h *HTTPHandler) loginRetryAfter(ctx context.Context, accountKey string, ipKeys []string) (time.Duration, error) {
+	cfg := h.config.LoginThrottle
+	now := time.Now().UTC()
+
+	accountAttempts, err := h.store.GetLoginAttempts(ctx, accountKey)
+	if err != nil {
+		return 0, err
+	}
+	wait := cfg.retryAfter(accountAttempts, cfg.Account, now)
+	for _, ipKey := range ipKeys {
+		ipAttempts, err := h.store.GetLoginAttempts(ctx, ipKey)
+		if err != nil {
+			return 0, err
+		}
+		if ipWait := cfg.retryAfter(ipAttempts, cfg.IP, now); ipWait > wait {
+			wait = ipWait
+		}
+	}
+	return wait, nil
+}
+
+// recordLoginFailure учитывает неудачную попытку для аккаунта и IP и при превышении порога блокирует ключ.
+// Ошибки хранилища только логируются: ответ клиенту (401) от них не зависит.
+func (h *HTTPHandler) recordLoginFailure(ctx context.Context, accountKey string, ipKeys []string) {
+	cfg := h.config.LoginThrottle
+	type throttleKey struct {
+		key  string
+		rule LoginThrottleRule
+	}
+	keys := []throttleKey{{accountKey, cfg.Account}}
+	for _, ipKey := range ipKeys {
+		keys = append(keys, throttleKey{ipKey, cfg.IP})
+	}
+	for _, item := range keys {
+		attempts, err := h.store.RecordLoginFailure(ctx, item.key, cfg.FailureWindow)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to record login failure", slog.String("key", item.key), slog.String("error", err.Error()))
+			continue
+		}
+		if item.rule.LockoutThreshold > 0 && attempts.Failures >= item.rule.LockoutThreshold {
+			until := time.Now().UTC().Add(item.rule.LockoutDuration)
+			if err := h.store.LockLogin(ctx, item.key, until); err != nil {
+				h.logger.ErrorContext(ctx, "Failed to lock login", slog.String("key", item.key), slog.String("error", err.Error()))
+				continue
+			}
+			h.logger.WarnContext(ctx, "Too many failed login attempts, login locked", slog.String("key", item.key), slog.Int("failures", attempts.Failures), slog.Time("locked_until", until))
+		}
+	}
+}
+
+// respondTooManyLoginAttempts отвечает 429 с заголовком Retry-After (в секундах).
+func (h *HTTPHandler) respondTooManyLoginAttempts(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	h.respondError(w, r, http.StatusTooManyRequests, "Too many failed login attempts, please try again later")
+}
@@ -0,0 +1,48 @@
+// user-service/internal/api/login_throttle_test.go
+package api
+
+import (
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestLoginThrottleRetryAfter(t *testing.T) {
+	cfg := LoginThrottleConfig{
+		BaseDelay:     time.Second,
+		MaxDelay:      30 * time.Second,
+		FailureWindow: 15 * time.Minute,
+	}
+	rule := LoginThrottleRule{BackoffAfter: 3, LockoutThreshold: 10, LockoutDuration: 15 * time.Minute}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	tests := []struct {
+		name     string
+		attempts domain.LoginAttempts
+		want     time.Duration
+	}{
+		{name: "no failures", attempts: domain.LoginAttempts{}, want: 0},
+		{name: "below backoff threshold", attempts: domain.LoginAttempts{Failures: 2, LastFailureAt: ago(0)}, want: 0},
+		{name: "first penalised attempt waits base delay", attempts: domain.LoginAttempts{Failures: 3, LastFailureAt: ago(0)}, want: time.Second},
+		{name: "delay doubles per failure", attempts: domain.LoginAttempts{Failures: 5, LastFailureAt: ago(0)}, want: 4 * time.Second},
+		{name: "elapsed time counts towards delay", attempts: domain.LoginAttempts{Failures: 5, LastFailureAt: ago(3 * time.Second)}, want: time.Second},
+		{name: "delay already served", attempts: domain.LoginAttempts{Failures: 5, LastFailureAt: ago(10 * time.Second)}, want: 0},
+		{name: "delay capped at max", attempts: domain.LoginAttempts{Failures: 9, LastFailureAt: ago(0)}, want: 30 * time.Second},
+		{name: "huge failure count does not overflow", attempts: domain.LoginAttempts{Failures: 200, LastFailureAt: ago(0)}, want: 30 * time.Second},
+		{name: "failures outside window are forgotten", attempts: domain.LoginAttempts{Failures: 9, LastFailureAt: ago(16 * time.Minute)}, want: 0},
+		{name: "lockout outlasts backoff", attempts: domain.LoginAttempts{Failures: 10, LastFailureAt: ago(0), LockedUntil: ago(-10 * time.Minute)}, want: 10 * time.Minute},
+		{name: "expired lockout", attempts: domain.LoginAttempts{Failures: 10, LastFailureAt: ago(20 * time.Minute), LockedUntil: ago(5 * time.Minute)}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.retryAfter(&tt.attempts, rule, now); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
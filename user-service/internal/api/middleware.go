@@ -3,51 +3,58 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
-	"strings"
-)
 
-// ContextKey используется для ключей в контексте запроса.
-type ContextKey string
+	"shared/auth"
+	"shared/authz"
+	"user-service/internal/store"
+)
 
+// Ключи контекста общие с MovieService и ReviewService (shared/auth).
 const (
-	// UserIDKey ключ для хранения ID пользователя в контексте.
-	UserIDKey ContextKey = "userID"
-	// UserRoleKey ключ для хранения роли пользователя в контексте.
-	UserRoleKey ContextKey = "userRole"
+	UserIDKey   = auth.UserIDKey
+	UserRoleKey = auth.UserRoleKey
 )
 
-// AuthMiddleware проверяет JWT токен из заголовка Authorization.
-// Если токен валиден, ID пользователя и его роль добавляются в контекст запроса.
+// AuthMiddleware проверяет JWT токен из заголовка Authorization и, в отличие от auth.Middleware
+// других сервисов, состояние аккаунта (существование, блокировку).
+// Если токен валиден, ID пользователя, его роль и разрешения добавляются в контекст запроса.
 func (h *HTTPHandler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
+		tokenString, err := auth.BearerToken(r)
+		if errors.Is(err, auth.ErrMissingAuthHeader) {
 			h.logger.WarnContext(r.Context(), "Authorization header missing")
 			h.respondError(w, r, http.StatusUnauthorized, "Authorization header required")
 			return
 		}
-
-		// Ожидаем токен в формате "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			h.logger.WarnContext(r.Context(), "Invalid Authorization header format", slog.String("header", authHeader))
+		if err != nil {
+			h.logger.WarnContext(r.Context(), "Invalid Authorization header format")
 			h.respondError(w, r, http.StatusUnauthorized, "Invalid Authorization header format")
 			return
 		}
-		tokenString := parts[1]
 
-		claims, err := h.tokenManager.Validate(tokenString)
+		claims, err := h.AuthenticateToken(r.Context(), tokenString)
 		if err != nil {
-			h.logger.WarnContext(r.Context(), "Invalid or expired token", slog.String("error", err.Error()))
-			h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired token")
+			var suspended *AccountSuspendedError
+			switch {
+			case errors.Is(err, ErrInvalidToken):
+				h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired token")
+			case errors.Is(err, store.ErrUserNotFound):
+				h.respondError(w, r, http.StatusUnauthorized, "User associated with token not found")
+			case errors.As(err, &suspended):
+				h.rejectSuspended(w, r, suspended.User)
+			default:
+				h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+			}
 			return
 		}
 
 		// Добавляем информацию из токена в контекст запроса
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
 		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+		ctx = authz.WithPermissions(ctx, claims.Permissions)
 
 		h.logger.DebugContext(ctx, "Token validated successfully", slog.String("userID", claims.UserID), slog.String("role", claims.Role))
 
@@ -55,3 +62,17 @@ func (h *HTTPHandler) AuthMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// requirePermission оборачивает обработчик проверкой разрешения perm (403 при его отсутствии).
+// Должен применяться после AuthMiddleware.
+func (h *HTTPHandler) requirePermission(perm authz.Permission, handlerFunc http.HandlerFunc) http.Handler {
+	return authz.Require(perm, h.respondPermissionDenied)(handlerFunc)
+}
+
+// respondPermissionDenied отвечает 403 на запрос без нужного разрешения.
+func (h *HTTPHandler) respondPermissionDenied(w http.ResponseWriter, r *http.Request, perm authz.Permission) {
+	userID, _ := r.Context().Value(UserIDKey).(string)
+	role, _ := r.Context().Value(UserRoleKey).(string)
+	h.logger.WarnContext(r.Context(), "User without required permission attempted a restricted action", slog.String("userID", userID), slog.String("role", role), slog.String("permission", string(perm)), slog.String("path", r.URL.Path))
+	h.respondError(w, r, http.StatusForbidden, "Permission required: "+string(perm))
+}
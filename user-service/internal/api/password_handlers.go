@@ -0,0 +1,237 @@
+// user-service/internal/api/password_handlers.go
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/mailer"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+// ChangePassword меняет пароль аутентифицированного пользователя (PUT /api/users/me/password).
+// Требует текущий пароль. После смены все refresh токены отзываются (выход на всех
+// устройствах), а текущему клиенту выдается новая пара токенов.
+func (h *HTTPHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for ChangePassword")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "HTTP ChangePassword request received", slog.String("userID", userID))
+
+	var req domain.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode change password request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User associated with token not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for password change", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to change password")
+		}
+		return
+	}
+
+	if !auth.CheckPasswordHash(req.CurrentPassword, user.PasswordHash) {
+		h.logger.WarnContext(ctx, "Invalid current password on password change", slog.String("userID", userID))
+		h.respondError(w, r, http.StatusUnauthorized, "Current password is incorrect")
+		return
+	}
+	if req.CurrentPassword == req.NewPassword {
+		h.respondError(w, r, http.StatusBadRequest, "New password must differ from the current one")
+		return
+	}
+
+	if err := h.setPassword(r, user, req.NewPassword); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to change password", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+
+	tokens, err := h.issueTokenPair(ctx, user, uuid.NewString())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to issue tokens after password change", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Password changed, but failed to issue new tokens")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Password changed successfully", slog.String("userID", userID))
+	h.respondJSON(w, r, http.StatusOK, tokens)
+}
+
+// ForgotPassword отправляет письмо со ссылкой на сброс пароля (POST /api/users/password/forgot).
+// Ответ не зависит от того, существует ли аккаунт, чтобы не раскрывать зарегистрированные email.
+func (h *HTTPHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP ForgotPassword request received", slog.String("path", r.URL.Path))
+
+	var req domain.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode forgot password request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	accepted := map[string]string{"message": "If an account with this email exists, a password reset link has been sent"}
+
+	user, err := h.store.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.logger.InfoContext(ctx, "Password reset requested for non-existent email", slog.String("email", req.Email))
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for password reset", slog.String("email", req.Email), slog.String("error", err.Error()))
+		}
+		h.respondJSON(w, r, http.StatusAccepted, accepted)
+		return
+	}
+
+	if err := h.sendPasswordResetEmail(r, user); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to send password reset email", slog.String("userID", user.ID), slog.String("error", err.Error()))
+	} else {
+		h.logger.InfoContext(ctx, "Password reset email sent", slog.String("userID", user.ID))
+	}
+	h.respondJSON(w, r, http.StatusAccepted, accepted)
+}
+
+// ResetPassword устанавливает новый пароль по одноразовому токену из письма (POST /api/users/password/reset).
+func (h *HTTPHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP ResetPassword request received", slog.String("path", r.URL.Path))
+
+	var req domain.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to decode reset password request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	token, err := h.store.GetPasswordResetTokenByHash(ctx, auth.HashOpaqueToken(req.Token))
+	if err != nil {
+		if errors.Is(err, store.ErrPasswordResetTokenNotFound) {
+			h.logger.WarnContext(ctx, "Unknown password reset token presented")
+			h.respondError(w, r, http.StatusBadRequest, "Invalid or expired reset token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to look up password reset token", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to reset password")
+		}
+		return
+	}
+	if token.UsedAt != nil || time.Now().UTC().After(token.ExpiresAt) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid or expired reset token")
+		return
+	}
+
+	if err := h.store.MarkPasswordResetTokenUsed(ctx, token.ID); err != nil {
+		if errors.Is(err, store.ErrPasswordResetTokenUsed) {
+			h.respondError(w, r, http.StatusBadRequest, "Invalid or expired reset token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to mark password reset token as used", slog.String("tokenID", token.ID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to reset password")
+		}
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, token.UserID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "User of password reset token not found", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid or expired reset token")
+		return
+	}
+
+	if err := h.setPassword(r, user, req.NewPassword); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to reset password", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Password reset successfully", slog.String("userID", user.ID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setPassword хеширует и сохраняет новый пароль, затем завершает все сессии пользователя
+// и гасит оставшиеся ссылки на сброс пароля.
+func (h *HTTPHandler) setPassword(r *http.Request, user *domain.User, newPassword string) error {
+	ctx := r.Context()
+	hashedPassword, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = hashedPassword
+	if err := h.store.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := h.store.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if err := h.store.InvalidateUserPasswordResetTokens(ctx, user.ID); err != nil {
+		// Пароль уже сменен; оставшиеся ссылки все равно истекут сами
+		h.logger.WarnContext(ctx, "Failed to invalidate password reset tokens", slog.String("userID", user.ID), slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// sendPasswordResetEmail создает токен сброса пароля и отправляет ссылку пользователю.
+func (h *HTTPHandler) sendPasswordResetEmail(r *http.Request, user *domain.User) error {
+	ctx := r.Context()
+	resetToken, resetTokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	record := &domain.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: resetTokenHash,
+		ExpiresAt: now.Add(h.config.PasswordResetTokenDuration),
+		CreatedAt: now,
+	}
+	if err := h.store.CreatePasswordResetToken(ctx, record); err != nil {
+		return fmt.Errorf("failed to store password reset token: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(h.config.AppBaseURL, "/"), url.QueryEscape(resetToken))
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo reset your password, open the link below:\n%s\n\nThe link is valid for %s and can be used once. If you did not request a password reset, ignore this email.\n",
+			user.Username, link, h.config.PasswordResetTokenDuration),
+	}
+	return h.mailer.Send(ctx, msg)
+}
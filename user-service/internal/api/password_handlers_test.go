@@ -0,0 +1,126 @@
+// user-service/internal/api/password_handlers_test.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/pkg/auth"
+)
+
+var resetLinkPattern = regexp.MustCompile(`/reset-password\?token=(\S+)`)
+
+// requestPasswordReset запрашивает письмо для email и возвращает токен из ссылки в нем.
+func requestPasswordReset(t *testing.T, env *testEnv, email string) string {
+	t.Helper()
+	rec := env.do(t, http.MethodPost, "/api/users/password/forgot", "", domain.ForgotPasswordRequest{Email: email})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("forgot password: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	messages := env.mailer.sentTo(email)
+	if len(messages) == 0 {
+		t.Fatalf("no password reset email sent to %s", email)
+	}
+	match := resetLinkPattern.FindStringSubmatch(messages[len(messages)-1].Body)
+	if match == nil {
+		t.Fatalf("no reset link in email body %q", messages[len(messages)-1].Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("unescape reset token: %v", err)
+	}
+	return token
+}
+
+func TestResetPassword(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "forgetful", "user")
+	session := env.tokens(t, user)
+
+	token := requestPasswordReset(t, env, user.Email)
+	rec := env.do(t, http.MethodPost, "/api/users/password/reset", "", domain.ResetPasswordRequest{Token: token, NewPassword: "brand-new-secret"})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("reset: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	stored, err := env.store.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !auth.CheckPasswordHash("brand-new-secret", stored.PasswordHash) {
+		t.Error("password was not changed")
+	}
+	// Сброс пароля завершает существующие сессии
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(session.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after password reset: code = %d, want 401", rec.Code)
+	}
+
+	// Ссылка одноразовая
+	rec = env.do(t, http.MethodPost, "/api/users/password/reset", "", domain.ResetPasswordRequest{Token: token, NewPassword: "attacker-secret"})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("reused reset token: code = %d, want 400", rec.Code)
+	}
+	stored, _ = env.store.GetByID(context.Background(), user.ID)
+	if !auth.CheckPasswordHash("brand-new-secret", stored.PasswordHash) {
+		t.Error("reused reset token changed the password")
+	}
+}
+
+func TestResetPasswordInvalidatesOtherLinks(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "twice", "user")
+
+	first := requestPasswordReset(t, env, user.Email)
+	second := requestPasswordReset(t, env, user.Email)
+	rec := env.do(t, http.MethodPost, "/api/users/password/reset", "", domain.ResetPasswordRequest{Token: second, NewPassword: "brand-new-secret"})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("reset: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/password/reset", "", domain.ResetPasswordRequest{Token: first, NewPassword: "another-secret"})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("older reset link after reset: code = %d, want 400", rec.Code)
+	}
+}
+
+func TestForgotPasswordUnknownEmail(t *testing.T) {
+	env := newTestEnv(t)
+
+	// Ответ такой же, как для существующего аккаунта, но письмо не отправляется
+	rec := env.do(t, http.MethodPost, "/api/users/password/forgot", "", domain.ForgotPasswordRequest{Email: "nobody@example.com"})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("code = %d, want 202", rec.Code)
+	}
+	if got := env.mailer.sentTo("nobody@example.com"); len(got) != 0 {
+		t.Errorf("sent %d email(s) to an unknown address", len(got))
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "changer", "user")
+	session := env.tokens(t, user)
+
+	tests := []struct {
+		name    string
+		current string
+		next    string
+		want    int
+	}{
+		{name: "wrong current password", current: "not-my-password", next: "brand-new-secret", want: http.StatusUnauthorized},
+		{name: "same password", current: testPassword, next: testPassword, want: http.StatusBadRequest},
+		{name: "changed", current: testPassword, next: "brand-new-secret", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, http.MethodPut, "/api/users/me/password", session.Token, domain.ChangePasswordRequest{CurrentPassword: tt.current, NewPassword: tt.next})
+			if rec.Code != tt.want {
+				t.Errorf("code = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// user-service/internal/api/profile_handlers.go
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+)
+
+// reservedUsernames совпадают с путями под /api/users и не могут быть именами пользователей,
+// иначе их публичный профиль GET /api/users/{username} был бы недоступен.
+var reservedUsernames = map[string]bool{
+	"me": true, "admin": true, "register": true, "login": true, "logout": true,
+	"token": true, "password": true, "verify": true,
+}
+
+// isReservedUsername сообщает, занято ли имя маршрутами API.
+func isReservedUsername(username string) bool {
+	return reservedUsernames[strings.ToLower(username)]
+}
+
+// normalizeGenres обрезает пробелы и убирает повторы (без учета регистра), сохраняя порядок.
+func normalizeGenres(genres []string) pq.StringArray {
+	normalized := pq.StringArray{}
+	seen := make(map[string]bool, len(genres))
+	for _, genre := range genres {
+		genre = strings.TrimSpace(genre)
+		key := strings.ToLower(genre)
+		if genre == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, genre)
+	}
+	return normalized
+}
+
+// applyProfileUpdate переносит переданные поля профиля в user. Возвращает true, если что-то передано.
+func applyProfileUpdate(user *domain.User, req *domain.UpdateProfileRequest) bool {
+	updated := false
+	if req.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*req.DisplayName)
+		updated = true
+	}
+	if req.Bio != nil {
+		user.Bio = strings.TrimSpace(*req.Bio)
+		updated = true
+	}
+	if req.AvatarURL != nil {
+		user.AvatarURL = strings.TrimSpace(*req.AvatarURL)
+		updated = true
+	}
+	if req.Location != nil {
+		user.Location = strings.TrimSpace(*req.Location)
+		updated = true
+	}
+	if req.FavoriteGenres != nil {
+		user.FavoriteGenres = normalizeGenres(*req.FavoriteGenres)
+		updated = true
+	}
+	return updated
+}
+
+// ownProfileResponse возвращает профиль для самого пользователя (без служебных полей блокировки и т.п.).
+func ownProfileResponse(user *domain.User) *domain.User {
+	return &domain.User{
+		ID:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		Role:           user.Role,
+		VerifiedAt:     user.VerifiedAt,
+		DisplayName:    user.DisplayName,
+		Bio:            user.Bio,
+		AvatarURL:      user.AvatarURL,
+		Location:       user.Location,
+		FavoriteGenres: user.FavoriteGenres,
+		CreatedAt:      user.CreatedAt,
+		UpdatedAt:      user.UpdatedAt,
+	}
+}
+
+// publicProfile возвращает публичную карточку пользователя.
+func publicProfile(user *domain.User) *domain.PublicProfile {
+	genres := []string(user.FavoriteGenres)
+	if genres == nil {
+		genres = []string{}
+	}
+	return &domain.PublicProfile{
+		Username:       user.Username,
+		DisplayName:    user.DisplayName,
+		Bio:            user.Bio,
+		AvatarURL:      user.AvatarURL,
+		Location:       user.Location,
+		FavoriteGenres: genres,
+		MemberSince:    user.CreatedAt,
+	}
+}
+
+// getListedUser загружает пользователя по имени из пути и проверяет, что его профиль публичен.
+// Аккаунты, ожидающие удаления, и заблокированные отвечают 404, как несуществующие. При ошибке ответ уже отправлен.
+func (h *HTTPHandler) getListedUser(w http.ResponseWriter, r *http.Request) (*domain.User, bool) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	user, err := h.store.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user by username", slog.String("username", username), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		}
+		return nil, false
+	}
+	if user.DeletionScheduledAt != nil || user.IsSuspended(time.Now().UTC()) {
+		h.respondError(w, r, http.StatusNotFound, "User not found")
+		return nil, false
+	}
+	return user, true
+}
+
+// GetPublicProfile возвращает публичный профиль пользователя по имени (GET /api/users/{username}).
+// Email, роль и состояние аккаунта не раскрываются; аккаунты, ожидающие удаления, и заблокированные не показываются.
+func (h *HTTPHandler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := h.getListedUser(w, r)
+	if !ok {
+		return
+	}
+	counts, err := h.store.GetFollowCounts(ctx, user.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to get follow counts for public profile", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve profile")
+		return
+	}
+	profile := publicProfile(user)
+	profile.FollowCounts = *counts
+	h.respondJSON(w, r, http.StatusOK, profile)
+}
@@ -0,0 +1,171 @@
+// user-service/internal/api/role_handlers.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"shared/authz"
+	"user-service/internal/domain"
+	"user-service/internal/store"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+)
+
+// roleNamePattern - допустимые имена пользовательских ролей.
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// builtinRole возвращает встроенную роль из shared/authz.
+func builtinRole(name string) (*domain.Role, bool) {
+	permissions, ok := authz.BuiltinRoles[name]
+	if !ok {
+		return nil, false
+	}
+	role := &domain.Role{Name: name, Permissions: pq.StringArray{}, Builtin: true}
+	for _, perm := range permissions {
+		role.Permissions = append(role.Permissions, string(perm))
+	}
+	return role, true
+}
+
+// resolveRole возвращает встроенную или пользовательскую роль; для неизвестной роли - store.ErrRoleNotFound.
+func (h *HTTPHandler) resolveRole(ctx context.Context, name string) (*domain.Role, error) {
+	if role, ok := builtinRole(name); ok {
+		return role, nil
+	}
+	return h.store.GetRole(ctx, name)
+}
+
+// rolePermissions возвращает разрешения роли для access токена.
+// Роль, которой больше нет (например, удаленная пользовательская), не дает разрешений.
+func (h *HTTPHandler) rolePermissions(ctx context.Context, name string) ([]string, error) {
+	role, err := h.resolveRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, store.ErrRoleNotFound) {
+			h.logger.WarnContext(ctx, "User has unknown role, issuing token without permissions", slog.String("role", name))
+			return nil, nil
+		}
+		return nil, err
+	}
+	return role.Permissions, nil
+}
+
+// ListRoles возвращает встроенные и пользовательские роли с разрешениями (GET /api/users/admin/roles).
+func (h *HTTPHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customRoles, err := h.store.ListRoles(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list roles from store", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to retrieve roles")
+		return
+	}
+
+	builtinNames := make([]string, 0, len(authz.BuiltinRoles))
+	for name := range authz.BuiltinRoles {
+		builtinNames = append(builtinNames, name)
+	}
+	sort.Strings(builtinNames)
+
+	response := domain.RolesResponse{Roles: make([]domain.Role, 0, len(builtinNames)+len(customRoles))}
+	for _, name := range builtinNames {
+		role, _ := builtinRole(name)
+		response.Roles = append(response.Roles, *role)
+	}
+	for _, role := range customRoles {
+		response.Roles = append(response.Roles, *role)
+	}
+	h.respondJSON(w, r, http.StatusOK, response)
+}
+
+// SaveRole создает или изменяет пользовательскую роль (PUT /api/users/admin/roles/{role}).
+// Встроенные роли изменить нельзя; разрешения должны быть из списка authz.AllPermissions.
+func (h *HTTPHandler) SaveRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+	name := mux.Vars(r)["role"]
+
+	if _, ok := builtinRole(name); ok {
+		h.respondError(w, r, http.StatusConflict, "Built-in roles cannot be modified")
+		return
+	}
+	if !roleNamePattern.MatchString(name) {
+		h.respondError(w, r, http.StatusBadRequest, "Role name must be 2-32 characters: lowercase letters, digits, '-' or '_', starting with a letter")
+		return
+	}
+
+	var req domain.SaveRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	role := &domain.Role{Name: name, Description: req.Description, Permissions: pq.StringArray{}}
+	seen := make(map[string]bool, len(req.Permissions))
+	for _, perm := range req.Permissions {
+		if !authz.IsKnown(authz.Permission(perm)) {
+			h.respondError(w, r, http.StatusBadRequest, "Unknown permission: "+perm)
+			return
+		}
+		if !seen[perm] {
+			seen[perm] = true
+			role.Permissions = append(role.Permissions, perm)
+		}
+	}
+
+	if err := h.store.SaveRole(ctx, role); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to save role", slog.String("role", name), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to save role")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Role saved by admin", slog.String("role", name), slog.String("adminID", adminID), slog.Any("permissions", []string(role.Permissions)))
+	h.respondJSON(w, r, http.StatusOK, role)
+}
+
+// DeleteRole удаляет пользовательскую роль (DELETE /api/users/admin/roles/{role}).
+// Роль, назначенную хотя бы одному пользователю, удалить нельзя (409).
+func (h *HTTPHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID, _ := ctx.Value(UserIDKey).(string)
+	name := mux.Vars(r)["role"]
+
+	if _, ok := builtinRole(name); ok {
+		h.respondError(w, r, http.StatusConflict, "Built-in roles cannot be deleted")
+		return
+	}
+
+	_, assigned, err := h.store.List(ctx, store.UserListParams{Page: 1, PageSize: 1, Role: name})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check role assignments", slog.String("role", name), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to delete role")
+		return
+	}
+	if assigned > 0 {
+		h.respondError(w, r, http.StatusConflict, "Role is assigned to users; reassign them first")
+		return
+	}
+
+	if err := h.store.DeleteRole(ctx, name); err != nil {
+		if errors.Is(err, store.ErrRoleNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "Role not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to delete role", slog.String("role", name), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to delete role")
+		}
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Role deleted by admin", slog.String("role", name), slog.String("adminID", adminID))
+	w.WriteHeader(http.StatusNoContent)
+}
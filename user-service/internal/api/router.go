@@ -4,6 +4,8 @@ package api
 import (
 	"github.com/gorilla/mux"
 	"net/http"
+
+	"shared/authz"
 )
 
 // NewHTTPRouter создает и настраивает HTTP маршрутизатор для UserService
@@ -11,19 +13,60 @@ func NewHTTPRouter(httpHandler *HTTPHandler) *mux.Router {
 	router := mux.NewRouter()
 	// router.StrictSlash(true) // Раскомментируйте, если хотите одинаковую обработку /path и /path/
 
+	// Публичные ключи для проверки JWT другими сервисами
+	router.HandleFunc("/.well-known/jwks.json", httpHandler.GetJWKS).Methods(http.MethodGet)
+
 	// Базовый префикс для всех API эндпоинтов пользователей
 	apiUsersRouter := router.PathPrefix("/api/users").Subrouter()
 
 	// Публичные эндпоинты (не требуют аутентификации)
 	apiUsersRouter.HandleFunc("/register", httpHandler.RegisterUser).Methods(http.MethodPost)
 	apiUsersRouter.HandleFunc("/login", httpHandler.LoginUser).Methods(http.MethodPost)
+	apiUsersRouter.HandleFunc("/login/2fa", httpHandler.LoginTwoFactor).Methods(http.MethodPost)       // Второй шаг входа при включенной 2FA
+	apiUsersRouter.HandleFunc("/token/refresh", httpHandler.RefreshToken).Methods(http.MethodPost)     // Ротация refresh токена
+	apiUsersRouter.HandleFunc("/logout", httpHandler.Logout).Methods(http.MethodPost)                  // Отзыв семейства refresh токенов
+	apiUsersRouter.HandleFunc("/password/forgot", httpHandler.ForgotPassword).Methods(http.MethodPost) // Письмо со ссылкой на сброс пароля
+	apiUsersRouter.HandleFunc("/password/reset", httpHandler.ResetPassword).Methods(http.MethodPost)   // Новый пароль по токену из письма
+	apiUsersRouter.HandleFunc("/verify", httpHandler.VerifyEmail).Methods(http.MethodGet)              // Подтверждение email по ссылке из письма
 
 	// Эндпоинты, требующие аутентификации
 	// Создаем саб-роутер для /me и применяем к нему AuthMiddleware
 	meRouter := apiUsersRouter.PathPrefix("/me").Subrouter()
-	meRouter.Use(httpHandler.AuthMiddleware)                                       // AuthMiddleware применяется ко всем маршрутам в meRouter
-	meRouter.HandleFunc("", httpHandler.GetUserProfile).Methods(http.MethodGet)    // GET /api/users/me
-	meRouter.HandleFunc("", httpHandler.UpdateUserProfile).Methods(http.MethodPut) // PUT /api/users/me <--- ДОБАВЛЕН ЭТОТ МАРШРУТ
+	meRouter.Use(httpHandler.AuthMiddleware)                                                                    // AuthMiddleware применяется ко всем маршрутам в meRouter
+	meRouter.HandleFunc("", httpHandler.GetUserProfile).Methods(http.MethodGet)                                 // GET /api/users/me
+	meRouter.HandleFunc("", httpHandler.UpdateUserProfile).Methods(http.MethodPut)                              // PUT /api/users/me <--- ДОБАВЛЕН ЭТОТ МАРШРУТ
+	meRouter.HandleFunc("", httpHandler.DeleteAccount).Methods(http.MethodDelete)                               // DELETE /api/users/me (удаление после отсрочки)
+	meRouter.HandleFunc("/deletion/cancel", httpHandler.CancelAccountDeletion).Methods(http.MethodPost)         // POST /api/users/me/deletion/cancel
+	meRouter.HandleFunc("/password", httpHandler.ChangePassword).Methods(http.MethodPut)                        // PUT /api/users/me/password
+	meRouter.HandleFunc("/2fa/setup", httpHandler.SetupTwoFactor).Methods(http.MethodPost)                      // POST /api/users/me/2fa/setup
+	meRouter.HandleFunc("/2fa/confirm", httpHandler.ConfirmTwoFactor).Methods(http.MethodPost)                  // POST /api/users/me/2fa/confirm
+	meRouter.HandleFunc("/verify/resend", httpHandler.ResendVerificationEmail).Methods(http.MethodPost)         // POST /api/users/me/verify/resend
+	meRouter.HandleFunc("/export", httpHandler.ExportData).Methods(http.MethodGet)                              // GET /api/users/me/export (синхронная выгрузка)
+	meRouter.HandleFunc("/export/jobs", httpHandler.StartExportJob).Methods(http.MethodPost)                    // POST /api/users/me/export/jobs (асинхронная выгрузка)
+	meRouter.HandleFunc("/export/jobs/{jobId}", httpHandler.GetExportJob).Methods(http.MethodGet)               // Состояние выгрузки
+	meRouter.HandleFunc("/export/jobs/{jobId}/download", httpHandler.DownloadExportJob).Methods(http.MethodGet) // Скачать готовый архив
+	meRouter.HandleFunc("/following/{username}", httpHandler.FollowUser).Methods(http.MethodPost)               // Подписаться на пользователя
+	meRouter.HandleFunc("/following/{username}", httpHandler.UnfollowUser).Methods(http.MethodDelete)           // Отписаться
+
+	// Административные эндпоинты (каждый требует своего разрешения, см. shared/authz)
+	adminRouter := apiUsersRouter.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(httpHandler.AuthMiddleware)
+	adminRouter.Handle("/users", httpHandler.requirePermission(authz.PermUserRead, httpHandler.ListUsers)).Methods(http.MethodGet)                            // Список с поиском и пагинацией
+	adminRouter.Handle("/users/{userId}", httpHandler.requirePermission(authz.PermUserRead, httpHandler.GetUserAdmin)).Methods(http.MethodGet)                // Карточка пользователя
+	adminRouter.Handle("/users/{userId}/role", httpHandler.requirePermission(authz.PermUserManageRoles, httpHandler.ChangeUserRole)).Methods(http.MethodPut)  // Смена роли
+	adminRouter.Handle("/users/{userId}/suspend", httpHandler.requirePermission(authz.PermUserSuspend, httpHandler.SuspendUser)).Methods(http.MethodPost)     // Блокировка аккаунта
+	adminRouter.Handle("/users/{userId}/unsuspend", httpHandler.requirePermission(authz.PermUserSuspend, httpHandler.UnsuspendUser)).Methods(http.MethodPost) // Снятие блокировки
+	adminRouter.Handle("/users/{userId}/logout", httpHandler.requirePermission(authz.PermUserSuspend, httpHandler.ForceLogoutUser)).Methods(http.MethodPost)  // Принудительный выход
+	adminRouter.Handle("/users/{userId}/unlock", httpHandler.requirePermission(authz.PermUserSuspend, httpHandler.UnlockUser)).Methods(http.MethodPost)       // Снять блокировку входа
+	adminRouter.Handle("/roles", httpHandler.requirePermission(authz.PermUserManageRoles, httpHandler.ListRoles)).Methods(http.MethodGet)                     // Роли и их разрешения
+	adminRouter.Handle("/roles/{role}", httpHandler.requirePermission(authz.PermUserManageRoles, httpHandler.SaveRole)).Methods(http.MethodPut)               // Создать/изменить пользовательскую роль
+	adminRouter.Handle("/roles/{role}", httpHandler.requirePermission(authz.PermUserManageRoles, httpHandler.DeleteRole)).Methods(http.MethodDelete)          // Удалить пользовательскую роль
+
+	// Публичный профиль. Регистрируется последним: фиксированные пути выше (/verify, /me, ...) имеют приоритет,
+	// а совпадающие с ними имена запрещены при регистрации (см. reservedUsernames)
+	apiUsersRouter.HandleFunc("/{username}", httpHandler.GetPublicProfile).Methods(http.MethodGet)
+	apiUsersRouter.HandleFunc("/{username}/followers", httpHandler.ListFollowers).Methods(http.MethodGet) // Подписчики
+	apiUsersRouter.HandleFunc("/{username}/following", httpHandler.ListFollowing).Methods(http.MethodGet) // Подписки
 
 	return router
 }
@@ -0,0 +1,181 @@
+// user-service/internal/api/token_handlers.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+// issueTokenPair выпускает access токен и новый refresh токен в семействе familyID.
+func (h *HTTPHandler) issueTokenPair(ctx context.Context, user *domain.User, familyID string) (*domain.TokenPairResponse, error) {
+	role, permissions, twoFactorSetupRequired, err := h.tokenAccess(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := h.tokenManager.Generate(user.ID, role, permissions, user.IsVerified())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, refreshTokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	record := &domain.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: now.Add(h.config.RefreshTokenDuration),
+		CreatedAt: now,
+	}
+	if err := h.store.CreateRefreshToken(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &domain.TokenPairResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(h.tokenManager.TokenDuration().Seconds()),
+
+		TwoFactorSetupRequired: twoFactorSetupRequired,
+	}, nil
+}
+
+// decodeRefreshTokenRequest читает и валидирует тело запроса с refresh токеном.
+func (h *HTTPHandler) decodeRefreshTokenRequest(w http.ResponseWriter, r *http.Request) (*domain.RefreshTokenRequest, bool) {
+	var req domain.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(r.Context(), "Failed to decode refresh token request body", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return nil, false
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.StructCtx(r.Context(), req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return nil, false
+	}
+	return &req, true
+}
+
+// RefreshToken обменивает refresh токен на новую пару токенов (ротация).
+// Повторное предъявление уже использованного refresh токена считается кражей:
+// все семейство токенов отзывается.
+func (h *HTTPHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP RefreshToken request received", slog.String("path", r.URL.Path))
+
+	req, ok := h.decodeRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.store.GetRefreshTokenByHash(ctx, auth.HashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, store.ErrRefreshTokenNotFound) {
+			h.logger.WarnContext(ctx, "Unknown refresh token presented")
+			h.respondError(w, r, http.StatusUnauthorized, "Invalid refresh token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to look up refresh token", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to refresh token")
+		}
+		return
+	}
+
+	if token.RevokedAt != nil {
+		h.logger.WarnContext(ctx, "Revoked refresh token presented", slog.String("userID", token.UserID), slog.String("familyID", token.FamilyID))
+		h.respondError(w, r, http.StatusUnauthorized, "Refresh token has been revoked")
+		return
+	}
+	if time.Now().UTC().After(token.ExpiresAt) {
+		h.respondError(w, r, http.StatusUnauthorized, "Refresh token has expired")
+		return
+	}
+
+	if err := h.store.MarkRefreshTokenUsed(ctx, token.ID); err != nil {
+		if errors.Is(err, store.ErrRefreshTokenReused) {
+			h.logger.WarnContext(ctx, "Refresh token reuse detected, revoking token family", slog.String("userID", token.UserID), slog.String("familyID", token.FamilyID))
+			if revokeErr := h.store.RevokeRefreshTokenFamily(ctx, token.FamilyID); revokeErr != nil {
+				h.logger.ErrorContext(ctx, "Failed to revoke refresh token family after reuse", slog.String("familyID", token.FamilyID), slog.String("error", revokeErr.Error()))
+			}
+			h.respondError(w, r, http.StatusUnauthorized, "Refresh token has already been used")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to rotate refresh token", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to refresh token")
+		}
+		return
+	}
+
+	// Берем актуальную роль из хранилища, а не из старого токена
+	user, err := h.store.GetByID(ctx, token.UserID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "User of refresh token not found", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusUnauthorized, "Invalid refresh token")
+		return
+	}
+	if h.rejectSuspended(w, r, user) {
+		return
+	}
+
+	tokens, err := h.issueTokenPair(ctx, user, token.FamilyID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to issue rotated tokens", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Tokens refreshed successfully", slog.String("userID", user.ID))
+	h.respondJSON(w, r, http.StatusOK, tokens)
+}
+
+// Logout отзывает семейство refresh токенов, к которому относится переданный токен.
+func (h *HTTPHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP Logout request received", slog.String("path", r.URL.Path))
+
+	req, ok := h.decodeRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.store.GetRefreshTokenByHash(ctx, auth.HashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, store.ErrRefreshTokenNotFound) {
+			h.respondError(w, r, http.StatusUnauthorized, "Invalid refresh token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to look up refresh token for logout", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Logout failed")
+		}
+		return
+	}
+
+	if err := h.store.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to revoke refresh token family on logout", slog.String("familyID", token.FamilyID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Logout failed")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "User logged out", slog.String("userID", token.UserID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetJWKS отдает публичные ключи для проверки access токенов (RFC 7517).
+// MovieService и ReviewService проверяют токены по этим ключам без общего секрета.
+func (h *HTTPHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	h.respondJSON(w, r, http.StatusOK, h.tokenManager.JWKS())
+}
@@ -0,0 +1,141 @@
+// user-service/internal/api/token_handlers_test.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+func refreshBody(token string) domain.RefreshTokenRequest {
+	return domain.RefreshTokenRequest{RefreshToken: token}
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "rotator", "user")
+	first := env.tokens(t, user)
+
+	rec := env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(first.RefreshToken))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var second domain.TokenPairResponse
+	decodeJSON(t, rec, &second)
+	if second.Token == "" || second.RefreshToken == "" {
+		t.Fatalf("refresh returned an incomplete pair: %+v", second)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("refresh token was not rotated")
+	}
+
+	// Новый refresh токен продолжает цепочку
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(second.RefreshToken))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("second refresh: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "victim", "user")
+	first := env.tokens(t, user)
+	other := env.tokens(t, user) // Другая сессия того же пользователя
+
+	rec := env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(first.RefreshToken))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var second domain.TokenPairResponse
+	decodeJSON(t, rec, &second)
+
+	// Повторное предъявление старого токена - признак кражи
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(first.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("replayed refresh: code = %d, want 401", rec.Code)
+	}
+
+	// Все семейство отозвано: выданный после ротации токен больше не работает
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(second.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh with token from revoked family: code = %d, want 401", rec.Code)
+	}
+	// Другие сессии не затронуты
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(other.RefreshToken))
+	if rec.Code != http.StatusOK {
+		t.Errorf("refresh in another family: code = %d, want 200", rec.Code)
+	}
+}
+
+func TestLogoutRevokesFamily(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "leaver", "user")
+	first := env.tokens(t, user)
+
+	rec := env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(first.RefreshToken))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var second domain.TokenPairResponse
+	decodeJSON(t, rec, &second)
+
+	// Выход по старому токену отзывает и токен, полученный ротацией
+	rec = env.do(t, http.MethodPost, "/api/users/logout", "", refreshBody(first.RefreshToken))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("logout: code = %d, body %s", rec.Code, rec.Body.String())
+	}
+	rec = env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(second.RefreshToken))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: code = %d, want 401", rec.Code)
+	}
+
+	rec = env.do(t, http.MethodPost, "/api/users/logout", "", refreshBody("unknown-token"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("logout with unknown token: code = %d, want 401", rec.Code)
+	}
+}
+
+func TestRefreshTokenRejected(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.createUser(t, "latecomer", "user")
+
+	expired, expiredHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		t.Fatalf("GenerateOpaqueToken: %v", err)
+	}
+	issuedAt := time.Now().UTC().Add(-48 * time.Hour)
+	if err := env.store.CreateRefreshToken(context.Background(), &domain.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		FamilyID:  uuid.NewString(),
+		TokenHash: expiredHash,
+		ExpiresAt: issuedAt.Add(24 * time.Hour),
+		CreatedAt: issuedAt,
+	}); err != nil {
+		t.Fatalf("CreateRefreshToken: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "expired", token: expired, want: http.StatusUnauthorized},
+		{name: "unknown", token: "not-a-real-token", want: http.StatusUnauthorized},
+		{name: "empty", token: "", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, http.MethodPost, "/api/users/token/refresh", "", refreshBody(tt.token))
+			if rec.Code != tt.want {
+				t.Errorf("code = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// user-service/internal/api/two_factor_handlers.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"shared/authz"
+	"user-service/internal/domain"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+// recoveryCodeCount - количество кодов восстановления, выдаваемых при включении 2FA.
+const recoveryCodeCount = 10
+
+// twoFactorEnabled сообщает, включена ли у пользователя 2FA (подтвержденный TOTP).
+func (h *HTTPHandler) twoFactorEnabled(ctx context.Context, userID string) (bool, error) {
+	secret, err := h.store.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrTOTPNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return secret.EnabledAt != nil, nil
+}
+
+// tokenAccess возвращает роль и разрешения для access токена. Если политика требует 2FA
+// для привилегированных ролей (с любыми разрешениями), а она не подключена, пользователь
+// получает роль user без разрешений, пока не подключит 2FA.
+func (h *HTTPHandler) tokenAccess(ctx context.Context, user *domain.User) (role string, permissions []string, twoFactorSetupRequired bool, err error) {
+	permissions, err = h.rolePermissions(ctx, user.Role)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("failed to resolve role permissions: %w", err)
+	}
+	if !h.config.RequirePrivilegedTwoFactor || len(permissions) == 0 {
+		return user.Role, permissions, false, nil
+	}
+	enabled, err := h.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("failed to check two-factor status: %w", err)
+	}
+	if !enabled {
+		h.logger.WarnContext(ctx, "Privileged user without two-factor authentication, issuing token with restricted role", slog.String("userID", user.ID), slog.String("role", user.Role))
+		return authz.RoleUser, nil, true, nil
+	}
+	return user.Role, permissions, false, nil
+}
+
+// SetupTwoFactor начинает подключение TOTP (POST /api/users/me/2fa/setup).
+// Возвращает секрет и otpauth URI; 2FA включается только после подтверждения первым кодом.
+func (h *HTTPHandler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for SetupTwoFactor")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "HTTP SetupTwoFactor request received", slog.String("userID", userID))
+
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User associated with token not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for 2FA setup", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to set up two-factor authentication")
+		}
+		return
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to generate TOTP secret", slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to set up two-factor authentication")
+		return
+	}
+	if err := h.store.SavePendingTOTP(ctx, &domain.TOTPSecret{UserID: userID, Secret: secret, CreatedAt: time.Now().UTC()}); err != nil {
+		if errors.Is(err, store.ErrTOTPAlreadyEnabled) {
+			h.respondError(w, r, http.StatusConflict, "Two-factor authentication is already enabled")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to save pending TOTP secret", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to set up two-factor authentication")
+		}
+		return
+	}
+
+	h.respondJSON(w, r, http.StatusOK, domain.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(h.config.TOTPIssuer, user.Email, secret),
+	})
+}
+
+// ConfirmTwoFactor включает 2FA после проверки первого кода (POST /api/users/me/2fa/confirm).
+// Возвращает коды восстановления; все прежние сессии завершаются, текущему клиенту выдается новая пара токенов.
+func (h *HTTPHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for ConfirmTwoFactor")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "HTTP ConfirmTwoFactor request received", slog.String("userID", userID))
+
+	var req domain.TwoFactorConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	secret, err := h.store.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrTOTPNotFound) {
+			h.respondError(w, r, http.StatusBadRequest, "Two-factor setup has not been started")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get TOTP secret", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to enable two-factor authentication")
+		}
+		return
+	}
+	if secret.EnabledAt != nil {
+		h.respondError(w, r, http.StatusConflict, "Two-factor authentication is already enabled")
+		return
+	}
+
+	step, valid := auth.ValidateTOTP(secret.Secret, req.Code, time.Now())
+	if !valid {
+		h.logger.WarnContext(ctx, "Invalid TOTP code on 2FA confirmation", slog.String("userID", userID))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid two-factor code")
+		return
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	recoveryCodeHashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to generate recovery code", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to enable two-factor authentication")
+			return
+		}
+		recoveryCodes = append(recoveryCodes, code)
+		recoveryCodeHashes = append(recoveryCodeHashes, auth.HashOpaqueToken(code))
+	}
+
+	if err := h.store.EnableTOTP(ctx, userID, step, recoveryCodeHashes); err != nil {
+		if errors.Is(err, store.ErrTOTPAlreadyEnabled) {
+			h.respondError(w, r, http.StatusConflict, "Two-factor authentication is already enabled")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to enable TOTP", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to enable two-factor authentication")
+		}
+		return
+	}
+
+	// Сессии, открытые только паролем, больше не должны продлеваться
+	if err := h.store.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to revoke sessions after enabling 2FA", slog.String("userID", userID), slog.String("error", err.Error()))
+	}
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to get user after enabling 2FA", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Two-factor authentication enabled, but failed to issue new tokens")
+		return
+	}
+	tokens, err := h.issueTokenPair(ctx, user, uuid.NewString())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to issue tokens after enabling 2FA", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Two-factor authentication enabled, but failed to issue new tokens")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Two-factor authentication enabled", slog.String("userID", userID))
+	h.respondJSON(w, r, http.StatusOK, domain.TwoFactorConfirmResponse{RecoveryCodes: recoveryCodes, Tokens: tokens})
+}
+
+// createLoginChallenge создает challenge токен для второго шага входа при включенной 2FA.
+func (h *HTTPHandler) createLoginChallenge(ctx context.Context, user *domain.User) (*domain.LoginChallengeResponse, error) {
+	challengeToken, challengeHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to generate login challenge", slog.String("error", err.Error()))
+		return nil, err
+	}
+	now := time.Now().UTC()
+	challenge := &domain.LoginChallenge{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: challengeHash,
+		ExpiresAt: now.Add(h.config.LoginChallengeDuration),
+		CreatedAt: now,
+	}
+	if err := h.store.CreateLoginChallenge(ctx, challenge); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to store login challenge", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	h.logger.InfoContext(ctx, "Password accepted, two-factor code required", slog.String("userID", user.ID))
+	return &domain.LoginChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+		ExpiresIn:         int64(h.config.LoginChallengeDuration.Seconds()),
+	}, nil
+}
+
+// LoginTwoFactor - второй шаг входа (POST /api/users/login/2fa): challenge токен и код TOTP
+// или код восстановления. Неверные коды учитываются тем же механизмом, что и неверные пароли.
+func (h *HTTPHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP LoginTwoFactor request received", slog.String("path", r.URL.Path))
+
+	var req domain.TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.StructCtx(ctx, req); err != nil {
+		h.respondError(w, r, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	challenge, err := h.store.GetLoginChallengeByHash(ctx, auth.HashOpaqueToken(req.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, store.ErrLoginChallengeNotFound) {
+			h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired challenge token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to look up login challenge", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Login failed")
+		}
+		return
+	}
+	if challenge.UsedAt != nil || time.Now().UTC().After(challenge.ExpiresAt) {
+		h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired challenge token")
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "User of login challenge not found", slog.String("userID", challenge.UserID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired challenge token")
+		return
+	}
+	if h.rejectSuspended(w, r, user) {
+		return
+	}
+
+	accountKey, ipKeys := accountThrottleKey(user.Email), ipThrottleKeys(h.clientIP(r))
+	wait, err := h.loginRetryAfter(ctx, accountKey, ipKeys)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check login throttle", slog.String("userID", user.ID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Login failed")
+		return
+	}
+	if wait > 0 {
+		h.respondTooManyLoginAttempts(w, r, wait)
+		return
+	}
+
+	secret, err := h.store.GetTOTP(ctx, user.ID)
+	if err != nil || secret.EnabledAt == nil {
+		h.logger.WarnContext(ctx, "Login challenge for user without enabled 2FA", slog.String("userID", user.ID))
+		h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired challenge token")
+		return
+	}
+
+	if req.Code != "" {
+		step, valid := auth.ValidateTOTP(secret.Secret, req.Code, time.Now())
+		if !valid {
+			h.logger.WarnContext(ctx, "Invalid TOTP code on login", slog.String("userID", user.ID))
+			h.recordLoginFailure(ctx, accountKey, ipKeys)
+			h.respondError(w, r, http.StatusUnauthorized, "Invalid two-factor code")
+			return
+		}
+		if err := h.store.MarkTOTPStepUsed(ctx, user.ID, step); err != nil {
+			if errors.Is(err, store.ErrTOTPCodeReused) {
+				h.logger.WarnContext(ctx, "TOTP code reuse on login", slog.String("userID", user.ID))
+				h.recordLoginFailure(ctx, accountKey, ipKeys)
+				h.respondError(w, r, http.StatusUnauthorized, "Two-factor code has already been used, wait for the next one")
+			} else {
+				h.logger.ErrorContext(ctx, "Failed to mark TOTP step as used", slog.String("userID", user.ID), slog.String("error", err.Error()))
+				h.respondError(w, r, http.StatusInternalServerError, "Login failed")
+			}
+			return
+		}
+	} else {
+		codeHash := auth.HashOpaqueToken(auth.NormalizeRecoveryCode(req.RecoveryCode))
+		if err := h.store.UseRecoveryCode(ctx, user.ID, codeHash); err != nil {
+			if errors.Is(err, store.ErrRecoveryCodeNotFound) {
+				h.logger.WarnContext(ctx, "Invalid recovery code on login", slog.String("userID", user.ID))
+				h.recordLoginFailure(ctx, accountKey, ipKeys)
+				h.respondError(w, r, http.StatusUnauthorized, "Invalid recovery code")
+			} else {
+				h.logger.ErrorContext(ctx, "Failed to use recovery code", slog.String("userID", user.ID), slog.String("error", err.Error()))
+				h.respondError(w, r, http.StatusInternalServerError, "Login failed")
+			}
+			return
+		}
+	}
+
+	if err := h.store.MarkLoginChallengeUsed(ctx, challenge.ID); err != nil {
+		if errors.Is(err, store.ErrLoginChallengeUsed) {
+			h.respondError(w, r, http.StatusUnauthorized, "Invalid or expired challenge token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to mark login challenge as used", slog.String("challengeID", challenge.ID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Login failed")
+		}
+		return
+	}
+
+	if err := h.store.ResetLoginAttempts(ctx, accountKey); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to reset login attempts after successful login", slog.String("userID", user.ID), slog.String("error", err.Error()))
+	}
+	h.completeLogin(w, r, user)
+}
@@ -0,0 +1,166 @@
+// user-service/internal/api/verification_handlers.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/mailer"
+	"user-service/internal/store"
+	"user-service/pkg/auth"
+
+	"github.com/google/uuid"
+)
+
+// VerifyEmail подтверждает email по одноразовому токену из письма (GET /api/users/verify?token=).
+func (h *HTTPHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "HTTP VerifyEmail request received", slog.String("path", r.URL.Path))
+
+	rawToken := r.URL.Query().Get("token")
+	if rawToken == "" {
+		h.respondError(w, r, http.StatusBadRequest, "Query parameter 'token' is required")
+		return
+	}
+
+	token, err := h.store.GetEmailVerificationTokenByHash(ctx, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, store.ErrEmailVerificationTokenNotFound) {
+			h.logger.WarnContext(ctx, "Unknown email verification token presented")
+			h.respondError(w, r, http.StatusBadRequest, "Invalid or expired verification token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to look up email verification token", slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to verify email")
+		}
+		return
+	}
+	if token.UsedAt != nil || time.Now().UTC().After(token.ExpiresAt) {
+		h.respondError(w, r, http.StatusBadRequest, "Invalid or expired verification token")
+		return
+	}
+
+	user, err := h.store.GetByID(ctx, token.UserID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "User of email verification token not found", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid or expired verification token")
+		return
+	}
+	// Ссылка, отправленная на прежний адрес, не подтверждает новый
+	if !strings.EqualFold(user.Email, token.Email) {
+		h.logger.WarnContext(ctx, "Email verification token issued for a previous address", slog.String("userID", user.ID))
+		h.respondError(w, r, http.StatusBadRequest, "Invalid or expired verification token")
+		return
+	}
+
+	if err := h.store.MarkEmailVerificationTokenUsed(ctx, token.ID); err != nil {
+		if errors.Is(err, store.ErrEmailVerificationTokenUsed) {
+			h.respondError(w, r, http.StatusBadRequest, "Invalid or expired verification token")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to mark email verification token as used", slog.String("tokenID", token.ID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to verify email")
+		}
+		return
+	}
+
+	if !user.IsVerified() {
+		now := time.Now().UTC()
+		if err := h.store.SetEmailVerifiedAt(ctx, user.ID, &now); err != nil {
+			h.logger.ErrorContext(ctx, "Failed to mark email as verified", slog.String("userID", user.ID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to verify email")
+			return
+		}
+	}
+
+	h.logger.InfoContext(ctx, "Email verified successfully", slog.String("userID", user.ID))
+	// Уже выданный access токен содержит email_verified=false до следующего /token/refresh
+	h.respondJSON(w, r, http.StatusOK, map[string]string{"message": "Email verified. Refresh your access token to apply the change."})
+}
+
+// ResendVerificationEmail повторно отправляет письмо подтверждения (POST /api/users/me/verify/resend).
+// Не чаще одного письма в VerificationResendInterval; при превышении возвращает 429 с Retry-After.
+func (h *HTTPHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(UserIDKey).(string)
+	if !ok || userID == "" {
+		h.logger.ErrorContext(ctx, "UserID not found in request context for ResendVerificationEmail")
+		h.respondError(w, r, http.StatusInternalServerError, "Error processing user identity")
+		return
+	}
+	h.logger.InfoContext(ctx, "HTTP ResendVerificationEmail request received", slog.String("userID", userID))
+
+	user, err := h.store.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.respondError(w, r, http.StatusNotFound, "User associated with token not found")
+		} else {
+			h.logger.ErrorContext(ctx, "Failed to get user for verification resend", slog.String("userID", userID), slog.String("error", err.Error()))
+			h.respondError(w, r, http.StatusInternalServerError, "Failed to send verification email")
+		}
+		return
+	}
+	if user.IsVerified() {
+		h.respondError(w, r, http.StatusConflict, "Email is already verified")
+		return
+	}
+
+	latest, err := h.store.GetLatestEmailVerificationToken(ctx, userID)
+	if err != nil && !errors.Is(err, store.ErrEmailVerificationTokenNotFound) {
+		h.logger.ErrorContext(ctx, "Failed to get latest verification token", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+	if latest != nil {
+		if wait := time.Until(latest.CreatedAt.Add(h.config.VerificationResendInterval)); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			h.respondError(w, r, http.StatusTooManyRequests, "Verification email was sent recently, please try again later")
+			return
+		}
+	}
+
+	if err := h.sendVerificationEmail(ctx, user); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to resend verification email", slog.String("userID", userID), slog.String("error", err.Error()))
+		h.respondError(w, r, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+
+	h.logger.InfoContext(ctx, "Verification email resent", slog.String("userID", userID))
+	h.respondJSON(w, r, http.StatusAccepted, map[string]string{"message": "Verification email sent"})
+}
+
+// sendVerificationEmail создает токен подтверждения для текущего email пользователя и отправляет ссылку.
+func (h *HTTPHandler) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	verifyToken, verifyTokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	record := &domain.EmailVerificationToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: verifyTokenHash,
+		ExpiresAt: now.Add(h.config.EmailVerificationTokenDuration),
+		CreatedAt: now,
+	}
+	if err := h.store.CreateEmailVerificationToken(ctx, record); err != nil {
+		return fmt.Errorf("failed to store email verification token: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/api/users/verify?token=%s", strings.TrimRight(h.config.AppBaseURL, "/"), url.QueryEscape(verifyToken))
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hello, %s!\n\nPlease confirm your email address by opening the link below:\n%s\n\nThe link is valid for %s. Until your email is confirmed you can sign in, but you cannot post reviews or submit movies.\n",
+			user.Username, link, h.config.EmailVerificationTokenDuration),
+	}
+	return h.mailer.Send(ctx, msg)
+}
@@ -0,0 +1,73 @@
+// user-service/internal/clients/movie_service_client.go
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/genproto/moviepb" // Копия сгенерированного кода из movie-service
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// listPageSize - размер страницы при постраничном чтении данных из других сервисов.
+const listPageSize = 500
+
+// MovieServiceClient определяет методы MovieService, которые использует UserService.
+type MovieServiceClient interface {
+	// ListMoviesBySubmitter возвращает все фильмы, предложенные пользователем (включая удаленные).
+	ListMoviesBySubmitter(ctx context.Context, userID string) ([]*moviepb.SubmittedMovie, error)
+	Close() error
+}
+
+// movieServiceGRPCClient реализует MovieServiceClient с использованием gRPC.
+type movieServiceGRPCClient struct {
+	client moviepb.MovieInterServiceClient
+	logger *slog.Logger
+	conn   *grpc.ClientConn
+}
+
+// NewMovieServiceGRPCClient создает gRPC клиент для MovieService.
+// Соединение устанавливается лениво: UserService запускается, даже если MovieService недоступен.
+func NewMovieServiceGRPCClient(movieServiceAddr string, logger *slog.Logger) (MovieServiceClient, error) {
+	conn, err := grpc.NewClient(movieServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials())) // Для разработки; в продакшене используйте TLS
+	if err != nil {
+		return nil, fmt.Errorf("failed to create movie service client for %s: %w", movieServiceAddr, err)
+	}
+	logger.Info("MovieService gRPC client created", slog.String("address", movieServiceAddr))
+	return &movieServiceGRPCClient{
+		client: moviepb.NewMovieInterServiceClient(conn),
+		logger: logger,
+		conn:   conn,
+	}, nil
+}
+
+// ListMoviesBySubmitter читает фильмы пользователя постранично через gRPC ListMoviesBySubmitter.
+func (c *movieServiceGRPCClient) ListMoviesBySubmitter(ctx context.Context, userID string) ([]*moviepb.SubmittedMovie, error) {
+	var movies []*moviepb.SubmittedMovie
+	for page := int32(1); ; page++ {
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		res, err := c.client.ListMoviesBySubmitter(callCtx, &moviepb.ListMoviesBySubmitterRequest{UserId: userID, Page: page, PageSize: listPageSize})
+		cancel()
+		if err != nil {
+			c.logger.ErrorContext(ctx, "MovieService.ListMoviesBySubmitter gRPC call failed", slog.String("user_id", userID), slog.Int("page", int(page)), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("grpc ListMoviesBySubmitter failed for user %s: %w", userID, err)
+		}
+		movies = append(movies, res.GetMovies()...)
+		if len(res.GetMovies()) == 0 || len(movies) >= int(res.GetTotalCount()) {
+			return movies, nil
+		}
+	}
+}
+
+// Close закрывает gRPC соединение.
+func (c *movieServiceGRPCClient) Close() error {
+	if c.conn != nil {
+		c.logger.Info("Closing gRPC connection to MovieService")
+		return c.conn.Close()
+	}
+	return nil
+}
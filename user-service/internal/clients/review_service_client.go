@@ -0,0 +1,70 @@
+// user-service/internal/clients/review_service_client.go
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/genproto/reviewpb" // Копия сгенерированного кода из review-service
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ReviewServiceClient определяет методы ReviewService, которые использует UserService.
+type ReviewServiceClient interface {
+	// ListReviewsByUser возвращает все отзывы пользователя.
+	ListReviewsByUser(ctx context.Context, userID string) ([]*reviewpb.Review, error)
+	Close() error
+}
+
+// reviewServiceGRPCClient реализует ReviewServiceClient с использованием gRPC.
+type reviewServiceGRPCClient struct {
+	client reviewpb.ReviewInterServiceClient
+	logger *slog.Logger
+	conn   *grpc.ClientConn
+}
+
+// NewReviewServiceGRPCClient создает gRPC клиент для ReviewService.
+// Соединение устанавливается лениво: UserService запускается, даже если ReviewService недоступен.
+func NewReviewServiceGRPCClient(reviewServiceAddr string, logger *slog.Logger) (ReviewServiceClient, error) {
+	conn, err := grpc.NewClient(reviewServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials())) // Для разработки; в продакшене используйте TLS
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review service client for %s: %w", reviewServiceAddr, err)
+	}
+	logger.Info("ReviewService gRPC client created", slog.String("address", reviewServiceAddr))
+	return &reviewServiceGRPCClient{
+		client: reviewpb.NewReviewInterServiceClient(conn),
+		logger: logger,
+		conn:   conn,
+	}, nil
+}
+
+// ListReviewsByUser читает отзывы пользователя постранично через gRPC ListReviewsByUser.
+func (c *reviewServiceGRPCClient) ListReviewsByUser(ctx context.Context, userID string) ([]*reviewpb.Review, error) {
+	var reviews []*reviewpb.Review
+	for page := int32(1); ; page++ {
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		res, err := c.client.ListReviewsByUser(callCtx, &reviewpb.ListReviewsByUserRequest{UserId: userID, Page: page, PageSize: listPageSize})
+		cancel()
+		if err != nil {
+			c.logger.ErrorContext(ctx, "ReviewService.ListReviewsByUser gRPC call failed", slog.String("user_id", userID), slog.Int("page", int(page)), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("grpc ListReviewsByUser failed for user %s: %w", userID, err)
+		}
+		reviews = append(reviews, res.GetReviews()...)
+		if len(res.GetReviews()) == 0 || len(reviews) >= int(res.GetTotalCount()) {
+			return reviews, nil
+		}
+	}
+}
+
+// Close закрывает gRPC соединение.
+func (c *reviewServiceGRPCClient) Close() error {
+	if c.conn != nil {
+		c.logger.Info("Closing gRPC connection to ReviewService")
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// user-service/internal/domain/data_export.go
+package domain
+
+import "time"
+
+// Форматы архива выгрузки персональных данных
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZip  = "zip"
+)
+
+// ExportJobStatus - состояние асинхронной выгрузки
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportJob - асинхронная выгрузка персональных данных. Готовый архив хранится в БД
+// до ExpiresAt, затем удаляется вместе с записью.
+type ExportJob struct {
+	ID          string          `json:"id" db:"id"`
+	UserID      string          `json:"-" db:"user_id"`
+	Format      string          `json:"format" db:"format"`
+	Status      ExportJobStatus `json:"status" db:"status"`
+	Error       string          `json:"error,omitempty" db:"error"`
+	Archive     []byte          `json:"-" db:"archive"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+// IsActive сообщает, что выгрузка еще не завершена.
+func (j *ExportJob) IsActive() bool {
+	return j.Status == ExportJobPending || j.Status == ExportJobRunning
+}
+
+// ExportJobResponse - состояние выгрузки со ссылкой на скачивание готового архива (HTTP)
+type ExportJobResponse struct {
+	*ExportJob
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// UserDataExport - содержимое архива: все данные о пользователе, которые хранят сервисы.
+type UserDataExport struct {
+	GeneratedAt      time.Time        `json:"generated_at"`
+	Profile          *User            `json:"profile"`
+	TwoFactorEnabled bool             `json:"two_factor_enabled"`
+	Reviews          []ExportedReview `json:"reviews"`          // Из ReviewService
+	SubmittedMovies  []ExportedMovie  `json:"submitted_movies"` // Из MovieService, включая удаленные
+}
+
+// ExportedReview - отзыв пользователя в выгрузке
+type ExportedReview struct {
+	ID        string    `json:"id"`
+	MovieID   string    `json:"movie_id"`
+	Rating    int32     `json:"rating"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportedMovie - фильм, предложенный пользователем, в выгрузке
+type ExportedMovie struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	ReleaseYear int        `json:"release_year"`
+	Director    string     `json:"director"`
+	Genres      []string   `json:"genres"`
+	Cast        []string   `json:"cast"`
+	PosterURL   string     `json:"poster_url,omitempty"`
+	TrailerURL  string     `json:"trailer_url,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
@@ -0,0 +1,27 @@
+// user-service/internal/domain/follow.go
+package domain
+
+import "time"
+
+// FollowEntry - пользователь в списке подписчиков или подписок (публичные данные).
+type FollowEntry struct {
+	UserID      string    `json:"-" db:"user_id"` // Нужен для gRPC ListFollowing, в HTTP не отдается, как и в PublicProfile
+	Username    string    `json:"username" db:"username"`
+	DisplayName string    `json:"display_name,omitempty" db:"display_name"`
+	AvatarURL   string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	FollowedAt  time.Time `json:"followed_at" db:"followed_at"`
+}
+
+// FollowCounts - количество подписчиков и подписок пользователя.
+type FollowCounts struct {
+	Followers int `json:"followers_count" db:"followers_count"`
+	Following int `json:"following_count" db:"following_count"`
+}
+
+// FollowListResponse - страница подписчиков или подписок (HTTP)
+type FollowListResponse struct {
+	Users      []*FollowEntry `json:"users"`
+	TotalCount int            `json:"total_count"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+}
@@ -0,0 +1,15 @@
+// user-service/internal/domain/login_attempt.go
+package domain
+
+import (
+	"time"
+)
+
+// LoginAttempts хранит счетчик неудачных попыток входа для одного ключа.
+// Ключ - это аккаунт ("account:<email>") или IP клиента ("ip:<addr>").
+type LoginAttempts struct {
+	Key           string     `db:"key"`
+	Failures      int        `db:"failures"`        // Неудачные попытки подряд в пределах окна
+	LastFailureAt *time.Time `db:"last_failure_at"` // Время последней неудачной попытки
+	LockedUntil   *time.Time `db:"locked_until"`    // Блокировка входа до указанного времени
+}
@@ -0,0 +1,30 @@
+// user-service/internal/domain/role.go
+package domain
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Role - именованная роль с набором разрешений (см. shared/authz).
+// Встроенные роли (user, moderator, admin) задаются в коде, пользовательские хранятся в БД.
+type Role struct {
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	Permissions pq.StringArray `json:"permissions" db:"permissions"`
+	Builtin     bool           `json:"builtin" db:"-"`                       // Встроенную роль нельзя изменить или удалить
+	CreatedAt   *time.Time     `json:"created_at,omitempty" db:"created_at"` // nil у встроенных ролей
+	UpdatedAt   *time.Time     `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// SaveRoleRequest для создания или изменения пользовательской роли (HTTP)
+type SaveRoleRequest struct {
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+// RolesResponse - список ролей с разрешениями (HTTP)
+type RolesResponse struct {
+	Roles []Role `json:"roles"`
+}
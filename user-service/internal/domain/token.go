@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefreshToken представляет серверную запись refresh токена.
+// Сам токен клиенту отдается один раз, в БД хранится только его SHA-256 хеш.
+// Все токены, полученные ротацией из одного логина, имеют общий FamilyID.
+type RefreshToken struct {
+	ID        string     `db:"id"` // UUID
+	UserID    string     `db:"user_id"`
+	FamilyID  string     `db:"family_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`    // Время ротации; повторное предъявление использованного токена = reuse
+	RevokedAt *time.Time `db:"revoked_at"` // Время отзыва (logout или обнаружение reuse)
+}
+
+// RefreshTokenRequest для обновления пары токенов и для выхода (HTTP)
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// TokenPairResponse для ответа с новой парой токенов (HTTP)
+type TokenPairResponse struct {
+	Token        string `json:"token"`         // Короткоживущий access токен (JWT)
+	RefreshToken string `json:"refresh_token"` // Ротируемый refresh токен
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // Время жизни access токена в секундах
+	// TwoFactorSetupRequired - политика требует 2FA для роли пользователя, но она не подключена;
+	// до подключения токен выдается с ограниченной ролью
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+}
+
+// EmailVerificationToken представляет одноразовый токен подтверждения email.
+// Токен привязан к адресу, на который был отправлен: после смены email старые ссылки не действуют.
+type EmailVerificationToken struct {
+	ID        string     `db:"id"` // UUID
+	UserID    string     `db:"user_id"`
+	Email     string     `db:"email"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// PasswordResetToken представляет одноразовый токен сброса пароля.
+// Как и для refresh токенов, в БД хранится только SHA-256 хеш.
+type PasswordResetToken struct {
+	ID        string     `db:"id"` // UUID
+	UserID    string     `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"` // Время использования; токен одноразовый
+}
@@ -0,0 +1,59 @@
+// user-service/internal/domain/two_factor.go
+package domain
+
+import (
+	"time"
+)
+
+// TOTPSecret хранит секрет TOTP пользователя.
+// Пока EnabledAt == nil, секрет ожидает подтверждения первым кодом и на вход не влияет.
+type TOTPSecret struct {
+	UserID       string     `db:"user_id"`
+	Secret       string     `db:"secret"` // base32
+	EnabledAt    *time.Time `db:"enabled_at"`
+	LastUsedStep int64      `db:"last_used_step"` // Последний принятый шаг TOTP (защита от повторного использования кода)
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// LoginChallenge - короткоживущий одноразовый токен между первым (пароль)
+// и вторым (код TOTP) шагами входа. В БД хранится только хеш.
+type LoginChallenge struct {
+	ID        string     `db:"id"` // UUID
+	UserID    string     `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// TwoFactorSetupResponse для ответа на начало подключения 2FA (HTTP)
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`      // Для ручного ввода в приложение
+	OTPAuthURI string `json:"otpauth_uri"` // Для QR-кода
+}
+
+// TwoFactorConfirmRequest для подтверждения подключения 2FA первым кодом (HTTP)
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// TwoFactorConfirmResponse возвращает одноразовые коды восстановления (показываются один раз)
+// и новую пару токенов: остальные сессии после включения 2FA завершаются.
+type TwoFactorConfirmResponse struct {
+	RecoveryCodes []string           `json:"recovery_codes"`
+	Tokens        *TokenPairResponse `json:"tokens"`
+}
+
+// LoginChallengeResponse возвращается первым шагом входа, если у пользователя включена 2FA (HTTP)
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int64  `json:"expires_in"` // Время жизни challenge токена в секундах
+}
+
+// TwoFactorLoginRequest для второго шага входа: код TOTP или код восстановления (HTTP)
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code,omitempty" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code,omitempty" validate:"required_without=Code"`
+}
@@ -2,17 +2,42 @@ package domain
 
 import (
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // User представляет модель пользователя в вашем приложении
 type User struct {
-	ID           string    `json:"id" db:"id"` // UUID
-	Username     string    `json:"username" db:"username"`
-	Email        string    `json:"email" db:"email"`
-	PasswordHash string    `json:"-" db:"password_hash"`     // Не отдаем хеш пароля в JSON
-	Role         string    `json:"role,omitempty" db:"role"` // Например, "user", "admin"
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string     `json:"id" db:"id"` // UUID
+	Username     string     `json:"username" db:"username"`
+	Email        string     `json:"email" db:"email"`
+	PasswordHash string     `json:"-" db:"password_hash"`                   // Не отдаем хеш пароля в JSON
+	Role         string     `json:"role,omitempty" db:"role"`               // Например, "user", "admin"
+	VerifiedAt   *time.Time `json:"verified_at,omitempty" db:"verified_at"` // Время подтверждения email; nil - не подтвержден
+	// Блокировка аккаунта администратором; SuspendedUntil == nil - бессрочно
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty" db:"suspended_until"`
+	SuspensionReason *string    `json:"suspension_reason,omitempty" db:"suspension_reason"`
+	// Публичный профиль (необязательные поля; пустые значения не показываются)
+	DisplayName    string         `json:"display_name,omitempty" db:"display_name"`
+	Bio            string         `json:"bio,omitempty" db:"bio"`
+	AvatarURL      string         `json:"avatar_url,omitempty" db:"avatar_url"`
+	Location       string         `json:"location,omitempty" db:"location"`
+	FavoriteGenres pq.StringArray `json:"favorite_genres,omitempty" db:"favorite_genres"`
+	// Запрошенное удаление аккаунта: после этого времени аккаунт удаляется безвозвратно
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsSuspended сообщает, заблокирован ли аккаунт в момент now (истекшая блокировка не действует).
+func (u *User) IsSuspended(now time.Time) bool {
+	return u.SuspendedAt != nil && (u.SuspendedUntil == nil || u.SuspendedUntil.After(now))
+}
+
+// IsVerified сообщает, подтвердил ли пользователь свой email.
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
 }
 
 // RegisterRequest для регистрации нового пользователя (HTTP)
@@ -30,13 +55,63 @@ type LoginRequest struct {
 
 // LoginResponse для ответа при успешном входе (HTTP)
 type LoginResponse struct {
-	User  *User  `json:"user"` // Можно возвращать User DTO без хеша
-	Token string `json:"token"`
+	User         *User  `json:"user"`          // Можно возвращать User DTO без хеша
+	Token        string `json:"token"`         // Короткоживущий access токен (JWT)
+	RefreshToken string `json:"refresh_token"` // Ротируемый refresh токен для /token/refresh
+	ExpiresIn    int64  `json:"expires_in"`    // Время жизни access токена в секундах
+	// TwoFactorSetupRequired - см. TokenPairResponse.TwoFactorSetupRequired
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
 }
 
-// UpdateProfileRequest для обновления профиля (HTTP)
+// PublicProfile - публичная карточка пользователя (без email и служебных полей)
+type PublicProfile struct {
+	Username       string    `json:"username"`
+	DisplayName    string    `json:"display_name,omitempty"`
+	Bio            string    `json:"bio,omitempty"`
+	AvatarURL      string    `json:"avatar_url,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	FavoriteGenres []string  `json:"favorite_genres"`
+	MemberSince    time.Time `json:"member_since"`
+	FollowCounts
+}
+
+// UpdateProfileRequest для обновления профиля (HTTP).
+// Для полей профиля пустая строка (пустой список жанров) очищает значение.
 type UpdateProfileRequest struct {
-	Username *string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
-	Email    *string `json:"email,omitempty" validate:"omitempty,email"`
-	// Не позволяем менять пароль этим эндпоинтом, для этого нужен отдельный
+	Username       *string   `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	Email          *string   `json:"email,omitempty" validate:"omitempty,email"`
+	DisplayName    *string   `json:"display_name,omitempty" validate:"omitempty,max=50"`
+	Bio            *string   `json:"bio,omitempty" validate:"omitempty,max=500"`
+	AvatarURL      *string   `json:"avatar_url,omitempty" validate:"omitempty,http_url,max=500"`
+	Location       *string   `json:"location,omitempty" validate:"omitempty,max=100"`
+	FavoriteGenres *[]string `json:"favorite_genres,omitempty" validate:"omitempty,max=10,dive,min=1,max=50"`
+	// Не позволяем менять пароль этим эндпоинтом, для этого есть PUT /api/users/me/password
+}
+
+// ChangePasswordRequest для смены пароля аутентифицированным пользователем (HTTP)
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6,max=100"`
+}
+
+// ForgotPasswordRequest для запроса письма со ссылкой на сброс пароля (HTTP)
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest для установки нового пароля по токену из письма (HTTP)
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6,max=100"`
+}
+
+// ChangeRoleRequest для смены роли пользователя администратором (HTTP)
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required,max=32"` // Встроенная (user, moderator, admin) или пользовательская роль
+}
+
+// SuspendUserRequest для блокировки аккаунта администратором (HTTP)
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" validate:"required,max=500"`
+	Until  *time.Time `json:"until,omitempty"` // RFC 3339; без значения - бессрочно
 }
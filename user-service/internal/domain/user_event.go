@@ -0,0 +1,32 @@
+// user-service/internal/domain/user_event.go
+package domain
+
+import (
+	"time"
+)
+
+// Типы событий пользователей, публикуемых для других сервисов.
+const (
+	// UserEventDeleted - аккаунт удален; сервисы-потребители удаляют или обезличивают данные пользователя.
+	UserEventDeleted = "user.deleted"
+)
+
+// UserEvent - запись outbox событий пользователей. ID монотонно возрастает,
+// потребители запоминают последний обработанный ID и продолжают с него.
+type UserEvent struct {
+	ID         int64     `json:"id" db:"id"`
+	Type       string    `json:"type" db:"type"`
+	UserID     string    `json:"user_id" db:"user_id"`
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+}
+
+// DeleteAccountRequest для запроса удаления своего аккаунта (HTTP)
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// AccountDeletionResponse - ответ на запрос удаления аккаунта (HTTP)
+type AccountDeletionResponse struct {
+	Message             string    `json:"message"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
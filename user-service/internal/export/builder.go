@@ -0,0 +1,152 @@
+// user-service/internal/export/builder.go
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-service/internal/clients"
+	"user-service/internal/domain"
+	"user-service/internal/store"
+)
+
+// archiveEntryName - имя JSON файла внутри zip архива.
+const archiveEntryName = "user-data.json"
+
+// Builder собирает выгрузку персональных данных пользователя: профиль из UserService,
+// отзывы из ReviewService и предложенные фильмы из MovieService (по gRPC).
+type Builder struct {
+	store   store.UserStore
+	movies  clients.MovieServiceClient
+	reviews clients.ReviewServiceClient
+}
+
+// NewBuilder создает Builder.
+func NewBuilder(s store.UserStore, movies clients.MovieServiceClient, reviews clients.ReviewServiceClient) *Builder {
+	return &Builder{store: s, movies: movies, reviews: reviews}
+}
+
+// IsValidFormat сообщает, поддерживается ли формат архива.
+func IsValidFormat(format string) bool {
+	return format == domain.ExportFormatJSON || format == domain.ExportFormatZip
+}
+
+// ContentType возвращает MIME тип архива.
+func ContentType(format string) string {
+	if format == domain.ExportFormatZip {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+// FileName возвращает имя файла архива для Content-Disposition.
+func FileName(userID string, format string, generatedAt time.Time) string {
+	return fmt.Sprintf("user-data-%s-%s.%s", userID, generatedAt.UTC().Format("20060102"), format)
+}
+
+// Collect собирает данные пользователя. Ошибка любого из сервисов прерывает выгрузку:
+// неполный архив для запроса по GDPR хуже повторной попытки.
+func (b *Builder) Collect(ctx context.Context, userID string) (*domain.UserDataExport, error) {
+	user, err := b.store.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+	data := &domain.UserDataExport{
+		GeneratedAt:     time.Now().UTC(),
+		Profile:         user,
+		Reviews:         []domain.ExportedReview{},
+		SubmittedMovies: []domain.ExportedMovie{},
+	}
+
+	totp, err := b.store.GetTOTP(ctx, userID)
+	switch {
+	case err == nil:
+		data.TwoFactorEnabled = totp.EnabledAt != nil
+	case !errors.Is(err, store.ErrTOTPNotFound):
+		return nil, fmt.Errorf("failed to load two-factor settings: %w", err)
+	}
+
+	reviews, err := b.reviews.ListReviewsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviews: %w", err)
+	}
+	for _, review := range reviews {
+		data.Reviews = append(data.Reviews, domain.ExportedReview{
+			ID:        review.GetId(),
+			MovieID:   review.GetMovieId(),
+			Rating:    review.GetRating(),
+			Comment:   review.GetComment(),
+			CreatedAt: review.GetCreatedAt().AsTime(),
+			UpdatedAt: review.GetUpdatedAt().AsTime(),
+		})
+	}
+
+	movies, err := b.movies.ListMoviesBySubmitter(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load submitted movies: %w", err)
+	}
+	for _, movie := range movies {
+		exported := domain.ExportedMovie{
+			ID:          movie.GetId(),
+			Title:       movie.GetTitle(),
+			Description: movie.GetDescription(),
+			ReleaseYear: int(movie.GetReleaseYear()),
+			Director:    movie.GetDirector(),
+			Genres:      append([]string{}, movie.GetGenres()...),
+			Cast:        append([]string{}, movie.GetCast()...),
+			PosterURL:   movie.GetPosterUrl(),
+			TrailerURL:  movie.GetTrailerUrl(),
+			Status:      movie.GetStatus(),
+			CreatedAt:   movie.GetCreatedAt().AsTime(),
+			UpdatedAt:   movie.GetUpdatedAt().AsTime(),
+		}
+		if movie.GetDeletedAt() != nil {
+			deletedAt := movie.GetDeletedAt().AsTime()
+			exported.DeletedAt = &deletedAt
+		}
+		data.SubmittedMovies = append(data.SubmittedMovies, exported)
+	}
+	return data, nil
+}
+
+// Build собирает данные пользователя и упаковывает их в архив формата format.
+func (b *Builder) Build(ctx context.Context, userID string, format string) ([]byte, error) {
+	data, err := b.Collect(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(data, format)
+}
+
+// Encode сериализует выгрузку в JSON и, для формата zip, упаковывает его в архив.
+func Encode(data *domain.UserDataExport, format string) ([]byte, error) {
+	if !IsValidFormat(format) {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	payload, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+	if format == domain.ExportFormatJSON {
+		return payload, nil
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: archiveEntryName, Method: zip.Deflate, Modified: data.GeneratedAt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zip entry: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return nil, fmt.Errorf("failed to write zip entry: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize zip archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
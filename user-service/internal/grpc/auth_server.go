@@ -0,0 +1,174 @@
+// user-service/internal/grpc/auth_server.go
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"math"
+	"net"
+
+	"user-service/internal/api"
+	"user-service/internal/domain"
+	"user-service/internal/genproto/userpb"
+	"user-service/internal/store"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetUserByEmail реализует gRPC метод GetUserByEmail.
+func (s *Server) GetUserByEmail(ctx context.Context, req *userpb.GetUserByEmailRequest) (*userpb.UserResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "email cannot be empty")
+	}
+
+	user, err := s.store.GetByEmail(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.ErrorContext(ctx, "Failed to get user by email from store", slog.String("email", req.GetEmail()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve user details: %v", err)
+	}
+	return domainUserToProto(user), nil
+}
+
+// RegisterUser реализует gRPC метод RegisterUser (правила те же, что у POST /api/users/register).
+func (s *Server) RegisterUser(ctx context.Context, req *userpb.RegisterUserRequest) (*userpb.UserResponse, error) {
+	user, err := s.auth.Register(ctx, domain.RegisterRequest{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, authErrorToStatus(err)
+	}
+	s.logger.InfoContext(ctx, "User registered via gRPC", slog.String("user_id", user.ID))
+	return domainUserToProto(user), nil
+}
+
+// LoginUser реализует gRPC метод LoginUser (первый шаг входа, как POST /api/users/login).
+// Второй шаг при включенной 2FA выполняется через HTTP: POST /api/users/login/2fa.
+//
+// Попытки всегда считаются по адресу вызывающего (peerIP). client_ip учитывается дополнительно
+// и только от доверенного шлюза; от остальных вызывающих он игнорируется, иначе подменой
+// client_ip можно было бы обойти счетчик по IP.
+func (s *Server) LoginUser(ctx context.Context, req *userpb.LoginUserRequest) (*userpb.LoginUserResponse, error) {
+	clientIPs := []string{peerIP(ctx)}
+	if clientIP := req.GetClientIp(); clientIP != "" {
+		if s.trustedGateway(ctx) {
+			clientIPs = append(clientIPs, clientIP)
+		} else {
+			s.logger.WarnContext(ctx, "Ignoring client_ip from untrusted caller", slog.String("peer", clientIPs[0]), slog.String("client_ip", clientIP))
+		}
+	}
+
+	result, err := s.auth.Login(ctx, domain.LoginRequest{Email: req.GetEmail(), Password: req.GetPassword()}, clientIPs...)
+	if err != nil {
+		return nil, authErrorToStatus(err)
+	}
+
+	response := &userpb.LoginUserResponse{User: domainUserToProto(result.User)}
+	if result.Challenge != nil {
+		response.TwoFactorRequired = true
+		response.ChallengeToken = result.Challenge.ChallengeToken
+		response.ExpiresIn = result.Challenge.ExpiresIn
+		return response, nil
+	}
+	response.AccessToken = result.Tokens.Token
+	response.RefreshToken = result.Tokens.RefreshToken
+	response.ExpiresIn = result.Tokens.ExpiresIn
+	response.TwoFactorSetupRequired = result.Tokens.TwoFactorSetupRequired
+	return response, nil
+}
+
+// ValidateToken реализует gRPC метод ValidateToken: проверяет подпись и срок действия access токена
+// и то, что аккаунт существует и не заблокирован.
+func (s *Server) ValidateToken(ctx context.Context, req *userpb.ValidateTokenRequest) (*userpb.ValidateTokenResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "token cannot be empty")
+	}
+
+	claims, err := s.auth.AuthenticateToken(ctx, req.GetToken())
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "user associated with token not found")
+		}
+		return nil, authErrorToStatus(err)
+	}
+
+	response := &userpb.ValidateTokenResponse{
+		UserId:        claims.UserID,
+		Role:          claims.Role,
+		Permissions:   claims.Permissions,
+		EmailVerified: claims.EmailVerified,
+	}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+	}
+	return response, nil
+}
+
+// authErrorToStatus преобразует ошибки api.HTTPHandler (Register, Login, AuthenticateToken) в gRPC статусы.
+func authErrorToStatus(err error) error {
+	var validationErrors validator.ValidationErrors
+	var throttled *api.LoginThrottledError
+	var suspended *api.AccountSuspendedError
+	switch {
+	case errors.As(err, &validationErrors):
+		return status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	case errors.Is(err, api.ErrUsernameReserved):
+		return status.Errorf(codes.InvalidArgument, "username is reserved")
+	case errors.Is(err, store.ErrUserAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "user with this email or username already exists")
+	case errors.Is(err, api.ErrInvalidCredentials):
+		return status.Errorf(codes.Unauthenticated, "invalid email or password")
+	case errors.Is(err, api.ErrInvalidToken):
+		return status.Errorf(codes.Unauthenticated, "invalid or expired token")
+	case errors.As(err, &throttled):
+		return status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry after %d seconds", int64(math.Ceil(throttled.RetryAfter.Seconds())))
+	case errors.As(err, &suspended):
+		return status.Errorf(codes.PermissionDenied, "account is suspended")
+	default:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+}
+
+// peerIP возвращает IP адрес вызывающей стороны или пустую строку, если он неизвестен.
+// gatewayTokenHeader - ключ метаданных, в котором шлюз передает свой сервисный токен.
+const gatewayTokenHeader = "x-gateway-token"
+
+// trustedGateway сообщает, предъявил ли вызывающий сервисный токен доверенного шлюза.
+func (s *Server) trustedGateway(ctx context.Context) bool {
+	if s.gatewayToken == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, token := range md.Get(gatewayTokenHeader) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.gatewayToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
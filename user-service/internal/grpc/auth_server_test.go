@@ -0,0 +1,71 @@
+// user-service/internal/grpc/auth_server_test.go
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+
+	"user-service/internal/api"
+	"user-service/internal/domain"
+	"user-service/internal/genproto/userpb"
+	"user-service/pkg/auth"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// recordingAuthenticator запоминает адреса, переданные в Login.
+type recordingAuthenticator struct {
+	clientIPs []string
+}
+
+func (a *recordingAuthenticator) Register(ctx context.Context, req domain.RegisterRequest) (*domain.User, error) {
+	return nil, nil
+}
+
+func (a *recordingAuthenticator) Login(ctx context.Context, req domain.LoginRequest, clientIPs ...string) (*api.LoginResult, error) {
+	a.clientIPs = clientIPs
+	return &api.LoginResult{User: &domain.User{ID: "user1"}, Tokens: &domain.TokenPairResponse{}}, nil
+}
+
+func (a *recordingAuthenticator) AuthenticateToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	return nil, nil
+}
+
+func TestLoginUserClientIP(t *testing.T) {
+	const gatewayToken = "gateway-secret"
+	tests := []struct {
+		name          string
+		serverToken   string
+		metadataToken string
+		clientIP      string
+		want          []string
+	}{
+		{name: "no client_ip", serverToken: gatewayToken, want: []string{"10.0.0.1"}},
+		{name: "client_ip from untrusted caller is ignored", serverToken: gatewayToken, clientIP: "203.0.113.7", want: []string{"10.0.0.1"}},
+		{name: "wrong gateway token", serverToken: gatewayToken, metadataToken: "guess", clientIP: "203.0.113.7", want: []string{"10.0.0.1"}},
+		{name: "gateway token not configured", metadataToken: gatewayToken, clientIP: "203.0.113.7", want: []string{"10.0.0.1"}},
+		{name: "trusted gateway adds client_ip", serverToken: gatewayToken, metadataToken: gatewayToken, clientIP: "203.0.113.7", want: []string{"10.0.0.1", "203.0.113.7"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := &recordingAuthenticator{}
+			server := NewServer(nil, authenticator, slog.New(slog.NewTextHandler(io.Discard, nil)), tt.serverToken)
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}})
+			if tt.metadataToken != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(gatewayTokenHeader, tt.metadataToken))
+			}
+			if _, err := server.LoginUser(ctx, &userpb.LoginUserRequest{Email: "user@example.com", Password: "password", ClientIp: tt.clientIP}); err != nil {
+				t.Fatalf("LoginUser: %v", err)
+			}
+			if !slices.Equal(authenticator.clientIPs, tt.want) {
+				t.Errorf("clientIPs = %v, want %v", authenticator.clientIPs, tt.want)
+			}
+		})
+	}
+}
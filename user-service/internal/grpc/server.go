@@ -5,27 +5,42 @@ import (
 	"errors"
 	"log/slog" // Для логирования
 
+	"user-service/internal/api"
 	"user-service/internal/domain"          // Ваша доменная модель User
 	"user-service/internal/genproto/userpb" // Сгенерированный gRPC код
 	"user-service/internal/store"           // Ваш интерфейс UserStore
+	"user-service/pkg/auth"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Authenticator - регистрация, вход и проверка токенов; реализуется *api.HTTPHandler,
+// чтобы gRPC и HTTP применяли одни и те же правила.
+type Authenticator interface {
+	Register(ctx context.Context, req domain.RegisterRequest) (*domain.User, error)
+	Login(ctx context.Context, req domain.LoginRequest, clientIPs ...string) (*api.LoginResult, error)
+	AuthenticateToken(ctx context.Context, tokenString string) (*auth.Claims, error)
+}
+
 // Server реализует интерфейс userpb.UserServiceServer
 type Server struct {
 	userpb.UnimplementedUserServiceServer                 // Обязательно для прямой совместимости
 	store                                 store.UserStore // Зависимость от хранилища пользователей
+	auth                                  Authenticator
 	logger                                *slog.Logger
+	gatewayToken                          string // Токен доверенного шлюза (см. trustedGateway); пусто - шлюзам не доверяем
 }
 
 // NewServer создает новый экземпляр gRPC сервера для UserService.
-func NewServer(userStore store.UserStore, logger *slog.Logger) *Server {
+// gatewayToken - сервисный токен шлюза, которому разрешено передавать client_ip в LoginUser.
+func NewServer(userStore store.UserStore, authenticator Authenticator, logger *slog.Logger, gatewayToken string) *Server {
 	return &Server{
-		store:  userStore,
-		logger: logger,
+		store:        userStore,
+		auth:         authenticator,
+		logger:       logger,
+		gatewayToken: gatewayToken,
 	}
 }
 
@@ -35,11 +50,16 @@ func domainUserToProto(user *domain.User) *userpb.UserResponse {
 		return nil
 	}
 	return &userpb.UserResponse{
-		Id:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Id:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		CreatedAt:      timestamppb.New(user.CreatedAt),
+		UpdatedAt:      timestamppb.New(user.UpdatedAt),
+		DisplayName:    user.DisplayName,
+		AvatarUrl:      user.AvatarURL,
+		Bio:            user.Bio,
+		Location:       user.Location,
+		FavoriteGenres: user.FavoriteGenres,
 		// Поле Role можно добавить, если оно есть в UserResponse proto
 	}
 }
@@ -67,5 +87,104 @@ func (s *Server) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*user
 	return domainUserToProto(user), nil
 }
 
-// TODO: Реализовать другие gRPC методы, если они будут определены в user.proto
-// (например, для регистрации, если решите делать ее через gRPC)
+// maxBatchGetUsers - максимальное количество ID в одном вызове BatchGetUsers
+const maxBatchGetUsers = 500
+
+// BatchGetUsers реализует gRPC метод BatchGetUsers: пользователи по списку ID одним запросом к хранилищу.
+// Отсутствующие пользователи просто не попадают в ответ.
+func (s *Server) BatchGetUsers(ctx context.Context, req *userpb.BatchGetUsersRequest) (*userpb.BatchGetUsersResponse, error) {
+	if len(req.GetUserIds()) > maxBatchGetUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "too many user_ids: %d (max %d)", len(req.GetUserIds()), maxBatchGetUsers)
+	}
+	if len(req.GetUserIds()) == 0 {
+		return &userpb.BatchGetUsersResponse{}, nil
+	}
+
+	users, err := s.store.GetByIDs(ctx, req.GetUserIds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get users by IDs from store", slog.Int("requested", len(req.GetUserIds())), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve users: %v", err)
+	}
+
+	response := &userpb.BatchGetUsersResponse{Users: make([]*userpb.UserResponse, 0, len(users))}
+	for _, user := range users {
+		response.Users = append(response.Users, domainUserToProto(user))
+	}
+	s.logger.DebugContext(ctx, "Users retrieved via gRPC batch", slog.Int("requested", len(req.GetUserIds())), slog.Int("found", len(response.Users)))
+	return response, nil
+}
+
+// Ограничения размера страницы ListUserEvents
+const (
+	defaultUserEventsLimit = 100
+	maxUserEventsLimit     = 500
+)
+
+// ListUserEvents реализует gRPC метод ListUserEvents: отдает события outbox после after_id.
+func (s *Server) ListUserEvents(ctx context.Context, req *userpb.ListUserEventsRequest) (*userpb.ListUserEventsResponse, error) {
+	if req.GetAfterId() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "after_id cannot be negative")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultUserEventsLimit
+	}
+	if limit > maxUserEventsLimit {
+		limit = maxUserEventsLimit
+	}
+
+	events, err := s.store.ListUserEvents(ctx, req.GetAfterId(), limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list user events from store", slog.Int64("after_id", req.GetAfterId()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to list user events: %v", err)
+	}
+
+	response := &userpb.ListUserEventsResponse{Events: make([]*userpb.UserEvent, 0, len(events))}
+	for _, event := range events {
+		response.Events = append(response.Events, &userpb.UserEvent{
+			Id:         event.ID,
+			Type:       event.Type,
+			UserId:     event.UserID,
+			OccurredAt: timestamppb.New(event.OccurredAt),
+		})
+	}
+	s.logger.DebugContext(ctx, "User events listed via gRPC", slog.Int64("after_id", req.GetAfterId()), slog.Int("count", len(response.Events)))
+	return response, nil
+}
+
+// Ограничения размера страницы ListFollowing
+const (
+	defaultFollowingPageSize = 100
+	maxFollowingPageSize     = 500
+)
+
+// ListFollowing реализует gRPC метод ListFollowing: ID пользователей, на которых подписан user_id.
+func (s *Server) ListFollowing(ctx context.Context, req *userpb.ListFollowingRequest) (*userpb.ListFollowingResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id cannot be empty")
+	}
+	page := int(req.GetPage())
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultFollowingPageSize
+	}
+	if pageSize > maxFollowingPageSize {
+		pageSize = maxFollowingPageSize
+	}
+
+	entries, totalCount, err := s.store.ListFollowing(ctx, req.GetUserId(), page, pageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list following from store", slog.String("user_id", req.GetUserId()), slog.String("error", err.Error()))
+		return nil, status.Errorf(codes.Internal, "failed to list following: %v", err)
+	}
+
+	response := &userpb.ListFollowingResponse{UserIds: make([]string, 0, len(entries)), TotalCount: int32(totalCount)}
+	for _, entry := range entries {
+		response.UserIds = append(response.UserIds, entry.UserID)
+	}
+	s.logger.DebugContext(ctx, "Following listed via gRPC", slog.String("user_id", req.GetUserId()), slog.Int("count", len(response.UserIds)))
+	return response, nil
+}
@@ -0,0 +1,65 @@
+// user-service/internal/grpc/server_test.go
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/genproto/userpb"
+	"user-service/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchGetUsers(t *testing.T) {
+	userStore := store.NewMockUserStore()
+	for _, id := range []string{"user-a", "user-b"} {
+		if err := userStore.Create(context.Background(), &domain.User{ID: id, Username: id, Email: id + "@example.com", Role: "user"}); err != nil {
+			t.Fatalf("Create %s: %v", id, err)
+		}
+	}
+	server := NewServer(userStore, nil, slog.New(slog.NewTextHandler(io.Discard, nil)), "")
+
+	tooMany := make([]string, maxBatchGetUsers+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("user-%d", i)
+	}
+	tests := []struct {
+		name     string
+		ids      []string
+		wantCode codes.Code
+		wantIDs  []string
+	}{
+		{name: "empty request", wantCode: codes.OK},
+		{name: "known users", ids: []string{"user-a", "user-b"}, wantCode: codes.OK, wantIDs: []string{"user-a", "user-b"}},
+		{name: "unknown ids are skipped", ids: []string{"deleted-user", "user-b", "not-a-uuid"}, wantCode: codes.OK, wantIDs: []string{"user-b"}},
+		{name: "duplicates returned once", ids: []string{"user-a", "user-a"}, wantCode: codes.OK, wantIDs: []string{"user-a"}},
+		{name: "max batch size", ids: append(tooMany[:maxBatchGetUsers-1:maxBatchGetUsers-1], "user-a"), wantCode: codes.OK, wantIDs: []string{"user-a"}},
+		{name: "over max batch size", ids: tooMany, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.BatchGetUsers(context.Background(), &userpb.BatchGetUsersRequest{UserIds: tt.ids})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			var gotIDs []string
+			for _, user := range resp.GetUsers() {
+				gotIDs = append(gotIDs, user.GetId())
+			}
+			slices.Sort(gotIDs)
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("users = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// user-service/internal/mailer/mailer.go
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message - письмо, отправляемое пользователю.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer определяет интерфейс отправки писем.
+// Реальный SMTP/API провайдер можно подключить, реализовав этот интерфейс.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogMailer "отправляет" письма в лог. Подходит для локальной разработки.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+// NewLogMailer создает новый экземпляр LogMailer.
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "Outgoing email (log mailer)",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body))
+	return nil
+}
+
+// FileMailer сохраняет каждое письмо в отдельный .eml файл в каталоге outboxDir.
+type FileMailer struct {
+	outboxDir string
+	logger    *slog.Logger
+}
+
+// NewFileMailer создает новый экземпляр FileMailer и при необходимости создает каталог.
+func NewFileMailer(outboxDir string, logger *slog.Logger) (*FileMailer, error) {
+	if outboxDir == "" {
+		return nil, fmt.Errorf("mail outbox directory cannot be empty")
+	}
+	if err := os.MkdirAll(outboxDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail outbox directory: %w", err)
+	}
+	return &FileMailer{outboxDir: outboxDir, logger: logger}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now().UTC()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(m.outboxDir, name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write email to %s: %w", path, err)
+	}
+	m.logger.InfoContext(ctx, "Email written to outbox", slog.String("to", msg.To), slog.String("path", path))
+	return nil
+}
@@ -0,0 +1,124 @@
+// user-service/internal/store/account_deletion_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) ScheduleDeletion(ctx context.Context, userID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	log.Printf("[MOCK USER STORE] Scheduling deletion of user %s at %s\n", userID, at.Format(time.RFC3339))
+	scheduledAt := at
+	user.DeletionScheduledAt = &scheduledAt
+	user.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (m *MockUserStore) CancelDeletion(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	log.Printf("[MOCK USER STORE] Cancelling deletion of user %s\n", userID)
+	user.DeletionScheduledAt = nil
+	user.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (m *MockUserStore) PurgeScheduledUsers(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*domain.User
+	for _, user := range m.users {
+		if user.DeletionScheduledAt != nil && !user.DeletionScheduledAt.After(now) {
+			due = append(due, user)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].DeletionScheduledAt.Before(*due[j].DeletionScheduledAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	purged := make([]string, 0, len(due))
+	for _, user := range due {
+		m.deleteUserDataLocked(user)
+		var nextID int64 = 1
+		if n := len(m.userEvents); n > 0 {
+			nextID = m.userEvents[n-1].ID + 1
+		}
+		m.userEvents = append(m.userEvents, &domain.UserEvent{ID: nextID, Type: domain.UserEventDeleted, UserID: user.ID, OccurredAt: now})
+		purged = append(purged, user.ID)
+		log.Printf("[MOCK USER STORE] User %s purged, event %d recorded\n", user.ID, nextID)
+	}
+	return purged, nil
+}
+
+// deleteUserDataLocked удаляет пользователя и все связанные с ним записи (аналог ON DELETE CASCADE).
+// Вызывается под m.mu.
+func (m *MockUserStore) deleteUserDataLocked(user *domain.User) {
+	delete(m.users, user.ID)
+	delete(m.usersByEmail, user.Email)
+	delete(m.loginAttempts, "account:"+strings.ToLower(user.Email))
+	delete(m.totpSecrets, user.ID)
+	delete(m.recoveryCodes, user.ID)
+	for hash, token := range m.refreshTokens {
+		if token.UserID == user.ID {
+			delete(m.refreshTokens, hash)
+		}
+	}
+	for hash, token := range m.resetTokens {
+		if token.UserID == user.ID {
+			delete(m.resetTokens, hash)
+		}
+	}
+	for hash, token := range m.verifyTokens {
+		if token.UserID == user.ID {
+			delete(m.verifyTokens, hash)
+		}
+	}
+	for hash, challenge := range m.challenges {
+		if challenge.UserID == user.ID {
+			delete(m.challenges, hash)
+		}
+	}
+	for id, job := range m.exportJobs {
+		if job.UserID == user.ID {
+			delete(m.exportJobs, id)
+		}
+	}
+	delete(m.follows, user.ID)
+	for _, followees := range m.follows {
+		delete(followees, user.ID)
+	}
+}
+
+func (m *MockUserStore) ListUserEvents(ctx context.Context, afterID int64, limit int) ([]*domain.UserEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	events := []*domain.UserEvent{}
+	for _, event := range m.userEvents {
+		if event.ID <= afterID {
+			continue
+		}
+		if len(events) == limit {
+			break
+		}
+		eventCopy := *event
+		events = append(events, &eventCopy)
+	}
+	return events, nil
+}
@@ -0,0 +1,63 @@
+// user-service/internal/store/email_verification_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Creating email verification token: ID='%s', UserID='%s'\n", token.ID, token.UserID)
+
+	tokenCopy := *token
+	m.verifyTokens[token.TokenHash] = &tokenCopy
+	return nil
+}
+
+func (m *MockUserStore) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if token, ok := m.verifyTokens[tokenHash]; ok {
+		tokenCopy := *token
+		return &tokenCopy, nil
+	}
+	return nil, ErrEmailVerificationTokenNotFound
+}
+
+func (m *MockUserStore) GetLatestEmailVerificationToken(ctx context.Context, userID string) (*domain.EmailVerificationToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var latest *domain.EmailVerificationToken
+	for _, token := range m.verifyTokens {
+		if token.UserID == userID && (latest == nil || token.CreatedAt.After(latest.CreatedAt)) {
+			latest = token
+		}
+	}
+	if latest == nil {
+		return nil, ErrEmailVerificationTokenNotFound
+	}
+	tokenCopy := *latest
+	return &tokenCopy, nil
+}
+
+func (m *MockUserStore) MarkEmailVerificationTokenUsed(ctx context.Context, tokenID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.verifyTokens {
+		if token.ID != tokenID {
+			continue
+		}
+		if token.UsedAt != nil {
+			return ErrEmailVerificationTokenUsed
+		}
+		now := time.Now().UTC()
+		token.UsedAt = &now
+		return nil
+	}
+	return ErrEmailVerificationTokenNotFound
+}
@@ -0,0 +1,120 @@
+// user-service/internal/store/export_job_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// copyExportJob возвращает копию выгрузки без архива.
+func copyExportJob(job *domain.ExportJob) *domain.ExportJob {
+	jobCopy := *job
+	jobCopy.Archive = nil
+	return &jobCopy
+}
+
+func (m *MockUserStore) CreateExportJob(ctx context.Context, job *domain.ExportJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Creating export job %s for user %s\n", job.ID, job.UserID)
+	m.exportJobs[job.ID] = copyExportJob(job)
+	return nil
+}
+
+func (m *MockUserStore) GetExportJob(ctx context.Context, jobID string) (*domain.ExportJob, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.exportJobs[jobID]
+	if !ok {
+		return nil, ErrExportJobNotFound
+	}
+	return copyExportJob(job), nil
+}
+
+func (m *MockUserStore) GetActiveExportJob(ctx context.Context, userID string) (*domain.ExportJob, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, job := range m.exportJobs {
+		if job.UserID == userID && job.IsActive() {
+			return copyExportJob(job), nil
+		}
+	}
+	return nil, ErrExportJobNotFound
+}
+
+func (m *MockUserStore) GetExportArchive(ctx context.Context, jobID string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.exportJobs[jobID]
+	if !ok || job.Status != domain.ExportJobCompleted {
+		return nil, ErrExportJobNotFound
+	}
+	return append([]byte(nil), job.Archive...), nil
+}
+
+func (m *MockUserStore) ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (*domain.ExportJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed *domain.ExportJob
+	for _, job := range m.exportJobs {
+		stale := job.Status == domain.ExportJobRunning && job.StartedAt != nil && job.StartedAt.Before(staleBefore)
+		if job.Status != domain.ExportJobPending && !stale {
+			continue
+		}
+		if claimed == nil || job.CreatedAt.Before(claimed.CreatedAt) {
+			claimed = job
+		}
+	}
+	if claimed == nil {
+		return nil, ErrExportJobNotFound
+	}
+	log.Printf("[MOCK USER STORE] Claiming export job %s\n", claimed.ID)
+	startedAt := now
+	claimed.Status = domain.ExportJobRunning
+	claimed.StartedAt = &startedAt
+	return copyExportJob(claimed), nil
+}
+
+func (m *MockUserStore) CompleteExportJob(ctx context.Context, jobID string, archive []byte, completedAt, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.exportJobs[jobID]
+	if !ok {
+		return ErrExportJobNotFound
+	}
+	job.Status = domain.ExportJobCompleted
+	job.Archive = append([]byte(nil), archive...)
+	job.CompletedAt = &completedAt
+	job.ExpiresAt = &expiresAt
+	return nil
+}
+
+func (m *MockUserStore) FailExportJob(ctx context.Context, jobID string, message string, completedAt, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.exportJobs[jobID]
+	if !ok {
+		return ErrExportJobNotFound
+	}
+	job.Status = domain.ExportJobFailed
+	job.Error = message
+	job.CompletedAt = &completedAt
+	job.ExpiresAt = &expiresAt
+	return nil
+}
+
+func (m *MockUserStore) DeleteExpiredExportJobs(ctx context.Context, now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deleted := 0
+	for id, job := range m.exportJobs {
+		if job.ExpiresAt != nil && !job.ExpiresAt.After(now) {
+			delete(m.exportJobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
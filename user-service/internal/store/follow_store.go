@@ -0,0 +1,115 @@
+// user-service/internal/store/follow_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) Follow(ctx context.Context, followerID, followeeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[followeeID]; !ok {
+		return ErrUserNotFound
+	}
+	if m.follows[followerID] == nil {
+		m.follows[followerID] = make(map[string]time.Time)
+	}
+	if _, ok := m.follows[followerID][followeeID]; !ok {
+		log.Printf("[MOCK USER STORE] User %s follows %s\n", followerID, followeeID)
+		m.follows[followerID][followeeID] = time.Now().UTC()
+	}
+	return nil
+}
+
+func (m *MockUserStore) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] User %s unfollows %s\n", followerID, followeeID)
+	delete(m.follows[followerID], followeeID)
+	return nil
+}
+
+func (m *MockUserStore) ListFollowers(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var entries []*domain.FollowEntry
+	for followerID, followees := range m.follows {
+		if followedAt, ok := followees[userID]; ok {
+			if entry := m.followEntryLocked(followerID, followedAt); entry != nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return pageFollowEntries(entries, page, pageSize)
+}
+
+func (m *MockUserStore) ListFollowing(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var entries []*domain.FollowEntry
+	for followeeID, followedAt := range m.follows[userID] {
+		if entry := m.followEntryLocked(followeeID, followedAt); entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return pageFollowEntries(entries, page, pageSize)
+}
+
+func (m *MockUserStore) GetFollowCounts(ctx context.Context, userID string) (*domain.FollowCounts, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := &domain.FollowCounts{}
+	for followerID, followees := range m.follows {
+		if _, ok := followees[userID]; ok && m.isListedLocked(followerID) {
+			counts.Followers++
+		}
+	}
+	for followeeID := range m.follows[userID] {
+		if m.isListedLocked(followeeID) {
+			counts.Following++
+		}
+	}
+	return counts, nil
+}
+
+// isListedLocked сообщает, показывается ли пользователь в списках подписок. Вызывается под m.mu.
+func (m *MockUserStore) isListedLocked(userID string) bool {
+	user, ok := m.users[userID]
+	return ok && user.DeletionScheduledAt == nil && !user.IsSuspended(time.Now().UTC())
+}
+
+// followEntryLocked собирает запись списка подписок или возвращает nil для скрытого пользователя.
+// Вызывается под m.mu.
+func (m *MockUserStore) followEntryLocked(userID string, followedAt time.Time) *domain.FollowEntry {
+	if !m.isListedLocked(userID) {
+		return nil
+	}
+	user := m.users[userID]
+	return &domain.FollowEntry{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL,
+		FollowedAt:  followedAt,
+	}
+}
+
+// pageFollowEntries сортирует записи (новые подписки первыми) и возвращает запрошенную страницу.
+func pageFollowEntries(entries []*domain.FollowEntry, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].FollowedAt.After(entries[j].FollowedAt) })
+	total := len(entries)
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []*domain.FollowEntry{}, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return entries[start:end], total, nil
+}
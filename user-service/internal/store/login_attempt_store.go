@@ -0,0 +1,63 @@
+// user-service/internal/store/login_attempt_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if attempts, ok := m.loginAttempts[key]; ok {
+		attemptsCopy := *attempts
+		return &attemptsCopy, nil
+	}
+	return &domain.LoginAttempts{Key: key}, nil
+}
+
+func (m *MockUserStore) RecordLoginFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+	attempts, ok := m.loginAttempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{Key: key}
+		m.loginAttempts[key] = attempts
+	}
+	if attempts.LastFailureAt != nil && attempts.LastFailureAt.Before(now.Add(-window)) {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = &now
+	log.Printf("[MOCK USER STORE] Login failure recorded: Key='%s', Failures=%d\n", key, attempts.Failures)
+
+	attemptsCopy := *attempts
+	return &attemptsCopy, nil
+}
+
+func (m *MockUserStore) LockLogin(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Locking login for key '%s' until %s\n", key, until.Format(time.RFC3339))
+
+	attempts, ok := m.loginAttempts[key]
+	if !ok {
+		attempts = &domain.LoginAttempts{Key: key}
+		m.loginAttempts[key] = attempts
+	}
+	attempts.Failures = 0
+	attempts.LockedUntil = &until
+	return nil
+}
+
+func (m *MockUserStore) ResetLoginAttempts(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.loginAttempts, key)
+	return nil
+}
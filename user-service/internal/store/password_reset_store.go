@@ -0,0 +1,60 @@
+// user-service/internal/store/password_reset_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Creating password reset token: ID='%s', UserID='%s'\n", token.ID, token.UserID)
+
+	tokenCopy := *token
+	m.resetTokens[token.TokenHash] = &tokenCopy
+	return nil
+}
+
+func (m *MockUserStore) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if token, ok := m.resetTokens[tokenHash]; ok {
+		tokenCopy := *token
+		return &tokenCopy, nil
+	}
+	return nil, ErrPasswordResetTokenNotFound
+}
+
+func (m *MockUserStore) MarkPasswordResetTokenUsed(ctx context.Context, tokenID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.resetTokens {
+		if token.ID != tokenID {
+			continue
+		}
+		if token.UsedAt != nil {
+			return ErrPasswordResetTokenUsed
+		}
+		now := time.Now().UTC()
+		token.UsedAt = &now
+		return nil
+	}
+	return ErrPasswordResetTokenNotFound
+}
+
+func (m *MockUserStore) InvalidateUserPasswordResetTokens(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Invalidating password reset tokens of user %s\n", userID)
+	now := time.Now().UTC()
+	for _, token := range m.resetTokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// user-service/internal/store/postgres_account_deletion_store.go
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// userEventsLockKey - ключ advisory-блокировки, сериализующей запись в user_events.
+// Потребители читают события по возрастанию ID, поэтому транзакции с меньшим ID
+// не должны фиксироваться позже транзакций с большим (иначе событие можно пропустить).
+const userEventsLockKey = 7420513
+
+// ScheduleDeletion назначает удаление аккаунта.
+func (s *PostgresUserStore) ScheduleDeletion(ctx context.Context, userID string, at time.Time) error {
+	query := `UPDATE users SET deletion_scheduled_at = $1, updated_at = $2 WHERE id = $3`
+	return s.execUserUpdate(ctx, "ScheduleDeletion", userID, query, at, time.Now().UTC(), userID)
+}
+
+// CancelDeletion отменяет запрошенное удаление аккаунта.
+func (s *PostgresUserStore) CancelDeletion(ctx context.Context, userID string) error {
+	query := `UPDATE users SET deletion_scheduled_at = NULL, updated_at = $1 WHERE id = $2`
+	return s.execUserUpdate(ctx, "CancelDeletion", userID, query, time.Now().UTC(), userID)
+}
+
+// PurgeScheduledUsers удаляет аккаунты с наступившим сроком удаления и записывает события user.deleted
+// в одной транзакции. Связанные записи (токены, 2FA и т.д.) удаляются каскадно.
+func (s *PostgresUserStore) PurgeScheduledUsers(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, userEventsLockKey); err != nil {
+		return nil, fmt.Errorf("failed to lock user events: %w", err)
+	}
+
+	var userIDs []string
+	err = tx.SelectContext(ctx, &userIDs,
+		`SELECT id FROM users WHERE deletion_scheduled_at <= $1 ORDER BY deletion_scheduled_at LIMIT $2 FOR UPDATE`,
+		now, limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to select users due for deletion", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to select users due for deletion: %w", err)
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	// Счетчики входа хранятся по email и не связаны с users внешним ключом
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM login_attempts WHERE key IN (SELECT 'account:' || LOWER(email) FROM users WHERE id = ANY($1))`,
+		pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("failed to delete login attempts: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ANY($1)`, pq.Array(userIDs)); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete users", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to delete users: %w", err)
+	}
+	for _, userID := range userIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO user_events (type, user_id, occurred_at) VALUES ($1, $2, $3)`,
+			domain.UserEventDeleted, userID, now); err != nil {
+			return nil, fmt.Errorf("failed to record user event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit user purge: %w", err)
+	}
+	s.logger.InfoContext(ctx, "Scheduled accounts purged", slog.Int("count", len(userIDs)))
+	return userIDs, nil
+}
+
+// ListUserEvents возвращает события пользователей после afterID.
+func (s *PostgresUserStore) ListUserEvents(ctx context.Context, afterID int64, limit int) ([]*domain.UserEvent, error) {
+	query := `SELECT id, type, user_id, occurred_at FROM user_events WHERE id > $1 ORDER BY id LIMIT $2`
+	events := []*domain.UserEvent{}
+	if err := s.db.SelectContext(ctx, &events, query, afterID, limit); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list user events", slog.Int64("afterID", afterID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list user events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,76 @@
+// user-service/internal/store/postgres_email_verification_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// CreateEmailVerificationToken сохраняет хеш нового токена подтверждения email.
+func (s *PostgresUserStore) CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error {
+	query := `INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	s.logger.DebugContext(ctx, "Executing CreateEmailVerificationToken query", slog.String("tokenID", token.ID), slog.String("userID", token.UserID))
+	_, err := s.db.ExecContext(ctx, query, token.ID, token.UserID, token.Email, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create email verification token in DB", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+	return nil
+}
+
+// GetEmailVerificationTokenByHash находит токен подтверждения email по его хешу.
+func (s *PostgresUserStore) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	query := `SELECT id, user_id, email, token_hash, expires_at, created_at, used_at
+              FROM email_verification_tokens WHERE token_hash = $1`
+	return s.getEmailVerificationToken(ctx, query, tokenHash)
+}
+
+// GetLatestEmailVerificationToken возвращает последний выданный пользователю токен подтверждения email.
+func (s *PostgresUserStore) GetLatestEmailVerificationToken(ctx context.Context, userID string) (*domain.EmailVerificationToken, error) {
+	query := `SELECT id, user_id, email, token_hash, expires_at, created_at, used_at
+              FROM email_verification_tokens WHERE user_id = $1
+              ORDER BY created_at DESC LIMIT 1`
+	return s.getEmailVerificationToken(ctx, query, userID)
+}
+
+func (s *PostgresUserStore) getEmailVerificationToken(ctx context.Context, query string, arg string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	err := s.db.GetContext(ctx, &token, query, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEmailVerificationTokenNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get email verification token from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkEmailVerificationTokenUsed атомарно помечает токен использованным.
+func (s *PostgresUserStore) MarkEmailVerificationTokenUsed(ctx context.Context, tokenID string) error {
+	query := `UPDATE email_verification_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now().UTC(), tokenID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to mark email verification token as used", slog.String("tokenID", tokenID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to mark email verification token as used: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check email verification token update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrEmailVerificationTokenUsed
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// user-service/internal/store/postgres_export_job_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// exportJobColumns - колонки таблицы export_jobs без архива (он читается только при скачивании).
+const exportJobColumns = `id, user_id, format, status, error, created_at, started_at, completed_at, expires_at`
+
+// CreateExportJob сохраняет новую выгрузку.
+func (s *PostgresUserStore) CreateExportJob(ctx context.Context, job *domain.ExportJob) error {
+	query := `INSERT INTO export_jobs (id, user_id, format, status, error, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := s.db.ExecContext(ctx, query, job.ID, job.UserID, job.Format, job.Status, job.Error, job.CreatedAt); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create export job in DB", slog.String("userID", job.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+	return nil
+}
+
+// getExportJobWhere возвращает одну выгрузку по условию или ErrExportJobNotFound.
+func (s *PostgresUserStore) getExportJobWhere(ctx context.Context, condition string, args ...interface{}) (*domain.ExportJob, error) {
+	query := `SELECT ` + exportJobColumns + ` FROM export_jobs WHERE ` + condition + ` ORDER BY created_at LIMIT 1`
+	var job domain.ExportJob
+	if err := s.db.GetContext(ctx, &job, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExportJobNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get export job from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+	return &job, nil
+}
+
+// GetExportJob возвращает выгрузку по ID.
+func (s *PostgresUserStore) GetExportJob(ctx context.Context, jobID string) (*domain.ExportJob, error) {
+	return s.getExportJobWhere(ctx, `id = $1`, jobID)
+}
+
+// GetActiveExportJob возвращает ожидающую или выполняющуюся выгрузку пользователя.
+func (s *PostgresUserStore) GetActiveExportJob(ctx context.Context, userID string) (*domain.ExportJob, error) {
+	return s.getExportJobWhere(ctx, `user_id = $1 AND status IN ($2, $3)`, userID, domain.ExportJobPending, domain.ExportJobRunning)
+}
+
+// GetExportArchive возвращает архив завершенной выгрузки.
+func (s *PostgresUserStore) GetExportArchive(ctx context.Context, jobID string) ([]byte, error) {
+	query := `SELECT archive FROM export_jobs WHERE id = $1 AND status = $2 AND archive IS NOT NULL`
+	var archive []byte
+	if err := s.db.GetContext(ctx, &archive, query, jobID, domain.ExportJobCompleted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExportJobNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get export archive from DB", slog.String("jobID", jobID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get export archive: %w", err)
+	}
+	return archive, nil
+}
+
+// ClaimExportJob берет следующую выгрузку в работу. SKIP LOCKED позволяет нескольким
+// экземплярам сервиса обрабатывать очередь, не мешая друг другу.
+func (s *PostgresUserStore) ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (*domain.ExportJob, error) {
+	query := `UPDATE export_jobs SET status = $1, started_at = $2
+              WHERE id = (
+                  SELECT id FROM export_jobs
+                  WHERE status = $3 OR (status = $1 AND started_at < $4)
+                  ORDER BY created_at
+                  LIMIT 1
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING ` + exportJobColumns
+	var job domain.ExportJob
+	err := s.db.GetContext(ctx, &job, query, domain.ExportJobRunning, now, domain.ExportJobPending, staleBefore)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExportJobNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to claim export job in DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to claim export job: %w", err)
+	}
+	return &job, nil
+}
+
+// finishExportJob переводит выгрузку в конечное состояние.
+func (s *PostgresUserStore) finishExportJob(ctx context.Context, jobID string, query string, args ...interface{}) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to finish export job in DB", slog.String("jobID", jobID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to finish export job: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check export job update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrExportJobNotFound
+	}
+	return nil
+}
+
+// CompleteExportJob сохраняет готовый архив.
+func (s *PostgresUserStore) CompleteExportJob(ctx context.Context, jobID string, archive []byte, completedAt, expiresAt time.Time) error {
+	query := `UPDATE export_jobs SET status = $1, archive = $2, completed_at = $3, expires_at = $4 WHERE id = $5`
+	return s.finishExportJob(ctx, jobID, query, domain.ExportJobCompleted, archive, completedAt, expiresAt, jobID)
+}
+
+// FailExportJob отмечает выгрузку неудавшейся.
+func (s *PostgresUserStore) FailExportJob(ctx context.Context, jobID string, message string, completedAt, expiresAt time.Time) error {
+	query := `UPDATE export_jobs SET status = $1, error = $2, completed_at = $3, expires_at = $4 WHERE id = $5`
+	return s.finishExportJob(ctx, jobID, query, domain.ExportJobFailed, message, completedAt, expiresAt, jobID)
+}
+
+// DeleteExpiredExportJobs удаляет выгрузки с истекшим сроком хранения вместе с архивами.
+func (s *PostgresUserStore) DeleteExpiredExportJobs(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM export_jobs WHERE expires_at <= $1`, now)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete expired export jobs from DB", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete expired export jobs: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check expired export jobs result: %w", err)
+	}
+	return int(rowsAffected), nil
+}
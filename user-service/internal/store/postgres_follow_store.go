@@ -0,0 +1,93 @@
+// user-service/internal/store/postgres_follow_store.go
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// listedUserCondition - SQL условие для пользователей, которые показываются в списках подписок.
+const listedUserCondition = `u.deletion_scheduled_at IS NULL AND NOT ` + suspendedCondition
+
+// Follow подписывает followerID на followeeID (повторная подписка игнорируется).
+func (s *PostgresUserStore) Follow(ctx context.Context, followerID, followeeID string) error {
+	query := `INSERT INTO follows (follower_id, followee_id, created_at) VALUES ($1, $2, $3)
+              ON CONFLICT (follower_id, followee_id) DO NOTHING`
+	_, err := s.db.ExecContext(ctx, query, followerID, followeeID, time.Now().UTC())
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation: пользователь удален
+			return ErrUserNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to insert follow into DB", slog.String("followerID", followerID), slog.String("followeeID", followeeID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to follow user: %w", err)
+	}
+	return nil
+}
+
+// Unfollow отменяет подписку followerID на followeeID.
+func (s *PostgresUserStore) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	query := `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`
+	if _, err := s.db.ExecContext(ctx, query, followerID, followeeID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete follow from DB", slog.String("followerID", followerID), slog.String("followeeID", followeeID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to unfollow user: %w", err)
+	}
+	return nil
+}
+
+// ListFollowers возвращает подписчиков пользователя.
+func (s *PostgresUserStore) ListFollowers(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	return s.listFollows(ctx, "followee_id", "follower_id", userID, page, pageSize)
+}
+
+// ListFollowing возвращает пользователей, на которых подписан userID.
+func (s *PostgresUserStore) ListFollowing(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	return s.listFollows(ctx, "follower_id", "followee_id", userID, page, pageSize)
+}
+
+// listFollows выбирает страницу follows по колонке keyColumn = userID, присоединяя пользователя из otherColumn.
+// Имена колонок передаются только константами из этого файла.
+func (s *PostgresUserStore) listFollows(ctx context.Context, keyColumn, otherColumn, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error) {
+	from := ` FROM follows f JOIN users u ON u.id = f.` + otherColumn +
+		` WHERE f.` + keyColumn + ` = $1 AND ` + listedUserCondition
+
+	var totalCount int
+	if err := s.db.GetContext(ctx, &totalCount, `SELECT COUNT(*)`+from, userID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count follows in DB", slog.String("userID", userID), slog.String("by", keyColumn), slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to count follows: %w", err)
+	}
+	if totalCount == 0 {
+		return []*domain.FollowEntry{}, 0, nil
+	}
+
+	query := `SELECT u.id AS user_id, u.username, u.display_name, u.avatar_url, f.created_at AS followed_at` + from +
+		` ORDER BY f.created_at DESC, u.id LIMIT $2 OFFSET $3`
+	entries := []*domain.FollowEntry{}
+	if err := s.db.SelectContext(ctx, &entries, query, userID, pageSize, (page-1)*pageSize); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list follows from DB", slog.String("userID", userID), slog.String("by", keyColumn), slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to list follows: %w", err)
+	}
+	return entries, totalCount, nil
+}
+
+// GetFollowCounts возвращает количество подписчиков и подписок пользователя.
+func (s *PostgresUserStore) GetFollowCounts(ctx context.Context, userID string) (*domain.FollowCounts, error) {
+	query := `SELECT
+                  (SELECT COUNT(*) FROM follows f JOIN users u ON u.id = f.follower_id
+                    WHERE f.followee_id = $1 AND ` + listedUserCondition + `) AS followers_count,
+                  (SELECT COUNT(*) FROM follows f JOIN users u ON u.id = f.followee_id
+                    WHERE f.follower_id = $1 AND ` + listedUserCondition + `) AS following_count`
+	var counts domain.FollowCounts
+	if err := s.db.GetContext(ctx, &counts, query, userID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count follows in DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get follow counts: %w", err)
+	}
+	return &counts, nil
+}
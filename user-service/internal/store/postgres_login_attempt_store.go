@@ -0,0 +1,71 @@
+// user-service/internal/store/postgres_login_attempt_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// GetLoginAttempts возвращает счетчик неудачных попыток входа для ключа.
+func (s *PostgresUserStore) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	query := `SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1`
+	var attempts domain.LoginAttempts
+	err := s.db.GetContext(ctx, &attempts, query, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &domain.LoginAttempts{Key: key}, nil
+		}
+		s.logger.ErrorContext(ctx, "Failed to get login attempts from DB", slog.String("key", key), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get login attempts: %w", err)
+	}
+	return &attempts, nil
+}
+
+// RecordLoginFailure атомарно увеличивает счетчик неудач (upsert), сбрасывая его по истечении окна.
+func (s *PostgresUserStore) RecordLoginFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error) {
+	query := `INSERT INTO login_attempts (key, failures, last_failure_at)
+              VALUES ($1, 1, $2)
+              ON CONFLICT (key) DO UPDATE SET
+                  failures = CASE WHEN login_attempts.last_failure_at < $3 THEN 1 ELSE login_attempts.failures + 1 END,
+                  last_failure_at = EXCLUDED.last_failure_at
+              RETURNING key, failures, last_failure_at, locked_until`
+	now := time.Now().UTC()
+	var attempts domain.LoginAttempts
+	err := s.db.GetContext(ctx, &attempts, query, key, now, now.Add(-window))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record login failure in DB", slog.String("key", key), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return &attempts, nil
+}
+
+// LockLogin блокирует вход по ключу до until и обнуляет счетчик.
+func (s *PostgresUserStore) LockLogin(ctx context.Context, key string, until time.Time) error {
+	query := `INSERT INTO login_attempts (key, failures, locked_until)
+              VALUES ($1, 0, $2)
+              ON CONFLICT (key) DO UPDATE SET failures = 0, locked_until = EXCLUDED.locked_until`
+	_, err := s.db.ExecContext(ctx, query, key, until)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to lock login in DB", slog.String("key", key), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	s.logger.WarnContext(ctx, "Login locked", slog.String("key", key), slog.Time("until", until))
+	return nil
+}
+
+// ResetLoginAttempts удаляет счетчик и блокировку для ключа.
+func (s *PostgresUserStore) ResetLoginAttempts(ctx context.Context, key string) error {
+	query := `DELETE FROM login_attempts WHERE key = $1`
+	_, err := s.db.ExecContext(ctx, query, key)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to reset login attempts in DB", slog.String("key", key), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// user-service/internal/store/postgres_password_reset_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// CreatePasswordResetToken сохраняет хеш нового токена сброса пароля.
+func (s *PostgresUserStore) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+              VALUES ($1, $2, $3, $4, $5)`
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	s.logger.DebugContext(ctx, "Executing CreatePasswordResetToken query", slog.String("tokenID", token.ID), slog.String("userID", token.UserID))
+	_, err := s.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create password reset token in DB", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+	return nil
+}
+
+// GetPasswordResetTokenByHash находит токен сброса пароля по его хешу.
+func (s *PostgresUserStore) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, created_at, used_at
+              FROM password_reset_tokens WHERE token_hash = $1`
+	var token domain.PasswordResetToken
+	err := s.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPasswordResetTokenNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get password reset token from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkPasswordResetTokenUsed атомарно помечает токен использованным.
+// Условие used_at IS NULL гарантирует, что токен сработает только один раз.
+func (s *PostgresUserStore) MarkPasswordResetTokenUsed(ctx context.Context, tokenID string) error {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now().UTC(), tokenID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to mark password reset token as used", slog.String("tokenID", tokenID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to mark password reset token as used: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check password reset token update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrPasswordResetTokenUsed
+	}
+	return nil
+}
+
+// InvalidateUserPasswordResetTokens гасит все оставшиеся токены сброса пароля пользователя.
+func (s *PostgresUserStore) InvalidateUserPasswordResetTokens(ctx context.Context, userID string) error {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`
+	_, err := s.db.ExecContext(ctx, query, time.Now().UTC(), userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to invalidate password reset tokens", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// user-service/internal/store/postgres_refresh_token_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// CreateRefreshToken сохраняет хеш нового refresh токена.
+func (s *PostgresUserStore) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	s.logger.DebugContext(ctx, "Executing CreateRefreshToken query", slog.String("tokenID", token.ID), slog.String("userID", token.UserID), slog.String("familyID", token.FamilyID))
+	_, err := s.db.ExecContext(ctx, query, token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create refresh token in DB", slog.String("userID", token.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash находит refresh токен по его хешу.
+func (s *PostgresUserStore) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at
+              FROM refresh_tokens WHERE token_hash = $1`
+	var token domain.RefreshToken
+	err := s.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRefreshTokenNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get refresh token from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed атомарно помечает токен использованным.
+// Условие used_at IS NULL гарантирует, что из двух параллельных ротаций успешна только одна.
+func (s *PostgresUserStore) MarkRefreshTokenUsed(ctx context.Context, tokenID string) error {
+	query := `UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now().UTC(), tokenID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to mark refresh token as used", slog.String("tokenID", tokenID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check refresh token update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		s.logger.WarnContext(ctx, "Refresh token was already used", slog.String("tokenID", tokenID))
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily отзывает все токены, полученные из одного логина.
+func (s *PostgresUserStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`
+	_, err := s.db.ExecContext(ctx, query, time.Now().UTC(), familyID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to revoke refresh token family", slog.String("familyID", familyID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	s.logger.InfoContext(ctx, "Refresh token family revoked", slog.String("familyID", familyID))
+	return nil
+}
+
+// RevokeUserRefreshTokens отзывает все refresh токены пользователя (все сессии).
+func (s *PostgresUserStore) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+	_, err := s.db.ExecContext(ctx, query, time.Now().UTC(), userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to revoke user refresh tokens", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+	s.logger.InfoContext(ctx, "All refresh tokens of user revoked", slog.String("userID", userID))
+	return nil
+}
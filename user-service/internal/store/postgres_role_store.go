@@ -0,0 +1,80 @@
+// user-service/internal/store/postgres_role_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// roleColumns - колонки таблицы roles в порядке полей domain.Role.
+const roleColumns = `name, description, permissions, created_at, updated_at`
+
+// ListRoles возвращает все пользовательские роли, упорядоченные по имени.
+func (s *PostgresUserStore) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles ORDER BY name`
+	roles := []*domain.Role{}
+	if err := s.db.SelectContext(ctx, &roles, query); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list roles from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+// GetRole возвращает пользовательскую роль по имени.
+func (s *PostgresUserStore) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE name = $1`
+	var role domain.Role
+	err := s.db.GetContext(ctx, &role, query, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get role from DB", slog.String("role", name), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return &role, nil
+}
+
+// SaveRole создает роль или обновляет описание и разрешения существующей (upsert).
+func (s *PostgresUserStore) SaveRole(ctx context.Context, role *domain.Role) error {
+	query := `INSERT INTO roles (name, description, permissions, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $4)
+              ON CONFLICT (name) DO UPDATE SET
+                  description = EXCLUDED.description,
+                  permissions = EXCLUDED.permissions,
+                  updated_at = EXCLUDED.updated_at
+              RETURNING created_at, updated_at`
+	now := time.Now().UTC()
+	err := s.db.QueryRowxContext(ctx, query, role.Name, role.Description, role.Permissions, now).Scan(&role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save role in DB", slog.String("role", role.Name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to save role: %w", err)
+	}
+	s.logger.InfoContext(ctx, "Role saved", slog.String("role", role.Name))
+	return nil
+}
+
+// DeleteRole удаляет пользовательскую роль.
+func (s *PostgresUserStore) DeleteRole(ctx context.Context, name string) error {
+	query := `DELETE FROM roles WHERE name = $1`
+	result, err := s.db.ExecContext(ctx, query, name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete role from DB", slog.String("role", name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows after role delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrRoleNotFound
+	}
+	s.logger.InfoContext(ctx, "Role deleted", slog.String("role", name))
+	return nil
+}
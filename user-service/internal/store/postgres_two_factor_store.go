@@ -0,0 +1,181 @@
+// user-service/internal/store/postgres_two_factor_store.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// GetTOTP возвращает секрет TOTP пользователя.
+func (s *PostgresUserStore) GetTOTP(ctx context.Context, userID string) (*domain.TOTPSecret, error) {
+	query := `SELECT user_id, secret, enabled_at, last_used_step, created_at FROM user_totp WHERE user_id = $1`
+	var secret domain.TOTPSecret
+	err := s.db.GetContext(ctx, &secret, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTOTPNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get TOTP secret from DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get TOTP secret: %w", err)
+	}
+	return &secret, nil
+}
+
+// SavePendingTOTP сохраняет неподтвержденный секрет, не затрагивая уже включенную 2FA.
+func (s *PostgresUserStore) SavePendingTOTP(ctx context.Context, secret *domain.TOTPSecret) error {
+	query := `INSERT INTO user_totp (user_id, secret, last_used_step, created_at)
+              VALUES ($1, $2, 0, $3)
+              ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = EXCLUDED.created_at
+              WHERE user_totp.enabled_at IS NULL`
+	if secret.CreatedAt.IsZero() {
+		secret.CreatedAt = time.Now().UTC()
+	}
+	result, err := s.db.ExecContext(ctx, query, secret.UserID, secret.Secret, secret.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save pending TOTP secret", slog.String("userID", secret.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to save TOTP secret: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check TOTP secret save result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+// EnableTOTP включает 2FA и заменяет коды восстановления в одной транзакции.
+func (s *PostgresUserStore) EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx,
+		`UPDATE user_totp SET enabled_at = $1, last_used_step = $2 WHERE user_id = $3 AND enabled_at IS NULL`,
+		now, step, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to enable TOTP", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to enable TOTP: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check TOTP enable result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete old recovery codes: %w", err)
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO user_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)`,
+			uuid.NewString(), userID, hash, now); err != nil {
+			return fmt.Errorf("failed to store recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit TOTP enable: %w", err)
+	}
+	s.logger.InfoContext(ctx, "TOTP enabled for user", slog.String("userID", userID))
+	return nil
+}
+
+// MarkTOTPStepUsed запоминает принятый шаг; условие last_used_step < $1 отсекает повтор кода.
+func (s *PostgresUserStore) MarkTOTPStepUsed(ctx context.Context, userID string, step int64) error {
+	query := `UPDATE user_totp SET last_used_step = $1 WHERE user_id = $2 AND last_used_step < $1`
+	result, err := s.db.ExecContext(ctx, query, step, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to mark TOTP step as used", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to mark TOTP step as used: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check TOTP step update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTOTPCodeReused
+	}
+	return nil
+}
+
+// UseRecoveryCode гасит неиспользованный код восстановления.
+func (s *PostgresUserStore) UseRecoveryCode(ctx context.Context, userID string, codeHash string) error {
+	query := `UPDATE user_recovery_codes SET used_at = $1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now().UTC(), userID, codeHash)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to use recovery code", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check recovery code update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	s.logger.InfoContext(ctx, "Recovery code used", slog.String("userID", userID))
+	return nil
+}
+
+// CreateLoginChallenge сохраняет хеш challenge токена второго шага входа.
+func (s *PostgresUserStore) CreateLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error {
+	query := `INSERT INTO login_challenges (id, user_id, token_hash, expires_at, created_at)
+              VALUES ($1, $2, $3, $4, $5)`
+	if challenge.CreatedAt.IsZero() {
+		challenge.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx, query, challenge.ID, challenge.UserID, challenge.TokenHash, challenge.ExpiresAt, challenge.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create login challenge in DB", slog.String("userID", challenge.UserID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create login challenge: %w", err)
+	}
+	return nil
+}
+
+// GetLoginChallengeByHash находит challenge токен по его хешу.
+func (s *PostgresUserStore) GetLoginChallengeByHash(ctx context.Context, tokenHash string) (*domain.LoginChallenge, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, created_at, used_at FROM login_challenges WHERE token_hash = $1`
+	var challenge domain.LoginChallenge
+	err := s.db.GetContext(ctx, &challenge, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLoginChallengeNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get login challenge from DB", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get login challenge: %w", err)
+	}
+	return &challenge, nil
+}
+
+// MarkLoginChallengeUsed атомарно помечает challenge токен использованным.
+func (s *PostgresUserStore) MarkLoginChallengeUsed(ctx context.Context, challengeID string) error {
+	query := `UPDATE login_challenges SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now().UTC(), challengeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to mark login challenge as used", slog.String("challengeID", challengeID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to mark login challenge as used: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check login challenge update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrLoginChallengeUsed
+	}
+	return nil
+}
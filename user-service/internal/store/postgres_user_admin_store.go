@@ -0,0 +1,98 @@
+// user-service/internal/store/postgres_user_admin_store.go
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// suspendedCondition - SQL условие действующей блокировки аккаунта.
+const suspendedCondition = `(suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW()))`
+
+// List возвращает страницу пользователей с фильтрацией по роли, статусу и подстроке username/email.
+func (s *PostgresUserStore) List(ctx context.Context, params UserListParams) ([]*domain.User, int, error) {
+	var users []*domain.User
+	var totalCount int
+
+	var args []interface{}
+	var conditions []string
+	argId := 1
+
+	if params.Role != "" {
+		conditions = append(conditions, fmt.Sprintf("role = $%d", argId))
+		args = append(args, params.Role)
+		argId++
+	}
+	switch params.Status {
+	case UserStatusSuspended:
+		conditions = append(conditions, suspendedCondition)
+	case UserStatusActive:
+		conditions = append(conditions, "NOT "+suspendedCondition)
+	}
+	if params.SearchQuery != "" {
+		conditions = append(conditions, fmt.Sprintf("(username ILIKE $%d OR email ILIKE $%d)", argId, argId))
+		args = append(args, "%"+params.SearchQuery+"%")
+		argId++
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	countQuery := `SELECT COUNT(*) FROM users` + where
+	s.logger.DebugContext(ctx, "Executing List users count query", slog.String("query", countQuery), slog.Any("args", args))
+	if err := s.db.GetContext(ctx, &totalCount, countQuery, args...); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count users in DB", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	if totalCount == 0 {
+		return []*domain.User{}, 0, nil
+	}
+
+	selectQuery := `SELECT ` + userColumns + ` FROM users` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d", argId, argId+1)
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+
+	s.logger.DebugContext(ctx, "Executing List users select query", slog.String("query", selectQuery), slog.Any("args", args))
+	if err := s.db.SelectContext(ctx, &users, selectQuery, args...); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list users from DB", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, totalCount, nil
+}
+
+// Suspend блокирует аккаунт пользователя.
+func (s *PostgresUserStore) Suspend(ctx context.Context, userID string, reason string, until *time.Time) error {
+	query := `UPDATE users SET suspended_at = $1, suspended_until = $2, suspension_reason = $3, updated_at = $1 WHERE id = $4`
+	return s.execUserUpdate(ctx, "Suspend", userID, query, time.Now().UTC(), until, reason, userID)
+}
+
+// Unsuspend снимает блокировку аккаунта.
+func (s *PostgresUserStore) Unsuspend(ctx context.Context, userID string) error {
+	query := `UPDATE users SET suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, updated_at = $1 WHERE id = $2`
+	return s.execUserUpdate(ctx, "Unsuspend", userID, query, time.Now().UTC(), userID)
+}
+
+// execUserUpdate выполняет UPDATE одной строки users и возвращает ErrUserNotFound, если строка не найдена.
+func (s *PostgresUserStore) execUserUpdate(ctx context.Context, op string, userID string, query string, args ...interface{}) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update user in DB", slog.String("op", op), slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to %s user: %w", strings.ToLower(op), err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check %s result: %w", strings.ToLower(op), err)
+	}
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	s.logger.InfoContext(ctx, "User updated in DB", slog.String("op", op), slog.String("userID", userID))
+	return nil
+}
@@ -11,11 +11,17 @@ import (
 
 	"user-service/internal/domain"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq" // <--- ДОБАВЬТЕ ЭТОТ ИМПОРТ для ошибок PostgreSQL
 	// _ "github.com/lib/pq" // Если вы уже импортировали его с _, оставьте так
 )
 
+// userColumns - список колонок users для SELECT, соответствующий domain.User.
+const userColumns = `id, username, email, password_hash, role, verified_at,
+       display_name, bio, avatar_url, location, favorite_genres,
+       suspended_at, suspended_until, suspension_reason, deletion_scheduled_at, created_at, updated_at`
+
 // PostgresUserStore реализует UserStore для PostgreSQL.
 type PostgresUserStore struct {
 	db     *sqlx.DB
@@ -85,8 +91,7 @@ func (s *PostgresUserStore) Create(ctx context.Context, user *domain.User) error
 
 // GetByID (остается без изменений)
 func (s *PostgresUserStore) GetByID(ctx context.Context, userID string) (*domain.User, error) {
-	query := `SELECT id, username, email, password_hash, role, created_at, updated_at 
-              FROM users WHERE id = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
 	var user domain.User
 	s.logger.DebugContext(ctx, "Executing GetByID query", slog.String("userID", userID))
 	err := s.db.GetContext(ctx, &user, query, userID)
@@ -102,10 +107,46 @@ func (s *PostgresUserStore) GetByID(ctx context.Context, userID string) (*domain
 	return &user, nil
 }
 
+// GetByIDs находит пользователей по списку ID одним запросом.
+// Строки, не являющиеся UUID, пропускаются: такого пользователя быть не может, а в запросе они вызвали бы ошибку.
+func (s *PostgresUserStore) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	validIDs := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, err := uuid.Parse(userID); err == nil {
+			validIDs = append(validIDs, userID)
+		}
+	}
+	users := []*domain.User{}
+	if len(validIDs) == 0 {
+		return users, nil
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ANY($1::uuid[])`
+	if err := s.db.SelectContext(ctx, &users, query, pq.Array(validIDs)); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get users by IDs from DB", slog.Int("count", len(validIDs)), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+	return users, nil
+}
+
+// GetByUsername находит пользователя по имени (для публичного профиля).
+func (s *PostgresUserStore) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1`
+	var user domain.User
+	err := s.db.GetContext(ctx, &user, query, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		s.logger.ErrorContext(ctx, "Failed to get user by username from DB", slog.String("username", username), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+	return &user, nil
+}
+
 // GetByEmail (остается без изменений)
 func (s *PostgresUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `SELECT id, username, email, password_hash, role, created_at, updated_at 
-              FROM users WHERE email = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 	var user domain.User
 	s.logger.DebugContext(ctx, "Executing GetByEmail query", slog.String("email", email))
 	err := s.db.GetContext(ctx, &user, query, email)
@@ -123,11 +164,15 @@ func (s *PostgresUserStore) GetByEmail(ctx context.Context, email string) (*doma
 
 // Update (остается без изменений, но также может потребовать обработки ошибок уникальности)
 func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error {
-	query := `UPDATE users SET username = $1, email = $2, password_hash = $3, role = $4, updated_at = $5
-              WHERE id = $6`
+	// verified_at сбрасывается здесь же, если меняется email: справа в SET стоит старое значение строки
+	query := `UPDATE users SET username = $1, email = $2, password_hash = $3, role = $4, updated_at = $5,
+                  display_name = $6, bio = $7, avatar_url = $8, location = $9, favorite_genres = COALESCE($10::text[], '{}'),
+                  verified_at = CASE WHEN email = $2 THEN verified_at END
+              WHERE id = $11`
 	user.UpdatedAt = time.Now().UTC()
 	s.logger.DebugContext(ctx, "Executing Update user query", slog.String("userID", user.ID))
-	result, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.PasswordHash, user.Role, user.UpdatedAt, user.ID)
+	result, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.PasswordHash, user.Role, user.UpdatedAt,
+		user.DisplayName, user.Bio, user.AvatarURL, user.Location, user.FavoriteGenres, user.ID)
 	if err != nil {
 		// TODO: Добавить обработку pq.Error для unique_violation (код 23505) и здесь, если нужно
 		var pqErr *pq.Error
@@ -150,3 +195,22 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 	s.logger.InfoContext(ctx, "User updated successfully in DB", slog.String("userID", user.ID))
 	return nil
 }
+
+// SetEmailVerifiedAt отмечает email пользователя подтвержденным (или снимает отметку при nil).
+func (s *PostgresUserStore) SetEmailVerifiedAt(ctx context.Context, userID string, verifiedAt *time.Time) error {
+	query := `UPDATE users SET verified_at = $1, updated_at = $2 WHERE id = $3`
+	result, err := s.db.ExecContext(ctx, query, verifiedAt, time.Now().UTC(), userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to set email verified_at in DB", slog.String("userID", userID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to set email verification state: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check email verification update result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	s.logger.InfoContext(ctx, "User email verification state updated", slog.String("userID", userID), slog.Bool("verified", verifiedAt != nil))
+	return nil
+}
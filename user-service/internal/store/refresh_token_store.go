@@ -0,0 +1,73 @@
+// user-service/internal/store/refresh_token_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Creating refresh token: ID='%s', UserID='%s', FamilyID='%s'\n", token.ID, token.UserID, token.FamilyID)
+
+	tokenCopy := *token
+	m.refreshTokens[token.TokenHash] = &tokenCopy
+	return nil
+}
+
+func (m *MockUserStore) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if token, ok := m.refreshTokens[tokenHash]; ok {
+		tokenCopy := *token
+		return &tokenCopy, nil
+	}
+	return nil, ErrRefreshTokenNotFound
+}
+
+func (m *MockUserStore) MarkRefreshTokenUsed(ctx context.Context, tokenID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.refreshTokens {
+		if token.ID != tokenID {
+			continue
+		}
+		if token.UsedAt != nil {
+			return ErrRefreshTokenReused
+		}
+		now := time.Now().UTC()
+		token.UsedAt = &now
+		return nil
+	}
+	return ErrRefreshTokenNotFound
+}
+
+func (m *MockUserStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Revoking refresh token family %s\n", familyID)
+	now := time.Now().UTC()
+	for _, token := range m.refreshTokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockUserStore) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Revoking all refresh tokens of user %s\n", userID)
+	now := time.Now().UTC()
+	for _, token := range m.refreshTokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
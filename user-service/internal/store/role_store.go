@@ -0,0 +1,61 @@
+// user-service/internal/store/role_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	roles := make([]*domain.Role, 0, len(m.roles))
+	for _, role := range m.roles {
+		roleCopy := *role
+		roles = append(roles, &roleCopy)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+func (m *MockUserStore) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if role, ok := m.roles[name]; ok {
+		roleCopy := *role
+		return &roleCopy, nil
+	}
+	return nil, ErrRoleNotFound
+}
+
+func (m *MockUserStore) SaveRole(ctx context.Context, role *domain.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Saving role '%s' with permissions %v\n", role.Name, role.Permissions)
+
+	now := time.Now().UTC()
+	if existing, ok := m.roles[role.Name]; ok {
+		role.CreatedAt = existing.CreatedAt
+	} else {
+		role.CreatedAt = &now
+	}
+	role.UpdatedAt = &now
+	roleCopy := *role
+	m.roles[role.Name] = &roleCopy
+	return nil
+}
+
+func (m *MockUserStore) DeleteRole(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[name]; !ok {
+		return ErrRoleNotFound
+	}
+	log.Printf("[MOCK USER STORE] Deleting role '%s'\n", name)
+	delete(m.roles, name)
+	return nil
+}
@@ -0,0 +1,117 @@
+// user-service/internal/store/two_factor_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) GetTOTP(ctx context.Context, userID string) (*domain.TOTPSecret, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if secret, ok := m.totpSecrets[userID]; ok {
+		secretCopy := *secret
+		return &secretCopy, nil
+	}
+	return nil, ErrTOTPNotFound
+}
+
+func (m *MockUserStore) SavePendingTOTP(ctx context.Context, secret *domain.TOTPSecret) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.totpSecrets[secret.UserID]; ok && existing.EnabledAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+	log.Printf("[MOCK USER STORE] Saving pending TOTP secret for user %s\n", secret.UserID)
+	secretCopy := *secret
+	secretCopy.EnabledAt = nil
+	m.totpSecrets[secret.UserID] = &secretCopy
+	return nil
+}
+
+func (m *MockUserStore) EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.totpSecrets[userID]
+	if !ok {
+		return ErrTOTPNotFound
+	}
+	if secret.EnabledAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+	log.Printf("[MOCK USER STORE] Enabling TOTP for user %s\n", userID)
+	now := time.Now().UTC()
+	secret.EnabledAt = &now
+	secret.LastUsedStep = step
+
+	codes := make(map[string]bool, len(recoveryCodeHashes))
+	for _, hash := range recoveryCodeHashes {
+		codes[hash] = false
+	}
+	m.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (m *MockUserStore) MarkTOTPStepUsed(ctx context.Context, userID string, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.totpSecrets[userID]
+	if !ok {
+		return ErrTOTPNotFound
+	}
+	if step <= secret.LastUsedStep {
+		return ErrTOTPCodeReused
+	}
+	secret.LastUsedStep = step
+	return nil
+}
+
+func (m *MockUserStore) UseRecoveryCode(ctx context.Context, userID string, codeHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	used, ok := m.recoveryCodes[userID][codeHash]
+	if !ok || used {
+		return ErrRecoveryCodeNotFound
+	}
+	log.Printf("[MOCK USER STORE] Recovery code used by user %s\n", userID)
+	m.recoveryCodes[userID][codeHash] = true
+	return nil
+}
+
+func (m *MockUserStore) CreateLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	challengeCopy := *challenge
+	m.challenges[challenge.TokenHash] = &challengeCopy
+	return nil
+}
+
+func (m *MockUserStore) GetLoginChallengeByHash(ctx context.Context, tokenHash string) (*domain.LoginChallenge, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if challenge, ok := m.challenges[tokenHash]; ok {
+		challengeCopy := *challenge
+		return &challengeCopy, nil
+	}
+	return nil, ErrLoginChallengeNotFound
+}
+
+func (m *MockUserStore) MarkLoginChallengeUsed(ctx context.Context, challengeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, challenge := range m.challenges {
+		if challenge.ID != challengeID {
+			continue
+		}
+		if challenge.UsedAt != nil {
+			return ErrLoginChallengeUsed
+		}
+		now := time.Now().UTC()
+		challenge.UsedAt = &now
+		return nil
+	}
+	return ErrLoginChallengeNotFound
+}
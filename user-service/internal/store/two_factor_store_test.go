@@ -0,0 +1,43 @@
+// user-service/internal/store/two_factor_store_test.go
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"user-service/internal/domain"
+)
+
+// Код принимается с допуском ±1 шаг, поэтому один и тот же шаг (или более ранний код из окна)
+// не должен проходить повторно после успешного входа.
+func TestMarkTOTPStepUsedRejectsReplay(t *testing.T) {
+	ctx := context.Background()
+	s := NewMockUserStore()
+	if err := s.SavePendingTOTP(ctx, &domain.TOTPSecret{UserID: "u1", Secret: "SECRET"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EnableTOTP(ctx, "u1", 100, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		step    int64
+		wantErr error
+	}{
+		{step: 100, wantErr: ErrTOTPCodeReused}, // Код, которым включали 2FA
+		{step: 99, wantErr: ErrTOTPCodeReused},  // Предыдущий шаг из окна допуска
+		{step: 101, wantErr: nil},
+		{step: 101, wantErr: ErrTOTPCodeReused},
+		{step: 100, wantErr: ErrTOTPCodeReused},
+		{step: 102, wantErr: nil},
+	}
+	for i, tt := range steps {
+		if err := s.MarkTOTPStepUsed(ctx, "u1", tt.step); !errors.Is(err, tt.wantErr) {
+			t.Errorf("#%d MarkTOTPStepUsed(step=%d) = %v, want %v", i, tt.step, err, tt.wantErr)
+		}
+	}
+	if err := s.MarkTOTPStepUsed(ctx, "unknown", 1); !errors.Is(err, ErrTOTPNotFound) {
+		t.Errorf("MarkTOTPStepUsed(unknown user) = %v, want %v", err, ErrTOTPNotFound)
+	}
+}
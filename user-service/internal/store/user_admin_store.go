@@ -0,0 +1,85 @@
+// user-service/internal/store/user_admin_store.go
+package store
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func (m *MockUserStore) List(ctx context.Context, params UserListParams) ([]*domain.User, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK USER STORE] Listing users with params: %+v\n", params)
+
+	now := time.Now().UTC()
+	search := strings.ToLower(params.SearchQuery)
+	var filtered []*domain.User
+	for _, user := range m.users {
+		if params.Role != "" && user.Role != params.Role {
+			continue
+		}
+		if params.Status == UserStatusSuspended && !user.IsSuspended(now) {
+			continue
+		}
+		if params.Status == UserStatusActive && user.IsSuspended(now) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(user.Username), search) && !strings.Contains(strings.ToLower(user.Email), search) {
+			continue
+		}
+		userCopy := *user
+		filtered = append(filtered, &userCopy)
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].CreatedAt.After(filtered[j].CreatedAt) })
+
+	total := len(filtered)
+	start := (params.Page - 1) * params.PageSize
+	if start >= total {
+		return []*domain.User{}, total, nil
+	}
+	end := start + params.PageSize
+	if end > total {
+		end = total
+	}
+	return filtered[start:end], total, nil
+}
+
+func (m *MockUserStore) Suspend(ctx context.Context, userID string, reason string, until *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	log.Printf("[MOCK USER STORE] Suspending user %s\n", userID)
+	now := time.Now().UTC()
+	user.SuspendedAt = &now
+	user.SuspendedUntil = nil
+	if until != nil {
+		u := *until
+		user.SuspendedUntil = &u
+	}
+	user.SuspensionReason = &reason
+	user.UpdatedAt = now
+	return nil
+}
+
+func (m *MockUserStore) Unsuspend(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	log.Printf("[MOCK USER STORE] Unsuspending user %s\n", userID)
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspensionReason = nil
+	user.UpdatedAt = time.Now().UTC()
+	return nil
+}
@@ -9,44 +9,251 @@ import (
 	"time"
 
 	"user-service/internal/domain"
+
+	"github.com/lib/pq"
 )
 
 // Кастомные ошибки хранилища
 var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user with this email or username already exists")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token has already been used")
+
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenUsed     = errors.New("password reset token has already been used")
+
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrEmailVerificationTokenUsed     = errors.New("email verification token has already been used")
+
+	ErrTOTPNotFound           = errors.New("TOTP is not set up for this user")
+	ErrTOTPAlreadyEnabled     = errors.New("TOTP is already enabled for this user")
+	ErrTOTPCodeReused         = errors.New("TOTP code has already been used")
+	ErrRecoveryCodeNotFound   = errors.New("recovery code not found or already used")
+	ErrLoginChallengeNotFound = errors.New("login challenge not found")
+	ErrLoginChallengeUsed     = errors.New("login challenge has already been used")
+
+	ErrRoleNotFound = errors.New("role not found")
+
+	ErrExportJobNotFound = errors.New("export job not found")
 )
 
+// Значения фильтра UserListParams.Status
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+)
+
+// UserListParams содержит параметры для постраничного списка пользователей (админка).
+type UserListParams struct {
+	Page        int
+	PageSize    int
+	SearchQuery string // Подстрока username или email
+	Role        string
+	Status      string // UserStatusActive, UserStatusSuspended или пусто (все)
+}
+
 // UserStore определяет интерфейс для операций с данными пользователей.
 type UserStore interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
+	// GetByIDs возвращает найденных пользователей из userIDs (порядок не гарантируется, отсутствующие пропускаются).
+	GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	// GetByUsername возвращает пользователя по имени или ErrUserNotFound.
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	// Update сохраняет изменения пользователя. Если email меняется, отметка о его подтверждении
+	// снимается в том же обновлении. Занятые username или email - ErrUserAlreadyExists.
 	Update(ctx context.Context, user *domain.User) error
+	// SetEmailVerifiedAt отмечает email пользователя подтвержденным (nil - снимает отметку).
+	SetEmailVerifiedAt(ctx context.Context, userID string, verifiedAt *time.Time) error
+	// List возвращает страницу пользователей и общее количество подходящих под фильтр.
+	List(ctx context.Context, params UserListParams) ([]*domain.User, int, error)
+	// Suspend блокирует аккаунт до until (nil - бессрочно); Unsuspend снимает блокировку.
+	Suspend(ctx context.Context, userID string, reason string, until *time.Time) error
+	Unsuspend(ctx context.Context, userID string) error
+
+	RefreshTokenStore
+	PasswordResetTokenStore
+	EmailVerificationTokenStore
+	LoginAttemptStore
+	TwoFactorStore
+	RoleStore
+	AccountDeletionStore
+	ExportJobStore
+	FollowStore
+}
+
+// RefreshTokenStore определяет операции с серверными refresh токенами.
+type RefreshTokenStore interface {
+	CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// MarkRefreshTokenUsed атомарно помечает токен использованным (ротация).
+	// Если токен уже был использован ранее, возвращает ErrRefreshTokenReused.
+	MarkRefreshTokenUsed(ctx context.Context, tokenID string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+}
+
+// PasswordResetTokenStore определяет операции с одноразовыми токенами сброса пароля.
+type PasswordResetTokenStore interface {
+	CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkPasswordResetTokenUsed атомарно помечает токен использованным.
+	// Если токен уже был использован, возвращает ErrPasswordResetTokenUsed.
+	MarkPasswordResetTokenUsed(ctx context.Context, tokenID string) error
+	// InvalidateUserPasswordResetTokens помечает использованными все неиспользованные токены пользователя.
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID string) error
+}
+
+// EmailVerificationTokenStore определяет операции с токенами подтверждения email.
+type EmailVerificationTokenStore interface {
+	CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error)
+	// GetLatestEmailVerificationToken возвращает последний выданный пользователю токен
+	// (используется для ограничения частоты повторной отправки письма).
+	GetLatestEmailVerificationToken(ctx context.Context, userID string) (*domain.EmailVerificationToken, error)
+	// MarkEmailVerificationTokenUsed атомарно помечает токен использованным.
+	// Если токен уже был использован, возвращает ErrEmailVerificationTokenUsed.
+	MarkEmailVerificationTokenUsed(ctx context.Context, tokenID string) error
+}
+
+// LoginAttemptStore определяет операции со счетчиками неудачных попыток входа.
+type LoginAttemptStore interface {
+	// GetLoginAttempts возвращает состояние ключа; для неизвестного ключа - пустую запись без ошибки.
+	GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error)
+	// RecordLoginFailure атомарно увеличивает счетчик неудач и возвращает новое состояние.
+	// Если последняя неудача была раньше now-window, счетчик начинается заново.
+	RecordLoginFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error)
+	// LockLogin блокирует вход по ключу до until и обнуляет счетчик.
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	// ResetLoginAttempts снимает блокировку и обнуляет счетчик (успешный вход, разблокировка админом).
+	ResetLoginAttempts(ctx context.Context, key string) error
+}
+
+// TwoFactorStore определяет операции с TOTP, кодами восстановления и challenge токенами входа.
+type TwoFactorStore interface {
+	// GetTOTP возвращает секрет TOTP пользователя или ErrTOTPNotFound.
+	GetTOTP(ctx context.Context, userID string) (*domain.TOTPSecret, error)
+	// SavePendingTOTP сохраняет (или заменяет) неподтвержденный секрет.
+	// Если 2FA уже включена, возвращает ErrTOTPAlreadyEnabled.
+	SavePendingTOTP(ctx context.Context, secret *domain.TOTPSecret) error
+	// EnableTOTP включает 2FA, запоминает использованный шаг и заменяет коды восстановления.
+	EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	// MarkTOTPStepUsed атомарно запоминает принятый шаг; для уже использованного шага - ErrTOTPCodeReused.
+	MarkTOTPStepUsed(ctx context.Context, userID string, step int64) error
+	// UseRecoveryCode атомарно гасит код восстановления или возвращает ErrRecoveryCodeNotFound.
+	UseRecoveryCode(ctx context.Context, userID string, codeHash string) error
+
+	CreateLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error
+	GetLoginChallengeByHash(ctx context.Context, tokenHash string) (*domain.LoginChallenge, error)
+	// MarkLoginChallengeUsed атомарно помечает challenge использованным (ErrLoginChallengeUsed при повторе).
+	MarkLoginChallengeUsed(ctx context.Context, challengeID string) error
+}
+
+// AccountDeletionStore определяет операции отложенного удаления аккаунтов и журнал событий
+// пользователей (outbox), из которого другие сервисы забирают удаления по gRPC.
+type AccountDeletionStore interface {
+	// ScheduleDeletion назначает удаление аккаунта на время at; CancelDeletion отменяет его.
+	ScheduleDeletion(ctx context.Context, userID string, at time.Time) error
+	CancelDeletion(ctx context.Context, userID string) error
+	// PurgeScheduledUsers удаляет до limit аккаунтов, срок удаления которых наступил к now,
+	// вместе со всеми их данными и в той же транзакции записывает событие user.deleted.
+	// Возвращает ID удаленных пользователей.
+	PurgeScheduledUsers(ctx context.Context, now time.Time, limit int) ([]string, error)
+	// ListUserEvents возвращает до limit событий с ID больше afterID в порядке возрастания ID.
+	ListUserEvents(ctx context.Context, afterID int64, limit int) ([]*domain.UserEvent, error)
+}
+
+// ExportJobStore определяет операции с асинхронными выгрузками персональных данных.
+type ExportJobStore interface {
+	CreateExportJob(ctx context.Context, job *domain.ExportJob) error
+	// GetExportJob возвращает выгрузку без архива или ErrExportJobNotFound.
+	GetExportJob(ctx context.Context, jobID string) (*domain.ExportJob, error)
+	// GetActiveExportJob возвращает незавершенную выгрузку пользователя или ErrExportJobNotFound.
+	GetActiveExportJob(ctx context.Context, userID string) (*domain.ExportJob, error)
+	// GetExportArchive возвращает готовый архив выгрузки или ErrExportJobNotFound.
+	GetExportArchive(ctx context.Context, jobID string) ([]byte, error)
+	// ClaimExportJob атомарно переводит самую старую ожидающую выгрузку в running и возвращает ее.
+	// Выгрузки, застрявшие в running с момента раньше staleBefore (упавший обработчик), берутся повторно.
+	// Если брать нечего, возвращает ErrExportJobNotFound.
+	ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (*domain.ExportJob, error)
+	CompleteExportJob(ctx context.Context, jobID string, archive []byte, completedAt, expiresAt time.Time) error
+	FailExportJob(ctx context.Context, jobID string, message string, completedAt, expiresAt time.Time) error
+	// DeleteExpiredExportJobs удаляет выгрузки с истекшим сроком хранения и возвращает их количество.
+	DeleteExpiredExportJobs(ctx context.Context, now time.Time) (int, error)
+}
+
+// FollowStore определяет операции с подписками пользователей друг на друга.
+// Списки и счетчики учитывают только активные аккаунты (не заблокированные и не ожидающие удаления).
+type FollowStore interface {
+	// Follow подписывает followerID на followeeID; повторная подписка не является ошибкой.
+	Follow(ctx context.Context, followerID, followeeID string) error
+	// Unfollow отменяет подписку; отсутствие подписки не является ошибкой.
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	// ListFollowers и ListFollowing возвращают страницу (новые подписки первыми) и общее количество.
+	ListFollowers(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error)
+	ListFollowing(ctx context.Context, userID string, page, pageSize int) ([]*domain.FollowEntry, int, error)
+	GetFollowCounts(ctx context.Context, userID string) (*domain.FollowCounts, error)
+}
+
+// RoleStore определяет операции с пользовательскими ролями (встроенные роли задаются в shared/authz).
+type RoleStore interface {
+	ListRoles(ctx context.Context) ([]*domain.Role, error)
+	// GetRole возвращает пользовательскую роль или ErrRoleNotFound.
+	GetRole(ctx context.Context, name string) (*domain.Role, error)
+	// SaveRole создает роль или заменяет описание и разрешения существующей.
+	SaveRole(ctx context.Context, role *domain.Role) error
+	// DeleteRole удаляет роль или возвращает ErrRoleNotFound.
+	DeleteRole(ctx context.Context, name string) error
 }
 
 // MockUserStore для начальной разработки и тестов
 type MockUserStore struct {
-	mu           sync.RWMutex
-	users        map[string]*domain.User // Ключ: UserID
-	usersByEmail map[string]*domain.User // Ключ: Email
+	mu            sync.RWMutex
+	users         map[string]*domain.User                   // Ключ: UserID
+	usersByEmail  map[string]*domain.User                   // Ключ: Email
+	refreshTokens map[string]*domain.RefreshToken           // Ключ: TokenHash
+	resetTokens   map[string]*domain.PasswordResetToken     // Ключ: TokenHash
+	verifyTokens  map[string]*domain.EmailVerificationToken // Ключ: TokenHash
+	loginAttempts map[string]*domain.LoginAttempts          // Ключ: account:<email> или ip:<addr>
+	totpSecrets   map[string]*domain.TOTPSecret             // Ключ: UserID
+	recoveryCodes map[string]map[string]bool                // UserID -> CodeHash -> использован
+	challenges    map[string]*domain.LoginChallenge         // Ключ: TokenHash
+	roles         map[string]*domain.Role                   // Ключ: имя пользовательской роли
+	userEvents    []*domain.UserEvent                       // Outbox событий пользователей, по возрастанию ID
+	exportJobs    map[string]*domain.ExportJob              // Ключ: ID выгрузки
+	follows       map[string]map[string]time.Time           // FollowerID -> FolloweeID -> время подписки
 }
 
 // NewMockUserStore создает новый экземпляр MockUserStore
 func NewMockUserStore() *MockUserStore {
 	m := &MockUserStore{
-		users:        make(map[string]*domain.User),
-		usersByEmail: make(map[string]*domain.User),
+		users:         make(map[string]*domain.User),
+		usersByEmail:  make(map[string]*domain.User),
+		refreshTokens: make(map[string]*domain.RefreshToken),
+		resetTokens:   make(map[string]*domain.PasswordResetToken),
+		verifyTokens:  make(map[string]*domain.EmailVerificationToken),
+		loginAttempts: make(map[string]*domain.LoginAttempts),
+		totpSecrets:   make(map[string]*domain.TOTPSecret),
+		recoveryCodes: make(map[string]map[string]bool),
+		challenges:    make(map[string]*domain.LoginChallenge),
+		roles:         make(map[string]*domain.Role),
+		exportJobs:    make(map[string]*domain.ExportJob),
+		follows:       make(map[string]map[string]time.Time),
 	}
 
 	// --- ДОБАВЛЯЕМ ПРЕДОПРЕДЕЛЕННОГО ПОЛЬЗОВАТЕЛЯ ---
 	predefinedUserID := "user-from-auth-token-123"
+	predefinedVerifiedAt := time.Now().Add(-48 * time.Hour)
 	predefinedUser := &domain.User{
 		ID:           predefinedUserID,
 		Username:     "HardcodedReviewer", // Имя, которое мы ожидаем увидеть
 		Email:        "reviewer@example.com",
 		PasswordHash: "somehash", // Не используется для GetUser
 		Role:         "user",
+		VerifiedAt:   &predefinedVerifiedAt,
 		CreatedAt:    time.Now().Add(-48 * time.Hour),
 		UpdatedAt:    time.Now().Add(-48 * time.Hour),
 	}
@@ -93,6 +300,37 @@ func (m *MockUserStore) GetByID(ctx context.Context, userID string) (*domain.Use
 	return nil, ErrUserNotFound
 }
 
+func (m *MockUserStore) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK USER STORE] Getting users by IDs: %d ID(s)\n", len(userIDs))
+	users := make([]*domain.User, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		user, ok := m.users[userID]
+		if !ok || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userCopy := *user
+		users = append(users, &userCopy)
+	}
+	return users, nil
+}
+
+func (m *MockUserStore) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	log.Printf("[MOCK USER STORE] Getting user by username: %s\n", username)
+	for _, user := range m.users {
+		if user.Username == username {
+			userCopy := *user
+			return &userCopy, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
 func (m *MockUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -132,6 +370,7 @@ func (m *MockUserStore) Update(ctx context.Context, user *domain.User) error {
 		}
 		delete(m.usersByEmail, existingUser.Email)
 		existingUser.Email = user.Email
+		existingUser.VerifiedAt = nil // Новый адрес нужно подтвердить заново
 		m.usersByEmail[existingUser.Email] = existingUser
 	}
 	if user.PasswordHash != "" {
@@ -140,8 +379,33 @@ func (m *MockUserStore) Update(ctx context.Context, user *domain.User) error {
 	if user.Role != "" {
 		existingUser.Role = user.Role
 	}
+	// Поля профиля можно очистить, поэтому копируются всегда
+	existingUser.DisplayName = user.DisplayName
+	existingUser.Bio = user.Bio
+	existingUser.AvatarURL = user.AvatarURL
+	existingUser.Location = user.Location
+	existingUser.FavoriteGenres = append(pq.StringArray(nil), user.FavoriteGenres...)
 
 	existingUser.UpdatedAt = time.Now().UTC()
 	m.users[user.ID] = existingUser
 	return nil
 }
+
+func (m *MockUserStore) SetEmailVerifiedAt(ctx context.Context, userID string, verifiedAt *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	log.Printf("[MOCK USER STORE] Setting email verified_at for user ID %s (verified=%t)\n", userID, verifiedAt != nil)
+
+	user, ok := m.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	if verifiedAt != nil {
+		t := *verifiedAt
+		user.VerifiedAt = &t
+	} else {
+		user.VerifiedAt = nil
+	}
+	user.UpdatedAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,60 @@
+// user-service/internal/worker/account_purger.go
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"user-service/internal/store"
+)
+
+// purgeBatchSize - сколько аккаунтов удаляется за одну транзакцию.
+const purgeBatchSize = 100
+
+// AccountPurger периодически удаляет аккаунты, срок отложенного удаления которых наступил.
+// Вместе с каждым аккаунтом в outbox записывается событие user.deleted, которое
+// review-service и movie-service забирают через gRPC ListUserEvents.
+type AccountPurger struct {
+	store    store.UserStore
+	logger   *slog.Logger
+	interval time.Duration
+}
+
+// NewAccountPurger создает AccountPurger, проверяющий аккаунты раз в interval.
+func NewAccountPurger(s store.UserStore, logger *slog.Logger, interval time.Duration) *AccountPurger {
+	return &AccountPurger{store: s, logger: logger, interval: interval}
+}
+
+// Run выполняет удаление до отмены ctx.
+func (p *AccountPurger) Run(ctx context.Context) {
+	p.logger.Info("Account purger started", slog.Duration("interval", p.interval))
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.purgeDue(ctx)
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Account purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeDue удаляет все аккаунты с наступившим сроком, пачками по purgeBatchSize.
+func (p *AccountPurger) purgeDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		purged, err := p.store.PurgeScheduledUsers(ctx, time.Now().UTC(), purgeBatchSize)
+		if err != nil {
+			p.logger.ErrorContext(ctx, "Failed to purge scheduled accounts", slog.String("error", err.Error()))
+			return
+		}
+		for _, userID := range purged {
+			p.logger.InfoContext(ctx, "Account permanently deleted", slog.String("userID", userID))
+		}
+		if len(purged) < purgeBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// user-service/internal/worker/export_worker.go
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"user-service/internal/export"
+	"user-service/internal/store"
+)
+
+const (
+	// exportJobTimeout ограничивает сборку одного архива.
+	exportJobTimeout = 5 * time.Minute
+	// exportJobStaleAfter - через сколько выгрузка в running считается брошенной (упал обработчик).
+	exportJobStaleAfter = 3 * exportJobTimeout
+)
+
+// ExportWorker выполняет асинхронные выгрузки персональных данных и удаляет
+// архивы, срок хранения которых истек.
+type ExportWorker struct {
+	store     store.UserStore
+	builder   *export.Builder
+	logger    *slog.Logger
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewExportWorker создает ExportWorker, проверяющий очередь раз в interval.
+// Готовые (и неудавшиеся) выгрузки хранятся retention.
+func NewExportWorker(s store.UserStore, builder *export.Builder, logger *slog.Logger, interval, retention time.Duration) *ExportWorker {
+	return &ExportWorker{store: s, builder: builder, logger: logger, interval: interval, retention: retention}
+}
+
+// Run обрабатывает очередь до отмены ctx.
+func (w *ExportWorker) Run(ctx context.Context) {
+	w.logger.Info("Export worker started", slog.Duration("interval", w.interval))
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.processPending(ctx)
+		w.deleteExpired(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Export worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processPending выполняет выгрузки, пока очередь не опустеет.
+func (w *ExportWorker) processPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		job, err := w.store.ClaimExportJob(ctx, now, now.Add(-exportJobStaleAfter))
+		if err != nil {
+			if !errors.Is(err, store.ErrExportJobNotFound) {
+				w.logger.ErrorContext(ctx, "Failed to claim export job", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		jobCtx, cancel := context.WithTimeout(ctx, exportJobTimeout)
+		archive, buildErr := w.builder.Build(jobCtx, job.UserID, job.Format)
+		cancel()
+
+		finishedAt := time.Now().UTC()
+		if buildErr != nil {
+			w.logger.ErrorContext(ctx, "Export job failed", slog.String("jobID", job.ID), slog.String("userID", job.UserID), slog.String("error", buildErr.Error()))
+			if err := w.store.FailExportJob(ctx, job.ID, "Failed to collect data, please request a new export", finishedAt, finishedAt.Add(w.retention)); err != nil {
+				w.logger.ErrorContext(ctx, "Failed to mark export job failed", slog.String("jobID", job.ID), slog.String("error", err.Error()))
+			}
+			continue
+		}
+		if err := w.store.CompleteExportJob(ctx, job.ID, archive, finishedAt, finishedAt.Add(w.retention)); err != nil {
+			w.logger.ErrorContext(ctx, "Failed to save export archive", slog.String("jobID", job.ID), slog.String("error", err.Error()))
+			continue
+		}
+		w.logger.InfoContext(ctx, "Export job completed", slog.String("jobID", job.ID), slog.String("userID", job.UserID), slog.Int("bytes", len(archive)))
+	}
+}
+
+// deleteExpired удаляет выгрузки с истекшим сроком хранения.
+func (w *ExportWorker) deleteExpired(ctx context.Context) {
+	deleted, err := w.store.DeleteExpiredExportJobs(ctx, time.Now().UTC())
+	if err != nil {
+		w.logger.ErrorContext(ctx, "Failed to delete expired export jobs", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		w.logger.InfoContext(ctx, "Expired export jobs deleted", slog.Int("count", deleted))
+	}
+}
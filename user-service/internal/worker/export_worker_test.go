@@ -0,0 +1,117 @@
+// user-service/internal/worker/export_worker_test.go
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/export"
+	"user-service/internal/genproto/moviepb"
+	"user-service/internal/genproto/reviewpb"
+	"user-service/internal/store"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMovieClient и fakeReviewClient отдают заранее заданные данные вместо gRPC вызовов.
+type fakeMovieClient struct {
+	movies []*moviepb.SubmittedMovie
+	err    error
+}
+
+func (c *fakeMovieClient) ListMoviesBySubmitter(ctx context.Context, userID string) ([]*moviepb.SubmittedMovie, error) {
+	return c.movies, c.err
+}
+
+func (c *fakeMovieClient) Close() error { return nil }
+
+type fakeReviewClient struct {
+	reviews []*reviewpb.Review
+	err     error
+}
+
+func (c *fakeReviewClient) ListReviewsByUser(ctx context.Context, userID string) ([]*reviewpb.Review, error) {
+	return c.reviews, c.err
+}
+
+func (c *fakeReviewClient) Close() error { return nil }
+
+func TestExportWorkerProcessPending(t *testing.T) {
+	now := timestamppb.Now()
+	deletedAt := timestamppb.New(time.Now().Add(-time.Hour))
+	tests := []struct {
+		name       string
+		reviewsErr error
+		wantStatus domain.ExportJobStatus
+	}{
+		{name: "completed", wantStatus: domain.ExportJobCompleted},
+		{name: "review service unavailable", reviewsErr: errors.New("unavailable"), wantStatus: domain.ExportJobFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userStore := store.NewMockUserStore()
+			user := &domain.User{ID: uuid.NewString(), Username: "exporter", Email: "exporter@example.com", Role: "user"}
+			if err := userStore.Create(ctx, user); err != nil {
+				t.Fatalf("Create user: %v", err)
+			}
+			movies := &fakeMovieClient{movies: []*moviepb.SubmittedMovie{
+				{Id: "movie-1", Title: "Live", Status: "approved", CreatedAt: now, UpdatedAt: now},
+				{Id: "movie-2", Title: "Removed", Status: "approved", CreatedAt: now, UpdatedAt: now, DeletedAt: deletedAt},
+			}}
+			reviews := &fakeReviewClient{reviews: []*reviewpb.Review{{Id: "review-1", MovieId: "movie-1", Rating: 4, CreatedAt: now, UpdatedAt: now}}, err: tt.reviewsErr}
+			job := &domain.ExportJob{ID: uuid.NewString(), UserID: user.ID, Format: domain.ExportFormatJSON, Status: domain.ExportJobPending, CreatedAt: time.Now().UTC()}
+			if err := userStore.CreateExportJob(ctx, job); err != nil {
+				t.Fatalf("CreateExportJob: %v", err)
+			}
+
+			w := NewExportWorker(userStore, export.NewBuilder(userStore, movies, reviews), slog.New(slog.NewTextHandler(io.Discard, nil)), time.Minute, time.Hour)
+			w.processPending(ctx)
+
+			got, err := userStore.GetExportJob(ctx, job.ID)
+			if err != nil {
+				t.Fatalf("GetExportJob: %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got.CompletedAt == nil || got.ExpiresAt == nil || !got.ExpiresAt.Equal(got.CompletedAt.Add(time.Hour)) {
+				t.Errorf("completed_at = %v, expires_at = %v, want expiry one retention period after completion", got.CompletedAt, got.ExpiresAt)
+			}
+
+			archive, err := userStore.GetExportArchive(ctx, job.ID)
+			if tt.wantStatus == domain.ExportJobFailed {
+				if got.Error == "" {
+					t.Error("failed job has no error message")
+				}
+				if !errors.Is(err, store.ErrExportJobNotFound) {
+					t.Errorf("GetExportArchive for failed job: err = %v, want ErrExportJobNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetExportArchive: %v", err)
+			}
+			var data domain.UserDataExport
+			if err := json.Unmarshal(archive, &data); err != nil {
+				t.Fatalf("decode archive: %v", err)
+			}
+			if data.Profile == nil || data.Profile.ID != user.ID {
+				t.Errorf("archive profile = %+v, want user %s", data.Profile, user.ID)
+			}
+			if len(data.Reviews) != 1 || len(data.SubmittedMovies) != 2 {
+				t.Fatalf("archive has %d reviews and %d movies, want 1 and 2", len(data.Reviews), len(data.SubmittedMovies))
+			}
+			if data.SubmittedMovies[0].DeletedAt != nil || data.SubmittedMovies[1].DeletedAt == nil {
+				t.Errorf("deleted_at = %v, %v; want only the removed movie marked deleted", data.SubmittedMovies[0].DeletedAt, data.SubmittedMovies[1].DeletedAt)
+			}
+		})
+	}
+}
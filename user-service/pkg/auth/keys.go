@@ -0,0 +1,152 @@
+// user-service/pkg/auth/keys.go
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Поддерживаемые алгоритмы подписи JWT.
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// minRSAKeyBits - минимальный допустимый размер RSA ключа.
+const minRSAKeyBits = 2048
+
+// SigningKey - приватный ключ для подписи JWT вместе с его идентификатором (kid).
+type SigningKey struct {
+	KeyID      string
+	Algorithm  string // AlgRS256 или AlgEdDSA
+	PrivateKey crypto.Signer
+}
+
+// JWK - публичный ключ в формате JSON Web Key (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKS - набор публичных ключей, который отдается на /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadSigningKeys загружает все приватные ключи (*.pem, PKCS#8 или PKCS#1 для RSA) из каталога.
+// Имя файла без расширения используется как kid.
+func LoadSigningKeys(dir string) ([]SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	keys := make([]SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key %s: %w", path, err)
+		}
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		key, err := ParseSigningKeyPEM(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no *.pem signing keys found in %s", dir)
+	}
+	return keys, nil
+}
+
+// ParseSigningKeyPEM разбирает приватный RSA или Ed25519 ключ в формате PEM.
+func ParseSigningKeyPEM(kid string, data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return SigningKey{}, fmt.Errorf("RSA key is too short: %d bits (min %d)", k.N.BitLen(), minRSAKeyBits)
+		}
+		return SigningKey{KeyID: kid, Algorithm: AlgRS256, PrivateKey: k}, nil
+	case ed25519.PrivateKey:
+		return SigningKey{KeyID: kid, Algorithm: AlgEdDSA, PrivateKey: k}, nil
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported private key type %T (expected RSA or Ed25519)", parsed)
+	}
+}
+
+// GenerateEd25519SigningKey создает временный Ed25519 ключ со случайным kid.
+// Используется только для локальной разработки, когда ключи не настроены.
+func GenerateEd25519SigningKey() (SigningKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+	}
+	kidBytes := make([]byte, 8)
+	if _, err := rand.Read(kidBytes); err != nil {
+		return SigningKey{}, fmt.Errorf("failed to generate key id: %w", err)
+	}
+	return SigningKey{KeyID: "dev-" + hex.EncodeToString(kidBytes), Algorithm: AlgEdDSA, PrivateKey: priv}, nil
+}
+
+// PublicJWK возвращает публичную часть ключа в формате JWK.
+func (k SigningKey) PublicJWK() (JWK, error) {
+	switch pub := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: k.KeyID,
+			Use: "sig",
+			Alg: AlgRS256,
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: k.KeyID,
+			Use: "sig",
+			Alg: AlgEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}, nil
+	default:
+		return JWK{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
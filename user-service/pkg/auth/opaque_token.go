@@ -0,0 +1,32 @@
+// user-service/pkg/auth/opaque_token.go
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// opaqueTokenBytes - количество случайных байт в непрозрачном токене.
+const opaqueTokenBytes = 32
+
+// GenerateOpaqueToken создает новый случайный непрозрачный токен
+// (refresh токен, токен сброса пароля и т.п.).
+// Возвращает сам токен (отдается клиенту) и его хеш (сохраняется в БД).
+func GenerateOpaqueToken() (token string, tokenHash string, err error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken возвращает SHA-256 хеш токена в hex.
+// Токен обладает высокой энтропией, поэтому медленный хеш (bcrypt) здесь не нужен.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
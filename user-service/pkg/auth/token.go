@@ -10,48 +10,88 @@ import (
 
 // TokenManager предоставляет методы для генерации и валидации JWT токенов.
 type TokenManager interface {
-	Generate(userID string, userRole string) (string, error)
+	Generate(userID string, userRole string, permissions []string, emailVerified bool) (string, error)
 	Validate(tokenString string) (*Claims, error)
+	TokenDuration() time.Duration // Время жизни access токена
+	JWKS() JWKS                   // Публичные ключи для проверки токенов другими сервисами
 }
 
-// jwtManager реализует TokenManager.
+// jwtManager реализует TokenManager с асимметричной подписью (RS256/EdDSA).
 type jwtManager struct {
-	secretKey     []byte        // Секретный ключ для подписи токенов
-	tokenDuration time.Duration // Длительность жизни токена
+	keys          map[string]SigningKey // Все ключи, которыми можно проверять токены (ключ: kid)
+	activeKey     SigningKey            // Ключ, которым подписываются новые токены
+	jwks          JWKS                  // Публичные части всех ключей
+	tokenDuration time.Duration         // Длительность жизни токена
 }
 
 // Claims определяет структуру данных, хранимых в JWT.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	UserID        string   `json:"user_id"`
+	Role          string   `json:"role"`
+	Permissions   []string `json:"permissions,omitempty"` // Разрешения роли на момент выпуска токена (см. shared/authz)
+	EmailVerified bool     `json:"email_verified"`        // Подтвержден ли email (политику применяют сервисы-потребители)
 	jwt.RegisteredClaims
 }
 
 // NewTokenManager создает новый экземпляр jwtManager.
-// secretKey должен быть достаточно сложным и храниться безопасно.
-// tokenDuration - например, time.Hour * 24 для токена, живущего 24 часа.
-func NewTokenManager(secretKey string, tokenDuration time.Duration) (TokenManager, error) {
-	if secretKey == "" {
-		return nil, fmt.Errorf("JWT secret key cannot be empty")
+// keys - все действующие ключи; новые токены подписываются ключом activeKeyID,
+// остальные ключи используются только для проверки (ротация без разлогинивания пользователей).
+// tokenDuration - например, time.Minute * 15 для короткоживущего access токена.
+func NewTokenManager(keys []SigningKey, activeKeyID string, tokenDuration time.Duration) (TokenManager, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one JWT signing key is required")
 	}
-	if len(secretKey) < 32 { // Рекомендуется минимальная длина для HMAC-SHA256
-		// В реальном приложении здесь может быть более строгая проверка или генерация ключа
-		// Для примера, мы не будем вызывать ошибку, но в проде это важно.
-		// return nil, fmt.Errorf("JWT secret key is too short (recommended min 32 bytes for HS256)")
-		fmt.Printf("Warning: JWT secret key is short. For production, use a key of at least 32 bytes for HS256.\n")
-	}
-	return &jwtManager{
-		secretKey:     []byte(secretKey),
+	m := &jwtManager{
+		keys:          make(map[string]SigningKey, len(keys)),
 		tokenDuration: tokenDuration,
-	}, nil
+		jwks:          JWKS{Keys: make([]JWK, 0, len(keys))},
+	}
+	for _, key := range keys {
+		if key.KeyID == "" {
+			return nil, fmt.Errorf("JWT signing key must have a key ID")
+		}
+		if _, exists := m.keys[key.KeyID]; exists {
+			return nil, fmt.Errorf("duplicate JWT key ID %q", key.KeyID)
+		}
+		jwk, err := key.PublicJWK()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.KeyID, err)
+		}
+		m.keys[key.KeyID] = key
+		m.jwks.Keys = append(m.jwks.Keys, jwk)
+	}
+	active, ok := m.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active JWT key ID %q not found among loaded keys", activeKeyID)
+	}
+	m.activeKey = active
+	return m, nil
 }
 
-// Generate создает новый JWT токен для указанного userID и userRole.
-func (m *jwtManager) Generate(userID string, userRole string) (string, error) {
+// signingMethod возвращает метод подписи jwt для алгоритма ключа.
+func signingMethod(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case AlgRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+// Generate создает новый JWT токен для указанного userID, userRole и разрешений роли.
+func (m *jwtManager) Generate(userID string, userRole string, permissions []string, emailVerified bool) (string, error) {
+	method, err := signingMethod(m.activeKey.Algorithm)
+	if err != nil {
+		return "", err
+	}
 	expirationTime := time.Now().Add(m.tokenDuration)
 	claims := &Claims{
-		UserID: userID,
-		Role:   userRole,
+		UserID:        userID,
+		Role:          userRole,
+		Permissions:   permissions,
+		EmailVerified: emailVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -59,24 +99,40 @@ func (m *jwtManager) Generate(userID string, userRole string) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(m.secretKey)
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = m.activeKey.KeyID
+	tokenString, err := token.SignedString(m.activeKey.PrivateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
 	return tokenString, nil
 }
 
+// TokenDuration возвращает время жизни выпускаемых access токенов.
+func (m *jwtManager) TokenDuration() time.Duration {
+	return m.tokenDuration
+}
+
+// JWKS возвращает публичные ключи всех действующих ключей подписи.
+func (m *jwtManager) JWKS() JWKS {
+	return m.jwks
+}
+
 // Validate проверяет JWT токен и возвращает извлеченные из него Claims.
 func (m *jwtManager) Validate(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		if token.Method.Alg() != key.Algorithm {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return m.secretKey, nil
-	})
+		return key.PrivateKey.Public(), nil
+	}, jwt.WithValidMethods([]string{AlgRS256, AlgEdDSA}))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -87,4 +143,3 @@ func (m *jwtManager) Validate(tokenString string) (*Claims, error) {
 	}
 	return claims, nil
 }
-   
\ No newline at end of file
@@ -0,0 +1,68 @@
+// user-service/pkg/auth/totp_test.go
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// Секрет из тестовых векторов RFC 6238 ("12345678901234567890") в base32.
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFCVectors(t *testing.T) {
+	// RFC 6238, приложение B (SHA1): 8-значные коды, нам нужны последние 6 цифр
+	key, err := totpEncoding.DecodeString(rfcTOTPSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		if got := totpCode(key, unix/int64(totpPeriod.Seconds())); got != want {
+			t.Errorf("totpCode(t=%d) = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestValidateTOTPWindow(t *testing.T) {
+	key, _ := totpEncoding.DecodeString(rfcTOTPSecret)
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / int64(totpPeriod.Seconds())
+
+	tests := []struct {
+		name     string
+		code     string
+		secret   string
+		wantOK   bool
+		wantStep int64
+	}{
+		{name: "current step", code: totpCode(key, current), wantOK: true, wantStep: current},
+		{name: "previous step within skew", code: totpCode(key, current-1), wantOK: true, wantStep: current - 1},
+		{name: "next step within skew", code: totpCode(key, current+1), wantOK: true, wantStep: current + 1},
+		{name: "two steps behind", code: totpCode(key, current-2), wantOK: false},
+		{name: "two steps ahead", code: totpCode(key, current+2), wantOK: false},
+		{name: "wrong length", code: "12345", wantOK: false},
+		{name: "lowercase secret accepted", code: totpCode(key, current), secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", wantOK: true, wantStep: current},
+		{name: "invalid secret", code: totpCode(key, current), secret: "not base32!", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := tt.secret
+			if secret == "" {
+				secret = rfcTOTPSecret
+			}
+			step, ok := ValidateTOTP(secret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ValidateTOTP() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("ValidateTOTP() step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}